@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/google/uuid"
+	"gopher-cache/internal/common/errors"
+	"time"
+)
+
+// APIKeyHeader is the header machine clients send their API key in.
+const APIKeyHeader = "X-API-Key"
+
+const (
+	// apiKeyPrefix starts every key so leaked keys are easy to find, e.g. by secret scanners.
+	apiKeyPrefix = "gc_"
+	// apiKeyBytes is how many random bytes a key has.
+	apiKeyBytes = 32
+	// apiKeyShownLength is how much of the start of a key is kept so admins can tell keys apart.
+	apiKeyShownLength = len(apiKeyPrefix) + 8
+)
+
+// APIKey is a key that machine clients such as the SMS gateway authenticate with instead of an ID
+// token. Only a hash of the key is kept so keys can't be recovered from storage.
+type APIKey struct {
+	ID   string
+	Name string
+	// Prefix is the start of the key.
+	Prefix string
+	Hash   string
+	// Scopes limit what clients using the key can do.
+	Scopes    []string
+	CreatedAt time.Time
+	// ExpiresAt is the zero time if the key doesn't expire.
+	ExpiresAt time.Time
+	// RevokedAt is the zero time if the key hasn't been revoked.
+	RevokedAt time.Time
+}
+
+// NewAPIKey creates a new key and returns it with the secret key, which is only available now.
+// expiresAt is the zero time if the key doesn't expire.
+func NewAPIKey(name string, scopes []string, expiresAt time.Time) (APIKey, string, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return APIKey{}, "", err
+	}
+
+	b := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return APIKey{}, "", err
+	}
+
+	secret := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	return APIKey{
+		ID:        id.String(),
+		Name:      name,
+		Prefix:    secret[:apiKeyShownLength],
+		Hash:      HashAPIKey(secret),
+		Scopes:    scopes,
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: expiresAt,
+	}, secret, nil
+}
+
+// HashAPIKey returns the hash keys are stored and looked up by. Keys are random so they don't need a
+// slow or salted hash like passwords do.
+func HashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Active reports whether the key can be used at the time.
+func (k APIKey) Active(at time.Time) bool {
+	if !k.RevokedAt.IsZero() {
+		return false
+	}
+
+	return k.ExpiresAt.IsZero() || at.Before(k.ExpiresAt)
+}
+
+// User returns the user that requests made with the key are authenticated as.
+func (k APIKey) User() User {
+	scopes := make([]string, len(k.Scopes))
+	copy(scopes, k.Scopes)
+
+	return User{
+		UUID:        "api-key:" + k.ID,
+		DisplayName: k.Name,
+		Scopes:      scopes,
+	}
+}
+
+var (
+	ErrorAPIKeyNotFound = errors.NewAuthenticationError("api key not found", "invalid-api-key")
+	ErrorAPIKeyInactive = errors.NewAuthenticationError("api key has expired or been revoked", "inactive-api-key")
+	// ErrorAPIKeyAlreadyExists is returned by stores when a key is added with the id of an existing key.
+	ErrorAPIKeyAlreadyExists = errors.NewConflictError("api key already exists", "api-key-already-exists")
+)
+
+// APIKeyStore is the interface used for finding the key a request was made with.
+type APIKeyStore interface {
+	// GetAPIKeyByHash returns the key with the hash, including revoked and expired keys. It returns
+	// ErrorAPIKeyNotFound if there is no such key.
+	GetAPIKeyByHash(ctx context.Context, hash string) (APIKey, error)
+}
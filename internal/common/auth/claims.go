@@ -0,0 +1,91 @@
+package auth
+
+import "strings"
+
+// ClaimNames are the names of the token claims that the fields of a User are read from. Identity
+// providers put the same information in different claims, e.g. Keycloak puts roles in
+// realm_access.roles and Auth0 requires custom claims to be namespaced with a URL.
+//
+// A name is first looked up as a claim. If there is no such claim and the name has dots it is looked up
+// as a path into nested claims, so realm_access.roles is the roles claim in the realm_access claim.
+type ClaimNames struct {
+	UUID        string
+	DisplayName string
+	Email       string
+	Number      string
+	Roles       string
+}
+
+// DefaultClaimNames are the claims used by the Firebase, mock and local tokens.
+var DefaultClaimNames = ClaimNames{
+	UUID:        "sub",
+	DisplayName: "name",
+	Email:       "email",
+	Number:      "number",
+	Roles:       "roles",
+}
+
+// withDefaults returns the names with the DefaultClaimNames in place of those that are empty.
+func (n ClaimNames) withDefaults() ClaimNames {
+	defaultTo := func(name *string, d string) {
+		if *name == "" {
+			*name = d
+		}
+	}
+
+	defaultTo(&n.UUID, DefaultClaimNames.UUID)
+	defaultTo(&n.DisplayName, DefaultClaimNames.DisplayName)
+	defaultTo(&n.Email, DefaultClaimNames.Email)
+	defaultTo(&n.Number, DefaultClaimNames.Number)
+	defaultTo(&n.Roles, DefaultClaimNames.Roles)
+
+	return n
+}
+
+// claim returns the value of the claim with the name or nil if there isn't one.
+func claim(claims map[string]interface{}, name string) interface{} {
+	if v, ok := claims[name]; ok {
+		return v
+	}
+
+	path := strings.Split(name, ".")
+	if len(path) == 1 {
+		return nil
+	}
+
+	var v interface{} = claims
+	for _, key := range path {
+		nested, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		v = nested[key]
+	}
+
+	return v
+}
+
+// stringClaim returns the claim with the name if it is a string.
+func stringClaim(claims map[string]interface{}, name string) (string, bool) {
+	s, ok := claim(claims, name).(string)
+	return s, ok
+}
+
+// rolesFromClaim returns the roles in a roles claim, which must be a list of strings. Anything else in
+// the list is ignored.
+func rolesFromClaim(v interface{}) []string {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var roles []string
+	for _, v := range list {
+		if role, ok := v.(string); ok {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles
+}
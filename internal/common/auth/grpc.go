@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strings"
+)
+
+// GrpcAuthInterceptor performs authentication of gRPC calls with the same credentials as HTTP requests:
+// a bearer token in the authorization metadata or an API key in the x-api-key metadata.
+type GrpcAuthInterceptor struct {
+	Tokens TokenAuthenticator
+	// APIKeys finds the keys of calls with an API key. API keys aren't accepted if it is nil.
+	APIKeys APIKeyStore
+}
+
+// Unary will place a User type into the context of unary calls after successfully authenticating them.
+func (a GrpcAuthInterceptor) Unary(
+	ctx context.Context,
+	req interface{},
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	ctx, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// Stream will place a User type into the context of streaming calls after successfully authenticating them.
+func (a GrpcAuthInterceptor) Stream(
+	srv interface{},
+	ss grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := a.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+func (a GrpcAuthInterceptor) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if secret := firstMetadataValue(md, APIKeyHeader); secret != "" && a.APIKeys != nil {
+		u, err := AuthenticateAPIKey(ctx, a.APIKeys, secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return ContextWithUser(ctx, u), nil
+	}
+
+	u, err := a.Tokens.AuthenticateToken(ctx, tokenFromMetadata(md))
+	if err != nil {
+		return nil, err
+	}
+
+	return ContextWithUser(ctx, u), nil
+}
+
+// tokenFromMetadata returns the bearer token in the authorization metadata or an empty string if there
+// isn't one.
+func tokenFromMetadata(md metadata.MD) string {
+	value := firstMetadataValue(md, "authorization")
+
+	if len(value) > 7 && strings.ToLower(value[0:6]) == "bearer" {
+		return value[7:]
+	}
+
+	return ""
+}
+
+// firstMetadataValue returns the first value of key, whose case doesn't matter, or an empty string.
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// authenticatedStream is a ServerStream whose context has the authenticated user.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"gopher-cache/internal/common/errors"
+	"testing"
+	"time"
+)
+
+func TestGrpcAuthInterceptor(t *testing.T) {
+	key, secret, err := NewAPIKey("SMS gateway", []string{"play-games"}, time.Time{})
+	require.NoError(t, err)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"id":     "player-1",
+		"name":   "Player",
+		"email":  "player@example.com",
+		"number": "+15734497033",
+	}).SignedString([]byte("mock_secret"))
+	require.NoError(t, err)
+
+	interceptor := GrpcAuthInterceptor{Tokens: MockTokenAuthenticator{}, APIKeys: apiKeyStore{key.ID: key}}
+
+	call := func(interceptor GrpcAuthInterceptor, md metadata.MD) (User, error) {
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+
+		resp, err := interceptor.Unary(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			return UserFromContext(ctx)
+		})
+		if err != nil {
+			return User{}, err
+		}
+
+		return resp.(User), nil
+	}
+
+	t.Run("token", func(t *testing.T) {
+		u, err := call(interceptor, metadata.Pairs("authorization", "Bearer "+token))
+		require.NoError(t, err)
+
+		assert.Equal(t, "player-1", u.UUID)
+		assert.Equal(t, "+15734497033", u.Number)
+	})
+
+	t.Run("api key", func(t *testing.T) {
+		u, err := call(interceptor, metadata.Pairs("x-api-key", secret))
+		require.NoError(t, err)
+
+		assert.Equal(t, key.User(), u)
+	})
+
+	t.Run("api keys not accepted", func(t *testing.T) {
+		_, err := call(GrpcAuthInterceptor{Tokens: MockTokenAuthenticator{}}, metadata.Pairs("x-api-key", secret))
+
+		assertAuthenticationError(t, err)
+	})
+
+	t.Run("unknown api key", func(t *testing.T) {
+		_, err := call(interceptor, metadata.Pairs("x-api-key", "unknown", "authorization", "Bearer "+token))
+
+		assert.Equal(t, ErrorAPIKeyNotFound, err)
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		_, err := call(interceptor, metadata.MD{})
+
+		assertAuthenticationError(t, err)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+
+		err := interceptor.Stream(nil, contextStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(_ interface{}, ss grpc.ServerStream) error {
+			u, err := UserFromContext(ss.Context())
+			require.NoError(t, err)
+			assert.Equal(t, "player-1", u.UUID)
+
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func assertAuthenticationError(t *testing.T, err error) {
+	slugError, ok := err.(errors.SlugError)
+	require.True(t, ok, err)
+	assert.Equal(t, errors.ErrorTypeAuthentication, slugError.ErrorType())
+}
+
+// contextStream is a ServerStream that only has a context.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s contextStream) Context() context.Context {
+	return s.ctx
+}
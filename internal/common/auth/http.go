@@ -13,57 +13,82 @@ import (
 // FirebaseHttpMiddleware performs authentications using Firebase.
 type FirebaseHttpMiddleware struct {
 	AuthClient *auth.Client
+	// Claims are the claims the user is read from. The DefaultClaimNames are used for names that are
+	// empty. The UUID is always the Firebase UID.
+	Claims ClaimNames
 }
 
 // Middleware will place a User type into the context after successfully authenticating
 // with Firebase.
 func (a FirebaseHttpMiddleware) Middleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-
-		bearerToken := a.tokenFromHeader(r)
-		if bearerToken == "" {
-			httperr.Unauthorised("empty-bearer-token", nil, w, r)
-			return
-		}
-
-		token, err := a.AuthClient.VerifyIDToken(ctx, bearerToken)
-		if err != nil {
-			httperr.Unauthorised("unable-to-verify-jwt", err, w, r)
-			return
-		}
-
-		name, ok := token.Claims["name"].(string)
-		if !ok {
-			httperr.InternalError("invalid-token", nil, w, r)
-			return
-		}
-
-		email, ok := token.Claims["email"].(string)
-		if !ok {
-			httperr.InternalError("invalid-token", nil, w, r)
-			return
-		}
-
-		number, ok := token.Claims["number"].(string)
-		if !ok {
-			httperr.InternalError("invalid-token", nil, w, r)
-			return
-		}
-
-		ctx = context.WithValue(ctx, userContextKey, User{
-			UUID:        token.UID,
-			DisplayName: name,
-			Email:       email,
-			Number:      number,
-		})
-		r = r.WithContext(ctx)
+	return TokenHttpMiddleware(a)(next)
+}
+
+// AuthenticateToken returns the user of a Firebase ID token.
+func (a FirebaseHttpMiddleware) AuthenticateToken(ctx context.Context, bearerToken string) (User, error) {
+	if bearerToken == "" {
+		return User{}, ErrorEmptyBearerToken
+	}
+
+	token, err := a.AuthClient.VerifyIDToken(ctx, bearerToken)
+	if err != nil {
+		return User{}, errors.NewAuthenticationError(err.Error(), "unable-to-verify-jwt")
+	}
 
-		next.ServeHTTP(w, r)
-	})
+	claimNames := a.Claims.withDefaults()
+
+	// Firebase tokens are missing claims only if the project is misconfigured, so it isn't the caller's fault.
+	name, ok := stringClaim(token.Claims, claimNames.DisplayName)
+	if !ok {
+		return User{}, errors.NewSlugError("token has no "+claimNames.DisplayName+" claim", "invalid-token")
+	}
+
+	email, ok := stringClaim(token.Claims, claimNames.Email)
+	if !ok {
+		return User{}, errors.NewSlugError("token has no "+claimNames.Email+" claim", "invalid-token")
+	}
+
+	number, ok := stringClaim(token.Claims, claimNames.Number)
+	if !ok {
+		return User{}, errors.NewSlugError("token has no "+claimNames.Number+" claim", "invalid-token")
+	}
+
+	return User{
+		UUID:        token.UID,
+		DisplayName: name,
+		Email:       email,
+		Number:      number,
+		Roles:       rolesFromClaim(claim(token.Claims, claimNames.Roles)),
+	}, nil
+}
+
+// TokenAuthenticator returns the user of a bearer token. The HTTP middlewares for tokens implement it so
+// the same verification can be used by servers of other protocols, e.g. gRPC.
+type TokenAuthenticator interface {
+	// AuthenticateToken returns an authentication error if the token is empty or invalid.
+	AuthenticateToken(ctx context.Context, bearerToken string) (User, error)
 }
 
-func (a FirebaseHttpMiddleware) tokenFromHeader(r *http.Request) string {
+// TokenHttpMiddleware returns a middleware placing the user of the bearer token in the Authorization header
+// into the context of requests if the token is authenticated by a.
+func TokenHttpMiddleware(a TokenAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			u, err := a.AuthenticateToken(r.Context(), tokenFromHeader(r))
+			if err != nil {
+				httperr.RespondWithSlugError(err, w, r)
+				return
+			}
+
+			r = r.WithContext(ContextWithUser(r.Context(), u))
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// tokenFromHeader returns the bearer token in the Authorization header or an empty string if there isn't one.
+func tokenFromHeader(r *http.Request) string {
 	headerValue := r.Header.Get("Authorization")
 
 	if len(headerValue) > 7 && strings.ToLower(headerValue[0:6]) == "bearer" {
@@ -79,6 +104,12 @@ type User struct {
 	DisplayName string
 	Email       string
 	Number      string
+	// Roles come from the roles claim, which is set as a custom claim in Firebase. It is empty if
+	// the token has no roles.
+	Roles []string
+	// Scopes limit what a client authenticated with an API key can do. They are nil for users
+	// authenticated with a token.
+	Scopes []string
 }
 
 type ctxKey int
@@ -88,9 +119,14 @@ const (
 )
 
 var (
-	ErrorNoUserInContext = errors.NewAuthorizationError("context has no user", "missing-context-user")
+	ErrorNoUserInContext = errors.NewAuthenticationError("context has no user", "missing-context-user")
 )
 
+// ContextWithUser returns a copy of ctx with the authenticated user u, which is returned by UserFromContext.
+func ContextWithUser(ctx context.Context, u User) context.Context {
+	return context.WithValue(ctx, userContextKey, u)
+}
+
 // UserFromContext will check the context for an authenticated user.
 // If none is found then ErrorNoUserInContext will be returned.
 func UserFromContext(ctx context.Context) (User, error) {
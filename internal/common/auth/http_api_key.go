@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"gopher-cache/internal/common/server/httperr"
+	"net/http"
+	"time"
+)
+
+// APIKeyHttpMiddleware performs authentication with API keys given in the X-API-Key header.
+type APIKeyHttpMiddleware struct {
+	Keys APIKeyStore
+	// Fallback authenticates requests that have no API key, e.g. with an ID token. Requests without a
+	// key are rejected if it is nil.
+	Fallback func(http.Handler) http.Handler
+}
+
+// Middleware will place the User of the API key into the context after successfully finding an active
+// key. The user has the scopes of the key and no number or roles.
+func (a APIKeyHttpMiddleware) Middleware(next http.Handler) http.Handler {
+	var fallback http.Handler
+	if a.Fallback != nil {
+		fallback = a.Fallback(next)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret := r.Header.Get(APIKeyHeader)
+		if secret == "" {
+			if fallback == nil {
+				httperr.Unauthorised("empty-api-key", nil, w, r)
+				return
+			}
+
+			fallback.ServeHTTP(w, r)
+			return
+		}
+
+		u, err := AuthenticateAPIKey(r.Context(), a.Keys, secret)
+		if err != nil {
+			httperr.RespondWithSlugError(err, w, r)
+			return
+		}
+
+		r = r.WithContext(ContextWithUser(r.Context(), u))
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AuthenticateAPIKey returns the user of the active API key in keys with the given secret.
+func AuthenticateAPIKey(ctx context.Context, keys APIKeyStore, secret string) (User, error) {
+	key, err := keys.GetAPIKeyByHash(ctx, HashAPIKey(secret))
+	if err != nil {
+		return User{}, err
+	}
+
+	if !key.Active(time.Now()) {
+		return User{}, ErrorAPIKeyInactive
+	}
+
+	return key.User(), nil
+}
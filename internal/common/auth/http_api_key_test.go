@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/common/logs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type apiKeyStore map[string]APIKey
+
+func (s apiKeyStore) GetAPIKeyByHash(_ context.Context, hash string) (APIKey, error) {
+	for _, k := range s {
+		if k.Hash == hash {
+			return k, nil
+		}
+	}
+
+	return APIKey{}, ErrorAPIKeyNotFound
+}
+
+func TestAPIKeyHttpMiddleware(t *testing.T) {
+	active, activeSecret, err := NewAPIKey("SMS gateway", []string{"play-games"}, time.Time{})
+	require.NoError(t, err)
+
+	revoked, revokedSecret, err := NewAPIKey("Revoked", []string{"play-games"}, time.Time{})
+	require.NoError(t, err)
+	revoked.RevokedAt = time.Now().Add(-time.Minute)
+
+	expired, expiredSecret, err := NewAPIKey("Expired", []string{"play-games"}, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+
+	keys := apiKeyStore{active.ID: active, revoked.ID: revoked, expired.ID: expired}
+
+	fallback := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), userContextKey, User{UUID: "fallback"})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	newHandler := func(fallback func(http.Handler) http.Handler) http.Handler {
+		middleware := APIKeyHttpMiddleware{Keys: keys, Fallback: fallback}
+
+		return logs.NewStructuredLogger(logrus.StandardLogger())(middleware.Middleware(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				u, err := UserFromContext(r.Context())
+				require.NoError(t, err)
+				_ = json.NewEncoder(w).Encode(u)
+			}),
+		))
+	}
+
+	request := func(handler http.Handler, key string) (*httptest.ResponseRecorder, User) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if key != "" {
+			r.Header.Set(APIKeyHeader, key)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		u := User{}
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&u))
+		}
+
+		return w, u
+	}
+
+	handler := newHandler(fallback)
+
+	w, u := request(handler, activeSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, User{UUID: "api-key:" + active.ID, DisplayName: "SMS gateway", Scopes: []string{"play-games"}}, u)
+
+	w, _ = request(handler, revokedSecret)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w, _ = request(handler, expiredSecret)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w, _ = request(handler, "gc_unknown")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Requests without a key are authenticated by the fallback if there is one.
+	w, u = request(handler, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "fallback", u.UUID)
+
+	w, _ = request(newHandler(nil), "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
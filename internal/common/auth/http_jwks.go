@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"gopher-cache/internal/common/errors"
+	"net/http"
+)
+
+// JWKSHttpMiddleware performs authentication with RS256 ID tokens signed by an identity provider that
+// publishes its keys as a JWKS. Tokens must be signed by a key in Keys, must not have expired and must
+// have been issued by Issuer for Audience. The token must contain the UUID and Number claims of Claims
+// and may also contain the others.
+type JWKSHttpMiddleware struct {
+	Keys     KeySet
+	Issuer   string
+	Audience string
+	// Claims are the claims the user is read from. The DefaultClaimNames are used for names that are
+	// empty.
+	Claims ClaimNames
+}
+
+var (
+	ErrorEmptyBearerToken = errors.NewAuthenticationError("request has no bearer token", "empty-bearer-token")
+	ErrorInvalidToken     = errors.NewAuthenticationError("token is missing claims", "invalid-token")
+)
+
+// Middleware will place a User type into the context after successfully verifying the bearer token.
+func (a JWKSHttpMiddleware) Middleware(next http.Handler) http.Handler {
+	return TokenHttpMiddleware(a)(next)
+}
+
+// AuthenticateToken returns the user of a bearer token signed by the identity provider.
+func (a JWKSHttpMiddleware) AuthenticateToken(ctx context.Context, bearerToken string) (User, error) {
+	if bearerToken == "" {
+		return User{}, ErrorEmptyBearerToken
+	}
+
+	claims, err := a.verify(ctx, bearerToken)
+	if err != nil {
+		return User{}, errors.NewAuthenticationError(err.Error(), "unable-to-verify-jwt")
+	}
+
+	names := a.Claims.withDefaults()
+
+	sub, ok := stringClaim(claims, names.UUID)
+	if !ok || sub == "" {
+		return User{}, ErrorInvalidToken
+	}
+
+	number, ok := stringClaim(claims, names.Number)
+	if !ok || number == "" {
+		return User{}, ErrorInvalidToken
+	}
+
+	name, _ := stringClaim(claims, names.DisplayName)
+	email, _ := stringClaim(claims, names.Email)
+
+	return User{
+		UUID:        sub,
+		DisplayName: name,
+		Email:       email,
+		Number:      number,
+		Roles:       rolesFromClaim(claim(claims, names.Roles)),
+	}, nil
+}
+
+// verify returns the claims of the token if it is valid.
+func (a JWKSHttpMiddleware) verify(ctx context.Context, bearerToken string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+
+	_, err := jwt.ParseWithClaims(bearerToken, claims, func(token *jwt.Token) (interface{}, error) {
+		// Only RS256 is accepted so a token can't pick a weaker algorithm, or use the public key as
+		// an HMAC secret.
+		if token.Method != jwt.SigningMethodRS256 {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+
+		keyID, _ := token.Header["kid"].(string)
+
+		return a.Keys.PublicKey(ctx, keyID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// MapClaims only checks the expiry if there is one, but ID tokens must expire.
+	if claims["exp"] == nil {
+		return nil, fmt.Errorf("token has no expiry")
+	}
+
+	if !claims.VerifyIssuer(a.Issuer, true) {
+		return nil, fmt.Errorf("token was not issued by %s", a.Issuer)
+	}
+
+	if !hasAudience(claims, a.Audience) {
+		return nil, fmt.Errorf("token was not issued for %s", a.Audience)
+	}
+
+	return claims, nil
+}
+
+// hasAudience reports whether audience is in the aud claim, which may be a string or a list of strings.
+func hasAudience(claims jwt.MapClaims, audience string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, v := range aud {
+			if v == audience {
+				return true
+			}
+		}
+	}
+
+	return false
+}
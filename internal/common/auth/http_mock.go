@@ -3,8 +3,7 @@ package auth
 import (
 	"context"
 	"github.com/dgrijalva/jwt-go"
-	"github.com/dgrijalva/jwt-go/request"
-	"gopher-cache/internal/common/server/httperr"
+	"gopher-cache/internal/common/errors"
 	"net/http"
 )
 
@@ -15,60 +14,55 @@ import (
 // - name
 // - email
 // - number
+// It may also contain a list of roles in roles.
 func HttpMockMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		var claims jwt.MapClaims
-		token, err := request.ParseFromRequest(
-			r,
-			request.AuthorizationHeaderExtractor,
-			func(token *jwt.Token) (i interface{}, e error) {
-				return []byte("mock_secret"), nil
-			},
-			request.WithClaims(&claims),
-		)
-		if err != nil {
-			httperr.BadRequest("unable-to-get-jwt", err, w, r)
-			return
-		}
+	return TokenHttpMiddleware(MockTokenAuthenticator{})(next)
+}
+
+// MockTokenAuthenticator authenticates the tokens accepted by HttpMockMiddleware.
+type MockTokenAuthenticator struct{}
 
-		if !token.Valid {
-			httperr.BadRequest("invalid-jwt", nil, w, r)
-			return
-		}
+var ErrorInvalidMockToken = errors.NewAuthenticationError("token is invalid", "invalid-jwt")
 
-		id, ok := claims["id"].(string)
-		if !ok {
-			httperr.BadRequest("invalid-jwt", nil, w, r)
-			return
-		}
+// AuthenticateToken returns the user of a token signed with mock_secret.
+func (MockTokenAuthenticator) AuthenticateToken(_ context.Context, bearerToken string) (User, error) {
+	var claims jwt.MapClaims
+	token, err := jwt.ParseWithClaims(bearerToken, &claims, func(token *jwt.Token) (i interface{}, e error) {
+		return []byte("mock_secret"), nil
+	})
+	if err != nil {
+		return User{}, errors.NewAuthenticationError(err.Error(), "unable-to-get-jwt")
+	}
 
-		name, ok := claims["name"].(string)
-		if !ok {
-			httperr.BadRequest("invalid-jwt", nil, w, r)
-			return
-		}
+	if !token.Valid {
+		return User{}, ErrorInvalidMockToken
+	}
 
-		email, ok := claims["email"].(string)
-		if !ok {
-			httperr.BadRequest("invalid-jwt", nil, w, r)
-			return
+	id, ok := claims["id"].(string)
+	if !ok {
+		return User{}, ErrorInvalidMockToken
+	}
 
-		}
+	name, ok := claims["name"].(string)
+	if !ok {
+		return User{}, ErrorInvalidMockToken
+	}
 
-		number, ok := claims["number"].(string)
-		if !ok {
-			httperr.BadRequest("invalid-jwt", nil, w, r)
-			return
-		}
+	email, ok := claims["email"].(string)
+	if !ok {
+		return User{}, ErrorInvalidMockToken
+	}
 
-		ctx := context.WithValue(r.Context(), userContextKey, User{
-			UUID:        id,
-			DisplayName: name,
-			Email:       email,
-			Number:      number,
-		})
-		r = r.WithContext(ctx)
+	number, ok := claims["number"].(string)
+	if !ok {
+		return User{}, ErrorInvalidMockToken
+	}
 
-		next.ServeHTTP(w, r)
-	})
+	return User{
+		UUID:        id,
+		DisplayName: name,
+		Email:       email,
+		Number:      number,
+		Roles:       rolesFromClaim(claims["roles"]),
+	}, nil
 }
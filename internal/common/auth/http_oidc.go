@@ -0,0 +1,182 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"golang.org/x/sync/singleflight"
+	"gopher-cache/internal/common/errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// discoveryRetryInterval is how long an OIDCHttpMiddleware waits before fetching the discovery document
+	// again after it failed, so an unavailable provider isn't asked by every request.
+	discoveryRetryInterval = 30 * time.Second
+	// discoveryTimeout limits a fetch of the discovery document. Fetches are shared by every request waiting
+	// for it, so they aren't cancelled with the request that started them.
+	discoveryTimeout = 10 * time.Second
+)
+
+// OIDCHttpMiddleware performs authentication with ID tokens from an OpenID Connect provider such as
+// Auth0 or Keycloak. The provider's keys are found with its discovery document and tokens are then
+// verified the same way as by JWKSHttpMiddleware.
+type OIDCHttpMiddleware struct {
+	issuer   string
+	audience string
+	claims   ClaimNames
+	client   *http.Client
+	group    singleflight.Group
+
+	lock     sync.Mutex
+	verifier *JWKSHttpMiddleware
+	// failedAt is when discovery last failed and err is why.
+	failedAt      time.Time
+	err           error
+	retryInterval time.Duration
+}
+
+// NewOIDCHttpMiddleware creates a middleware for tokens issued by the provider at issuer for audience.
+// The user is read from claims, where the DefaultClaimNames are used for names that are empty.
+// http.DefaultClient is used if client is nil.
+//
+// The discovery document isn't fetched until the first request so the provider doesn't have to be
+// available when the server starts.
+func NewOIDCHttpMiddleware(issuer, audience string, claims ClaimNames, client *http.Client) *OIDCHttpMiddleware {
+	if issuer == "" {
+		panic("empty issuer")
+	}
+
+	if audience == "" {
+		panic("empty audience")
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &OIDCHttpMiddleware{
+		issuer:        strings.TrimSuffix(issuer, "/"),
+		audience:      audience,
+		claims:        claims,
+		client:        client,
+		retryInterval: discoveryRetryInterval,
+	}
+}
+
+// Middleware will place a User type into the context after successfully verifying the bearer token.
+func (a *OIDCHttpMiddleware) Middleware(next http.Handler) http.Handler {
+	return TokenHttpMiddleware(a)(next)
+}
+
+// AuthenticateToken returns the user of a bearer token issued by the provider.
+func (a *OIDCHttpMiddleware) AuthenticateToken(ctx context.Context, bearerToken string) (User, error) {
+	verifier, err := a.discover(ctx)
+	if err != nil {
+		return User{}, errors.NewSlugError(err.Error(), "oidc-discovery-failed")
+	}
+
+	return verifier.AuthenticateToken(ctx, bearerToken)
+}
+
+// discover returns the verifier for the provider, fetching its discovery document the first time.
+// Requests made while it is being fetched share the fetch. Failures are cached for retryInterval, after
+// which discovery is tried again.
+func (a *OIDCHttpMiddleware) discover(ctx context.Context) (*JWKSHttpMiddleware, error) {
+	a.lock.Lock()
+	verifier, err, failedAt := a.verifier, a.err, a.failedAt
+	a.lock.Unlock()
+
+	if verifier != nil {
+		return verifier, nil
+	}
+
+	if err != nil && time.Since(failedAt) < a.retryInterval {
+		return nil, err
+	}
+
+	select {
+	case res := <-a.group.DoChan("discovery", a.newVerifier):
+		if res.Err != nil {
+			return nil, res.Err
+		}
+
+		return res.Val.(*JWKSHttpMiddleware), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// newVerifier fetches the discovery document and keeps the verifier for the provider, or the error if it
+// fails.
+func (a *OIDCHttpMiddleware) newVerifier() (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), discoveryTimeout)
+	defer cancel()
+
+	verifier, err := a.fetchVerifier(ctx)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if err != nil {
+		a.failedAt = time.Now()
+		a.err = err
+
+		return nil, err
+	}
+
+	a.verifier = verifier
+
+	return verifier, nil
+}
+
+func (a *OIDCHttpMiddleware) fetchVerifier(ctx context.Context) (*JWKSHttpMiddleware, error) {
+	metadata, err := a.fetchMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The issuer in the document must be the one it was fetched from, otherwise tokens from another
+	// provider could be accepted.
+	if strings.TrimSuffix(metadata.Issuer, "/") != a.issuer {
+		return nil, fmt.Errorf("discovery document is for issuer %q not %q", metadata.Issuer, a.issuer)
+	}
+
+	if metadata.JWKSURI == "" {
+		return nil, fmt.Errorf("discovery document has no jwks_uri")
+	}
+
+	return &JWKSHttpMiddleware{
+		Keys:     NewRemoteKeySet(metadata.JWKSURI, a.client),
+		Issuer:   metadata.Issuer,
+		Audience: a.audience,
+		Claims:   a.claims,
+	}, nil
+}
+
+func (a *OIDCHttpMiddleware) fetchMetadata(ctx context.Context) (ProviderMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.issuer+discoveryPath, nil)
+	if err != nil {
+		return ProviderMetadata{}, err
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return ProviderMetadata{}, fmt.Errorf("unable to fetch discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ProviderMetadata{}, fmt.Errorf("unable to fetch discovery document: %s", resp.Status)
+	}
+
+	metadata := ProviderMetadata{}
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return ProviderMetadata{}, fmt.Errorf("unable to decode discovery document: %w", err)
+	}
+
+	return metadata, nil
+}
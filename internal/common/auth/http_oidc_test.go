@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"encoding/json"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/common/logs"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOIDCHttpMiddleware(t *testing.T) {
+	var requests int32
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		mux.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	logger := logs.NewStructuredLogger(logrus.StandardLogger())
+
+	issuer, err := NewLocalIssuer(srv.URL, "gopher-cache", time.Hour)
+	require.NoError(t, err)
+	mux.Handle("/", logger(issuer.Handler()))
+
+	newHandler := func(issuerURL string, claims ClaimNames) http.Handler {
+		middleware := NewOIDCHttpMiddleware(issuerURL, "gopher-cache", claims, srv.Client())
+
+		return logger(middleware.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			u, err := UserFromContext(r.Context())
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(u)
+		})))
+	}
+
+	t.Run("default claims", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		handler := newHandler(issuer.URL()+"/", ClaimNames{})
+
+		user := User{UUID: "uuid", DisplayName: "Gopher", Number: "15734497033", Roles: []string{"admin"}}
+
+		token, err := issuer.Issue(user)
+		require.NoError(t, err)
+
+		w, got := authenticate(handler, token)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, user, got)
+
+		// The discovery document and keys are only fetched once.
+		w, _ = authenticate(handler, token)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("mapped claims", func(t *testing.T) {
+		handler := newHandler(issuer.URL(), ClaimNames{
+			UUID:        "user_id",
+			DisplayName: "nickname",
+			Number:      "phone_number",
+			Roles:       "realm_access.roles",
+		})
+
+		token, err := issuer.IssueWithClaims(jwt.MapClaims{
+			"sub":          "subject",
+			"user_id":      "uuid",
+			"nickname":     "Gopher",
+			"email":        "gopher@example.com",
+			"phone_number": "+15734497033",
+			"realm_access": map[string]interface{}{"roles": []string{"organizer"}},
+		})
+		require.NoError(t, err)
+
+		w, got := authenticate(handler, token)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, User{
+			UUID:        "uuid",
+			DisplayName: "Gopher",
+			Email:       "gopher@example.com",
+			Number:      "+15734497033",
+			Roles:       []string{"organizer"},
+		}, got)
+
+		// The number claim is required.
+		token, err = issuer.IssueWithClaims(jwt.MapClaims{"user_id": "uuid", "number": "15734497033"})
+		require.NoError(t, err)
+
+		w, _ = authenticate(handler, token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("namespaced claims", func(t *testing.T) {
+		handler := newHandler(issuer.URL(), ClaimNames{Roles: "https://gophercache.com/roles"})
+
+		token, err := issuer.IssueWithClaims(jwt.MapClaims{
+			"sub":                           "uuid",
+			"number":                        "15734497033",
+			"https://gophercache.com/roles": []string{"creator"},
+		})
+		require.NoError(t, err)
+
+		w, got := authenticate(handler, token)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"creator"}, got.Roles)
+	})
+
+	t.Run("wrong issuer", func(t *testing.T) {
+		// The discovery document is served for another issuer than the one asked for.
+		mux.Handle("/other/", http.StripPrefix("/other", logger(issuer.Handler())))
+		handler := newHandler(issuer.URL()+"/other", ClaimNames{})
+
+		token, err := issuer.Issue(User{UUID: "uuid", Number: "15734497033"})
+		require.NoError(t, err)
+
+		atomic.StoreInt32(&requests, 0)
+		w, _ := authenticate(handler, token)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+		// The failed discovery isn't tried again until the retry interval has passed.
+		w, _ = authenticate(handler, token)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	})
+}
+
+func TestClaim(t *testing.T) {
+	claims := map[string]interface{}{
+		"sub":          "uuid",
+		"a.b":          "dotted",
+		"realm_access": map[string]interface{}{"roles": []interface{}{"admin"}},
+	}
+
+	assert.Equal(t, "uuid", claim(claims, "sub"))
+	assert.Equal(t, "dotted", claim(claims, "a.b"))
+	assert.Equal(t, []interface{}{"admin"}, claim(claims, "realm_access.roles"))
+	assert.Nil(t, claim(claims, "realm_access.groups"))
+	assert.Nil(t, claim(claims, "sub.value"))
+	assert.Nil(t, claim(claims, "missing"))
+}
@@ -0,0 +1,239 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"golang.org/x/sync/singleflight"
+	"gopher-cache/internal/common/errors"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JSONWebKey is a public key in the JSON Web Key format. Only RSA keys are supported.
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	KeyID     string `json:"kid"`
+	// N is the modulus and E the exponent of the key, both base64url encoded big-endian integers.
+	N string `json:"n"`
+	E string `json:"e"`
+}
+
+// JSONWebKeySet is the document served by a JWKS endpoint.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+func newJSONWebKey(keyID string, key *rsa.PublicKey) JSONWebKey {
+	return JSONWebKey{
+		KeyType:   "RSA",
+		Use:       "sig",
+		Algorithm: "RS256",
+		KeyID:     keyID,
+		N:         base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func (k JSONWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	if k.KeyType != "RSA" {
+		return nil, fmt.Errorf("unsupported key type %q", k.KeyType)
+	}
+
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("exponent is too large")
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
+
+// KeySet provides the public keys that tokens are verified with.
+type KeySet interface {
+	// PublicKey returns the key with the ID given in the kid header of a token. ErrorUnknownKey is
+	// returned if there is no such key.
+	PublicKey(ctx context.Context, keyID string) (*rsa.PublicKey, error)
+}
+
+var ErrorUnknownKey = errors.NewAuthenticationError("token is signed with an unknown key", "unknown-signing-key")
+
+const (
+	// keySetRefreshInterval is how long a RemoteKeySet waits before fetching keys again when asked for a
+	// key it doesn't know, so tokens with made up key IDs can't be used to flood the JWKS endpoint.
+	keySetRefreshInterval = 30 * time.Second
+	// keySetMaxAge is how long a RemoteKeySet caches keys if the JWKS endpoint doesn't say.
+	keySetMaxAge = time.Hour
+	// keySetFetchTimeout limits a fetch of the keys. Fetches are shared by every request waiting for the
+	// keys, so they aren't cancelled with the request that started them.
+	keySetFetchTimeout = 10 * time.Second
+)
+
+// RemoteKeySet is a KeySet that fetches keys from a JWKS endpoint. The keys are cached for as long as
+// the Cache-Control header of the endpoint allows, and fetched again early when a token is signed with a
+// key that isn't cached, which is how rotated keys are picked up. Requests needing the keys at the same
+// time share one fetch, and the keys aren't fetched again within minRefresh of the last fetch, even if it
+// failed.
+type RemoteKeySet struct {
+	url    string
+	client *http.Client
+	group  singleflight.Group
+
+	lock sync.Mutex
+	keys map[string]*rsa.PublicKey
+	// fetchedAt is when the keys were last fetched or failed to be, and fetchErr is the error of the last
+	// fetch if it failed.
+	fetchedAt  time.Time
+	fetchErr   error
+	expiresAt  time.Time
+	minRefresh time.Duration
+}
+
+// NewRemoteKeySet creates a new key set for the JWKS endpoint at url. http.DefaultClient is used if
+// client is nil.
+func NewRemoteKeySet(url string, client *http.Client) *RemoteKeySet {
+	if url == "" {
+		panic("empty url")
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &RemoteKeySet{url: url, client: client, minRefresh: keySetRefreshInterval}
+}
+
+// PublicKey returns the key with the key ID, fetching the keys if they have expired or it isn't cached.
+func (s *RemoteKeySet) PublicKey(ctx context.Context, keyID string) (*rsa.PublicKey, error) {
+	s.lock.Lock()
+	key, cached := s.keys[keyID]
+	fresh := time.Now().Before(s.expiresAt)
+	refreshed := time.Since(s.fetchedAt) < s.minRefresh
+	s.lock.Unlock()
+
+	if cached && fresh {
+		return key, nil
+	}
+
+	if !refreshed {
+		select {
+		case <-s.group.DoChan("keys", s.refresh):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Expired keys are better than none while the endpoint is unavailable.
+	if key, ok := s.keys[keyID]; ok {
+		return key, nil
+	}
+
+	if s.keys == nil && s.fetchErr != nil {
+		return nil, s.fetchErr
+	}
+
+	return nil, ErrorUnknownKey
+}
+
+// refresh fetches the keys and caches them if the fetch succeeds.
+func (s *RemoteKeySet) refresh() (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), keySetFetchTimeout)
+	defer cancel()
+
+	keys, maxAge, err := s.fetch(ctx)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.fetchedAt = time.Now()
+	s.fetchErr = err
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.keys = keys
+	s.expiresAt = s.fetchedAt.Add(maxAge)
+
+	return nil, nil
+}
+
+// fetch returns the keys served by the endpoint and how long they can be cached for.
+func (s *RemoteKeySet) fetch(ctx context.Context) (map[string]*rsa.PublicKey, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to fetch keys: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("unable to fetch keys: %s", resp.Status)
+	}
+
+	set := JSONWebKeySet{}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, 0, fmt.Errorf("unable to decode keys: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		// Keys that aren't for signatures or can't be used with RS256 are skipped rather than failing
+		// the whole set.
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.rsaPublicKey()
+		if err != nil {
+			continue
+		}
+
+		keys[k.KeyID] = key
+	}
+
+	return keys, maxAge(resp.Header), nil
+}
+
+// maxAge returns the max-age of the Cache-Control header or keySetMaxAge if there isn't one.
+func maxAge(h http.Header) time.Duration {
+	for _, directive := range strings.Split(h.Get("Cache-Control"), ",") {
+		directive = strings.TrimSpace(directive)
+		if !strings.HasPrefix(directive, "max-age=") {
+			continue
+		}
+
+		seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+		if err != nil || seconds < 0 {
+			break
+		}
+
+		return time.Duration(seconds) * time.Second
+	}
+
+	return keySetMaxAge
+}
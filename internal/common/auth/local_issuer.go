@@ -0,0 +1,249 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"gopher-cache/internal/common/server/httperr"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Paths of the endpoints served by LocalIssuer.Handler, relative to the issuer URL.
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	jwksPath      = "/jwks.json"
+	tokenPath     = "/token"
+)
+
+// localIssuerKeys is how many signing keys a LocalIssuer publishes. Tokens signed with a key that has
+// been rotated out can no longer be verified.
+const localIssuerKeys = 2
+
+// LocalIssuer is an OpenID Connect identity provider for local environments and tests. It signs RS256
+// ID tokens and serves the discovery document and JWKS that production providers serve, so tokens it
+// issues are verified the same way as those of a real provider.
+//
+// Anyone who can reach the token endpoint can get a token for any user, so it must never be used
+// outside of development.
+type LocalIssuer struct {
+	url      string
+	audience string
+	ttl      time.Duration
+
+	lock sync.RWMutex
+	// keys are the keys that are published, oldest first. The newest key signs tokens.
+	keys []localIssuerKey
+}
+
+type localIssuerKey struct {
+	id  string
+	key *rsa.PrivateKey
+}
+
+// NewLocalIssuer creates an issuer with a new signing key. url is where Handler is served and is used
+// as the iss claim of tokens, which are issued for audience and expire after ttl.
+func NewLocalIssuer(url, audience string, ttl time.Duration) (*LocalIssuer, error) {
+	if url == "" {
+		panic("empty url")
+	}
+
+	if audience == "" {
+		panic("empty audience")
+	}
+
+	if ttl <= 0 {
+		panic("ttl must be positive")
+	}
+
+	i := &LocalIssuer{url: strings.TrimSuffix(url, "/"), audience: audience, ttl: ttl}
+
+	if err := i.Rotate(); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// URL returns the issuer URL.
+func (i *LocalIssuer) URL() string { return i.url }
+
+// Audience returns the audience tokens are issued for.
+func (i *LocalIssuer) Audience() string { return i.audience }
+
+// JWKSURL returns the URL the issuer's public keys are served at.
+func (i *LocalIssuer) JWKSURL() string { return i.url + jwksPath }
+
+// Rotate creates a new key to sign tokens with. The previous key is still published so tokens it
+// signed can be verified until they expire, but older keys are dropped.
+func (i *LocalIssuer) Rotate() error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.keys = append(i.keys, localIssuerKey{id: id.String(), key: key})
+	if len(i.keys) > localIssuerKeys {
+		i.keys = i.keys[len(i.keys)-localIssuerKeys:]
+	}
+
+	return nil
+}
+
+// Issue returns an ID token for the user.
+func (i *LocalIssuer) Issue(u User) (string, error) {
+	claims := jwt.MapClaims{
+		"sub":    u.UUID,
+		"name":   u.DisplayName,
+		"email":  u.Email,
+		"number": u.Number,
+	}
+
+	if len(u.Roles) > 0 {
+		claims["roles"] = u.Roles
+	}
+
+	return i.IssueWithClaims(claims)
+}
+
+// IssueWithClaims returns an ID token with the claims. The iss, aud, iat and exp claims are set by the
+// issuer unless they are in claims, which lets tests create tokens that shouldn't be accepted. Claims
+// set to nil are left out.
+func (i *LocalIssuer) IssueWithClaims(claims jwt.MapClaims) (string, error) {
+	now := time.Now()
+
+	token := jwt.MapClaims{
+		"iss": i.url,
+		"aud": i.audience,
+		"iat": now.Unix(),
+		"exp": now.Add(i.ttl).Unix(),
+	}
+
+	for k, v := range claims {
+		if v == nil {
+			delete(token, k)
+			continue
+		}
+
+		token[k] = v
+	}
+
+	i.lock.RLock()
+	signer := i.keys[len(i.keys)-1]
+	i.lock.RUnlock()
+
+	t := jwt.NewWithClaims(jwt.SigningMethodRS256, token)
+	t.Header["kid"] = signer.id
+
+	return t.SignedString(signer.key)
+}
+
+// Handler returns the issuer's endpoints, which must be served at the issuer URL:
+//   - GET /.well-known/openid-configuration returns the discovery document.
+//   - GET /jwks.json returns the public keys.
+//   - POST /token returns an ID token for the user in the body, given as JSON in the form of
+//     LocalTokenRequest.
+func (i *LocalIssuer) Handler() http.Handler {
+	router := chi.NewRouter()
+	router.Get(discoveryPath, i.serveDiscovery)
+	router.Get(jwksPath, i.serveJWKS)
+	router.Post(tokenPath, i.serveToken)
+
+	return router
+}
+
+// ProviderMetadata is the OpenID Connect discovery document. Only the fields used here are included.
+type ProviderMetadata struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	TokenEndpoint                    string   `json:"token_endpoint,omitempty"`
+	ResponseTypesSupported           []string `json:"response_types_supported"`
+	SubjectTypesSupported            []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	ClaimsSupported                  []string `json:"claims_supported,omitempty"`
+}
+
+func (i *LocalIssuer) serveDiscovery(w http.ResponseWriter, r *http.Request) {
+	render.Respond(w, r, ProviderMetadata{
+		Issuer:                           i.url,
+		JWKSURI:                          i.JWKSURL(),
+		TokenEndpoint:                    i.url + tokenPath,
+		ResponseTypesSupported:           []string{"id_token"},
+		SubjectTypesSupported:            []string{"public"},
+		IDTokenSigningAlgValuesSupported: []string{"RS256"},
+		ClaimsSupported:                  []string{"sub", "name", "email", "number", "roles"},
+	})
+}
+
+func (i *LocalIssuer) serveJWKS(w http.ResponseWriter, r *http.Request) {
+	i.lock.RLock()
+	set := JSONWebKeySet{Keys: make([]JSONWebKey, len(i.keys))}
+	for j, k := range i.keys {
+		set.Keys[j] = newJSONWebKey(k.id, &k.key.PublicKey)
+	}
+	i.lock.RUnlock()
+
+	w.Header().Set("Cache-Control", "max-age=300")
+	render.Respond(w, r, set)
+}
+
+// LocalTokenRequest is the user a token is requested for from a LocalIssuer.
+type LocalTokenRequest struct {
+	UUID        string   `json:"sub"`
+	DisplayName string   `json:"name"`
+	Email       string   `json:"email"`
+	Number      string   `json:"number"`
+	Roles       []string `json:"roles"`
+}
+
+// LocalTokenResponse is the token issued by a LocalIssuer.
+type LocalTokenResponse struct {
+	IDToken   string `json:"id_token"`
+	TokenType string `json:"token_type"`
+	ExpiresIn int    `json:"expires_in"`
+}
+
+func (i *LocalIssuer) serveToken(w http.ResponseWriter, r *http.Request) {
+	req := LocalTokenRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperr.BadRequest("invalid-token-request", err, w, r)
+		return
+	}
+
+	if req.UUID == "" || req.Number == "" {
+		httperr.BadRequest("invalid-token-request", nil, w, r)
+		return
+	}
+
+	token, err := i.Issue(User{
+		UUID:        req.UUID,
+		DisplayName: req.DisplayName,
+		Email:       req.Email,
+		Number:      req.Number,
+		Roles:       req.Roles,
+	})
+	if err != nil {
+		httperr.InternalError("unable-to-issue-token", err, w, r)
+		return
+	}
+
+	render.Respond(w, r, LocalTokenResponse{
+		IDToken:   token,
+		TokenType: "Bearer",
+		ExpiresIn: int(i.ttl.Seconds()),
+	})
+}
@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/common/logs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestLocalIssuer serves a LocalIssuer and returns it with a middleware verifying its tokens.
+func newTestLocalIssuer(t *testing.T) (*LocalIssuer, http.Handler) {
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	issuer, err := NewLocalIssuer(srv.URL, "gopher-cache", time.Hour)
+	require.NoError(t, err)
+	// httperr expects the request to be logged.
+	logger := logs.NewStructuredLogger(logrus.StandardLogger())
+	mux.Handle("/", logger(issuer.Handler()))
+
+	keys := NewRemoteKeySet(issuer.JWKSURL(), srv.Client())
+	keys.minRefresh = 0
+
+	middleware := JWKSHttpMiddleware{Keys: keys, Issuer: issuer.URL(), Audience: issuer.Audience()}
+
+	return issuer, logger(middleware.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, err := UserFromContext(r.Context())
+		require.NoError(t, err)
+		_ = json.NewEncoder(w).Encode(u)
+	})))
+}
+
+func authenticate(handler http.Handler, token string) (*httptest.ResponseRecorder, User) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	u := User{}
+	if w.Code == http.StatusOK {
+		_ = json.NewDecoder(w.Body).Decode(&u)
+	}
+
+	return w, u
+}
+
+func TestJWKSHttpMiddleware(t *testing.T) {
+	issuer, handler := newTestLocalIssuer(t)
+
+	user := User{UUID: "uuid", DisplayName: "Gopher", Email: "gopher@example.com", Number: "15734497033", Roles: []string{"admin"}}
+
+	token, err := issuer.Issue(user)
+	require.NoError(t, err)
+
+	w, got := authenticate(handler, token)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, user, got)
+
+	now := time.Now()
+
+	for name, claims := range map[string]jwt.MapClaims{
+		"expired":        {"sub": "uuid", "number": "1", "exp": now.Add(-time.Minute).Unix()},
+		"no expiry":      {"sub": "uuid", "number": "1", "exp": nil},
+		"wrong audience": {"sub": "uuid", "number": "1", "aud": "another-app"},
+		"wrong issuer":   {"sub": "uuid", "number": "1", "iss": "https://example.com"},
+		"no subject":     {"number": "1"},
+		"no number":      {"sub": "uuid"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			token, err := issuer.IssueWithClaims(claims)
+			require.NoError(t, err)
+
+			w, _ := authenticate(handler, token)
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+
+	t.Run("hmac", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub":    "uuid",
+			"number": "1",
+			"iss":    issuer.URL(),
+			"aud":    issuer.Audience(),
+			"exp":    now.Add(time.Minute).Unix(),
+		}).SignedString([]byte("mock_secret"))
+		require.NoError(t, err)
+
+		w, _ := authenticate(handler, token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		w, _ := authenticate(handler, "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestLocalIssuer_Rotate(t *testing.T) {
+	issuer, handler := newTestLocalIssuer(t)
+
+	user := User{UUID: "uuid", Number: "15734497033"}
+
+	first, err := issuer.Issue(user)
+	require.NoError(t, err)
+
+	w, _ := authenticate(handler, first)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// Tokens signed with the new key are verified after fetching the keys again, and tokens signed
+	// with the previous key are still valid.
+	require.NoError(t, issuer.Rotate())
+
+	second, err := issuer.Issue(user)
+	require.NoError(t, err)
+
+	w, _ = authenticate(handler, second)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w, _ = authenticate(handler, first)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Once the first key has been rotated out its tokens aren't valid.
+	require.NoError(t, issuer.Rotate())
+
+	third, err := issuer.Issue(user)
+	require.NoError(t, err)
+
+	w, _ = authenticate(handler, third)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w, _ = authenticate(handler, first)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestLocalIssuer_Handler(t *testing.T) {
+	issuer, handler := newTestLocalIssuer(t)
+
+	resp, err := http.Get(issuer.URL() + discoveryPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	metadata := ProviderMetadata{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&metadata))
+	assert.Equal(t, issuer.URL(), metadata.Issuer)
+	assert.Equal(t, issuer.JWKSURL(), metadata.JWKSURI)
+
+	body, err := json.Marshal(LocalTokenRequest{UUID: "uuid", Number: "15734497033", Roles: []string{"player"}})
+	require.NoError(t, err)
+
+	resp, err = http.Post(metadata.TokenEndpoint, "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	token := LocalTokenResponse{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&token))
+
+	w, got := authenticate(handler, token.IDToken)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, User{UUID: "uuid", Number: "15734497033", Roles: []string{"player"}}, got)
+
+	resp, err = http.Post(metadata.TokenEndpoint, "application/json", bytes.NewReader([]byte(`{"sub":"uuid"}`)))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
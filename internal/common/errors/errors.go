@@ -8,16 +8,34 @@ type ErrorType struct {
 
 // ErrorTypes used by this package.
 var (
-	ErrorTypeUnknown        = ErrorType{"unknown"}
+	ErrorTypeUnknown = ErrorType{"unknown"}
+	// ErrorTypeAuthentication is used when who is making a request isn't known.
+	ErrorTypeAuthentication = ErrorType{"authentication"}
+	// ErrorTypeAuthorization is used when who is making a request is known but isn't allowed to make it.
 	ErrorTypeAuthorization  = ErrorType{"authorization"}
 	ErrorTypeIncorrectInput = ErrorType{"incorrect-input"}
+	ErrorTypeNotFound       = ErrorType{"not-found"}
+	// ErrorTypeConflict is used when a request can't be made because of the current state of what it changes.
+	ErrorTypeConflict = ErrorType{"conflict"}
 )
 
+// FieldError describes a rule broken by one field of incorrect input.
+type FieldError struct {
+	// Field is the path of the field, e.g. levels[0].clues[1].
+	Field string `json:"field"`
+	// Rule is a slug naming the rule, e.g. max-length.
+	Rule string `json:"rule"`
+	// Limit is the limit of the rule, e.g. the max length, or 0 if the rule has none.
+	Limit int `json:"limit,omitempty"`
+}
+
 // SlugError extends error. In addition to error it provides a slug.
 type SlugError struct {
 	error     string
 	slug      string
 	errorType ErrorType
+	// fields is a pointer so SlugErrors stay comparable with ==.
+	fields *[]FieldError
 }
 
 // Error returns a string indicating what error occured.
@@ -30,6 +48,15 @@ func (s SlugError) Slug() string {
 	return s.slug
 }
 
+// Fields returns the field errors of incorrect input or nil if the error isn't about particular fields.
+func (s SlugError) Fields() []FieldError {
+	if s.fields == nil {
+		return nil
+	}
+
+	return *s.fields
+}
+
 // ErrorType returns an ErrorType to assist in determining the type of the error.
 func (s SlugError) ErrorType() ErrorType {
 	return s.errorType
@@ -44,6 +71,15 @@ func NewSlugError(error string, slug string) SlugError {
 	}
 }
 
+// NewAuthenticationError creates a new SlugError for authentication errors.
+func NewAuthenticationError(error string, slug string) SlugError {
+	return SlugError{
+		error:     error,
+		slug:      slug,
+		errorType: ErrorTypeAuthentication,
+	}
+}
+
 // NewAuthorizationError creates a new SlugError for authorization errors.
 func NewAuthorizationError(error string, slug string) SlugError {
 	return SlugError{
@@ -53,11 +89,36 @@ func NewAuthorizationError(error string, slug string) SlugError {
 	}
 }
 
-// NewIncorrectInputError creates a new SlugError for client input errors.
-func NewIncorrectInputError(error string, slug string) SlugError {
-	return SlugError{
+// NewIncorrectInputError creates a new SlugError for client input errors. fields lists which fields of the
+// input are incorrect if that is known.
+func NewIncorrectInputError(error string, slug string, fields ...FieldError) SlugError {
+	s := SlugError{
 		error:     error,
 		slug:      slug,
 		errorType: ErrorTypeIncorrectInput,
 	}
+
+	if len(fields) > 0 {
+		s.fields = &fields
+	}
+
+	return s
+}
+
+// NewNotFoundError creates a new SlugError for when what was asked for doesn't exist.
+func NewNotFoundError(error string, slug string) SlugError {
+	return SlugError{
+		error:     error,
+		slug:      slug,
+		errorType: ErrorTypeNotFound,
+	}
+}
+
+// NewConflictError creates a new SlugError for when the current state of something prevents a change.
+func NewConflictError(error string, slug string) SlugError {
+	return SlugError{
+		error:     error,
+		slug:      slug,
+		errorType: ErrorTypeConflict,
+	}
 }
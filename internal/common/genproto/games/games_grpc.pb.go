@@ -0,0 +1,372 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: games.proto
+
+package games
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// GamesServiceClient is the client API for GamesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GamesServiceClient interface {
+	// CreateGame creates a game. The authenticated user is its creator.
+	CreateGame(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// CreateGameState starts a game for the authenticated user.
+	CreateGameState(ctx context.Context, in *CreateGameStateRequest, opts ...grpc.CallOption) (*Response, error)
+	// UpdateGameState submits the input of a player. Users may only update their own game state unless they
+	// are an admin or organizer. The input may be a text command such as HINT rather than an answer.
+	UpdateGameState(ctx context.Context, in *UpdateGameStateRequest, opts ...grpc.CallOption) (*Response, error)
+	// GetGames queries for games the same way as GET /games of the HTTP API.
+	GetGames(ctx context.Context, in *GetGamesRequest, opts ...grpc.CallOption) (*GamesPage, error)
+	// GetPlayer queries for a player. Users may only read their own player unless they are an admin or
+	// organizer.
+	GetPlayer(ctx context.Context, in *GetPlayerRequest, opts ...grpc.CallOption) (*Player, error)
+	// GetState queries for a game state. Users may only read their own game states unless they are an admin
+	// or organizer.
+	GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*State, error)
+	// WatchState sends the game state when the call is made and then each time it is updated, until the game
+	// is completed or the call is cancelled.
+	WatchState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (GamesService_WatchStateClient, error)
+}
+
+type gamesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGamesServiceClient(cc grpc.ClientConnInterface) GamesServiceClient {
+	return &gamesServiceClient{cc}
+}
+
+func (c *gamesServiceClient) CreateGame(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/games.GamesService/CreateGame", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gamesServiceClient) CreateGameState(ctx context.Context, in *CreateGameStateRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/games.GamesService/CreateGameState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gamesServiceClient) UpdateGameState(ctx context.Context, in *UpdateGameStateRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/games.GamesService/UpdateGameState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gamesServiceClient) GetGames(ctx context.Context, in *GetGamesRequest, opts ...grpc.CallOption) (*GamesPage, error) {
+	out := new(GamesPage)
+	err := c.cc.Invoke(ctx, "/games.GamesService/GetGames", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gamesServiceClient) GetPlayer(ctx context.Context, in *GetPlayerRequest, opts ...grpc.CallOption) (*Player, error) {
+	out := new(Player)
+	err := c.cc.Invoke(ctx, "/games.GamesService/GetPlayer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gamesServiceClient) GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*State, error) {
+	out := new(State)
+	err := c.cc.Invoke(ctx, "/games.GamesService/GetState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gamesServiceClient) WatchState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (GamesService_WatchStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GamesService_ServiceDesc.Streams[0], "/games.GamesService/WatchState", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gamesServiceWatchStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GamesService_WatchStateClient interface {
+	Recv() (*State, error)
+	grpc.ClientStream
+}
+
+type gamesServiceWatchStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *gamesServiceWatchStateClient) Recv() (*State, error) {
+	m := new(State)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GamesServiceServer is the server API for GamesService service.
+// All implementations must embed UnimplementedGamesServiceServer
+// for forward compatibility
+type GamesServiceServer interface {
+	// CreateGame creates a game. The authenticated user is its creator.
+	CreateGame(context.Context, *CreateGameRequest) (*emptypb.Empty, error)
+	// CreateGameState starts a game for the authenticated user.
+	CreateGameState(context.Context, *CreateGameStateRequest) (*Response, error)
+	// UpdateGameState submits the input of a player. Users may only update their own game state unless they
+	// are an admin or organizer. The input may be a text command such as HINT rather than an answer.
+	UpdateGameState(context.Context, *UpdateGameStateRequest) (*Response, error)
+	// GetGames queries for games the same way as GET /games of the HTTP API.
+	GetGames(context.Context, *GetGamesRequest) (*GamesPage, error)
+	// GetPlayer queries for a player. Users may only read their own player unless they are an admin or
+	// organizer.
+	GetPlayer(context.Context, *GetPlayerRequest) (*Player, error)
+	// GetState queries for a game state. Users may only read their own game states unless they are an admin
+	// or organizer.
+	GetState(context.Context, *GetStateRequest) (*State, error)
+	// WatchState sends the game state when the call is made and then each time it is updated, until the game
+	// is completed or the call is cancelled.
+	WatchState(*GetStateRequest, GamesService_WatchStateServer) error
+	mustEmbedUnimplementedGamesServiceServer()
+}
+
+// UnimplementedGamesServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGamesServiceServer struct {
+}
+
+func (UnimplementedGamesServiceServer) CreateGame(context.Context, *CreateGameRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGame not implemented")
+}
+func (UnimplementedGamesServiceServer) CreateGameState(context.Context, *CreateGameStateRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGameState not implemented")
+}
+func (UnimplementedGamesServiceServer) UpdateGameState(context.Context, *UpdateGameStateRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGameState not implemented")
+}
+func (UnimplementedGamesServiceServer) GetGames(context.Context, *GetGamesRequest) (*GamesPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGames not implemented")
+}
+func (UnimplementedGamesServiceServer) GetPlayer(context.Context, *GetPlayerRequest) (*Player, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlayer not implemented")
+}
+func (UnimplementedGamesServiceServer) GetState(context.Context, *GetStateRequest) (*State, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetState not implemented")
+}
+func (UnimplementedGamesServiceServer) WatchState(*GetStateRequest, GamesService_WatchStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchState not implemented")
+}
+func (UnimplementedGamesServiceServer) mustEmbedUnimplementedGamesServiceServer() {}
+
+// UnsafeGamesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GamesServiceServer will
+// result in compilation errors.
+type UnsafeGamesServiceServer interface {
+	mustEmbedUnimplementedGamesServiceServer()
+}
+
+func RegisterGamesServiceServer(s grpc.ServiceRegistrar, srv GamesServiceServer) {
+	s.RegisterService(&GamesService_ServiceDesc, srv)
+}
+
+func _GamesService_CreateGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GamesServiceServer).CreateGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/games.GamesService/CreateGame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GamesServiceServer).CreateGame(ctx, req.(*CreateGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GamesService_CreateGameState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGameStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GamesServiceServer).CreateGameState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/games.GamesService/CreateGameState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GamesServiceServer).CreateGameState(ctx, req.(*CreateGameStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GamesService_UpdateGameState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGameStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GamesServiceServer).UpdateGameState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/games.GamesService/UpdateGameState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GamesServiceServer).UpdateGameState(ctx, req.(*UpdateGameStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GamesService_GetGames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GamesServiceServer).GetGames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/games.GamesService/GetGames",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GamesServiceServer).GetGames(ctx, req.(*GetGamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GamesService_GetPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GamesServiceServer).GetPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/games.GamesService/GetPlayer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GamesServiceServer).GetPlayer(ctx, req.(*GetPlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GamesService_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GamesServiceServer).GetState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/games.GamesService/GetState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GamesServiceServer).GetState(ctx, req.(*GetStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GamesService_WatchState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GamesServiceServer).WatchState(m, &gamesServiceWatchStateServer{stream})
+}
+
+type GamesService_WatchStateServer interface {
+	Send(*State) error
+	grpc.ServerStream
+}
+
+type gamesServiceWatchStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *gamesServiceWatchStateServer) Send(m *State) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// GamesService_ServiceDesc is the grpc.ServiceDesc for GamesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GamesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "games.GamesService",
+	HandlerType: (*GamesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateGame",
+			Handler:    _GamesService_CreateGame_Handler,
+		},
+		{
+			MethodName: "CreateGameState",
+			Handler:    _GamesService_CreateGameState_Handler,
+		},
+		{
+			MethodName: "UpdateGameState",
+			Handler:    _GamesService_UpdateGameState_Handler,
+		},
+		{
+			MethodName: "GetGames",
+			Handler:    _GamesService_GetGames_Handler,
+		},
+		{
+			MethodName: "GetPlayer",
+			Handler:    _GamesService_GetPlayer_Handler,
+		},
+		{
+			MethodName: "GetState",
+			Handler:    _GamesService_GetState_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchState",
+			Handler:       _GamesService_WatchState_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "games.proto",
+}
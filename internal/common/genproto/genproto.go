@@ -0,0 +1,4 @@
+// Package genproto contains the code generated from the protobuf definitions in api/protobuf.
+package genproto
+
+//go:generate protoc --proto_path=../../../api/protobuf --go_out=../../.. --go_opt=module=gopher-cache --go-grpc_out=../../.. --go-grpc_opt=module=gopher-cache games.proto
@@ -0,0 +1,29 @@
+// Package geo implements a library for working with geographic coordinates.
+package geo
+
+import "math"
+
+// EarthRadiusKm is the mean radius of the earth in kilometers.
+const EarthRadiusKm = 6371.0
+
+// DistanceKm returns the great-circle distance in kilometers between two coordinates using the
+// haversine formula.
+func DistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	phi1 := radians(lat1)
+	phi2 := radians(lat2)
+	dPhi := radians(lat2 - lat1)
+	dLambda := radians(lng2 - lng1)
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) + math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// ValidCoordinates reports whether lat and lng are within the range of real coordinates.
+func ValidCoordinates(lat, lng float64) bool {
+	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
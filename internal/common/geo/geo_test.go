@@ -0,0 +1,60 @@
+package geo
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestDistanceKm(t *testing.T) {
+	// Austin to Dallas is roughly 293km.
+	d := DistanceKm(30.2672, -97.7431, 32.7767, -96.7970)
+	assert.InDelta(t, 293, d, 5)
+
+	assert.Equal(t, 0.0, DistanceKm(30.2672, -97.7431, 30.2672, -97.7431))
+}
+
+func TestValidCoordinates(t *testing.T) {
+	assert.True(t, ValidCoordinates(30.2672, -97.7431))
+	assert.True(t, ValidCoordinates(-90, 180))
+	assert.False(t, ValidCoordinates(91, 0))
+	assert.False(t, ValidCoordinates(0, -181))
+}
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, "9v6kp", Encode(30.2672, -97.7431, 5))
+	assert.Equal(t, "u4pruydqq", Encode(57.64911, 10.40744, 9))
+	assert.Equal(t, "u", Encode(57.64911, 10.40744, 0))
+	assert.Equal(t, "u4pruydqq", Encode(57.64911, 10.40744, 12))
+}
+
+func TestSearchPrefixes(t *testing.T) {
+	austinLat, austinLng := 30.2672, -97.7431
+	dallasLat, dallasLng := 32.7767, -96.7970
+
+	covered := func(prefixes []string, hash string) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(hash, prefix) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	prefixes := SearchPrefixes(austinLat, austinLng, 10)
+	assert.True(t, covered(prefixes, Encode(austinLat+0.05, austinLng-0.05, MaxPrecision)))
+	assert.False(t, covered(prefixes, Encode(dallasLat, dallasLng, MaxPrecision)))
+
+	prefixes = SearchPrefixes(austinLat, austinLng, 300)
+	assert.True(t, covered(prefixes, Encode(dallasLat, dallasLng, MaxPrecision)))
+
+	// A radius larger than any cell is searched with the largest cells rather than the whole world.
+	prefixes = SearchPrefixes(70, austinLng, 500)
+	assert.NotContains(t, prefixes, "")
+	assert.True(t, covered(prefixes, Encode(72, austinLng+12, MaxPrecision)))
+
+	// Every longitude is within a radius reaching the pole.
+	prefixes = SearchPrefixes(88, austinLng, 500)
+	assert.True(t, covered(prefixes, Encode(88, austinLng+180, MaxPrecision)))
+}
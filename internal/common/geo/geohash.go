@@ -0,0 +1,137 @@
+package geo
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// MaxPrecision is the longest geohash produced by this package.
+const MaxPrecision = 9
+
+// Encode returns the geohash of a coordinate with the given precision.
+// A precision outside of 1 to MaxPrecision is clamped to that range.
+func Encode(lat, lng float64, precision int) string {
+	if precision < 1 {
+		precision = 1
+	}
+
+	if precision > MaxPrecision {
+		precision = MaxPrecision
+	}
+
+	latRange := [2]float64{-90, 90}
+	lngRange := [2]float64{-180, 180}
+
+	var hash strings.Builder
+
+	even := true
+	bit := 0
+	ch := 0
+
+	for hash.Len() < precision {
+		if even {
+			mid := (lngRange[0] + lngRange[1]) / 2
+			if lng >= mid {
+				ch |= 1 << (4 - bit)
+				lngRange[0] = mid
+			} else {
+				lngRange[1] = mid
+			}
+		} else {
+			mid := (latRange[0] + latRange[1]) / 2
+			if lat >= mid {
+				ch |= 1 << (4 - bit)
+				latRange[0] = mid
+			} else {
+				latRange[1] = mid
+			}
+		}
+
+		even = !even
+
+		if bit < 4 {
+			bit++
+		} else {
+			hash.WriteByte(base32[ch])
+			bit = 0
+			ch = 0
+		}
+	}
+
+	return hash.String()
+}
+
+// cellSizeDegrees returns the height and width in degrees of a geohash cell with the given precision.
+func cellSizeDegrees(precision int) (lat, lng float64) {
+	bits := precision * 5
+	lngBits := (bits + 1) / 2
+	latBits := bits / 2
+
+	return 180 / math.Pow(2, float64(latBits)), 360 / math.Pow(2, float64(lngBits))
+}
+
+// SearchPrefixes returns the geohash prefixes that together cover every point within radiusKm of a
+// coordinate. Prefixes are never empty, so at most the cells of a quarter of the world are searched.
+func SearchPrefixes(lat, lng, radiusKm float64) []string {
+	const kmPerDegree = math.Pi * EarthRadiusKm / 180
+
+	// The largest cells are used if no cell is as large as the radius.
+	precision := 1
+
+	for p := MaxPrecision; p >= 1; p-- {
+		latSize, lngSize := cellSizeDegrees(p)
+		heightKm := latSize * kmPerDegree
+		widthKm := lngSize * kmPerDegree * math.Cos(radians(math.Min(math.Abs(lat)+latSize, 90)))
+
+		// The cell and its neighbors cover the radius if a cell is at least as large as the radius.
+		if heightKm >= radiusKm && widthKm >= radiusKm {
+			precision = p
+			break
+		}
+	}
+
+	latSize, lngSize := cellSizeDegrees(precision)
+	unique := map[string]bool{}
+
+	for _, dLat := range []float64{-1, 0, 1} {
+		for _, dLng := range []float64{-1, 0, 1} {
+			nLat := math.Max(-90, math.Min(90, lat+dLat*latSize))
+			nLng := wrapLongitude(lng + dLng*lngSize)
+			unique[Encode(nLat, nLng, precision)] = true
+		}
+	}
+
+	// A radius reaching a pole covers every longitude around it, which the neighbors of the largest cells
+	// don't, so every cell touching the pole is added.
+	for _, pole := range []float64{-90, 90} {
+		if DistanceKm(lat, lng, pole, 0) <= radiusKm {
+			for nLng := -180 + lngSize/2; nLng < 180; nLng += lngSize {
+				unique[Encode(pole, nLng, precision)] = true
+			}
+		}
+	}
+
+	var prefixes []string
+	for prefix := range unique {
+		prefixes = append(prefixes, prefix)
+	}
+
+	sort.Strings(prefixes)
+
+	return prefixes
+}
+
+func wrapLongitude(lng float64) float64 {
+	for lng > 180 {
+		lng -= 360
+	}
+
+	for lng < -180 {
+		lng += 360
+	}
+
+	return lng
+}
@@ -0,0 +1,89 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	swaggerFiles "github.com/swaggo/files"
+	"gopher-cache/internal/common/server/httperr"
+	"html/template"
+	"net/http"
+	"path"
+)
+
+// SpecHandler serves a spec in JSON.
+func SpecHandler(doc *openapi3.T) http.Handler {
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		panic(err)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(spec)
+	})
+}
+
+var swaggerUITemplate = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{.Title}}</title>
+  <link rel="stylesheet" href="{{.AssetsURL}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="{{.AssetsURL}}/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({url: {{.SpecURL}}, dom_id: "#swagger-ui"});
+    };
+  </script>
+</body>
+</html>
+`))
+
+// SwaggerUIHandler serves Swagger UI for the spec at specURL. Its scripts and styles are loaded from
+// assetsURL, where SwaggerUIAssetHandler should be served.
+func SwaggerUIHandler(title, specURL, assetsURL string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = swaggerUITemplate.Execute(w, struct {
+			Title     string
+			SpecURL   string
+			AssetsURL string
+		}{title, specURL, assetsURL})
+	})
+}
+
+// swaggerUIAssets are the files of Swagger UI used by the page of SwaggerUIHandler.
+var swaggerUIAssets = map[string]bool{"swagger-ui.css": true, "swagger-ui-bundle.js": true}
+
+// SwaggerUIAssetHandler serves the scripts and styles of Swagger UI by the last element of the request's path,
+// e.g. swagger-ui.css for /api/docs/swagger-ui.css. They are bundled with the service so the documentation
+// works without reaching a CDN.
+func SwaggerUIAssetHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := path.Base(r.URL.Path)
+		if !swaggerUIAssets[name] {
+			httperr.NotFound("asset-not-found", fmt.Errorf("no asset %s", name), w, r)
+			return
+		}
+
+		f, err := swaggerFiles.HTTP.Open("/" + name)
+		if err != nil {
+			httperr.InternalError("asset-not-bundled", err, w, r)
+			return
+		}
+		defer f.Close()
+
+		info, err := f.Stat()
+		if err != nil {
+			httperr.InternalError("asset-not-bundled", err, w, r)
+			return
+		}
+
+		http.ServeContent(w, r, name, info.ModTime(), f)
+	})
+}
@@ -0,0 +1,91 @@
+// Package openapi validates requests against the OpenAPI 3 spec of an API and serves its documentation. The
+// server interface and models of an API are generated from its spec with oapi-codegen and the spec is loaded
+// with kin-openapi.
+package openapi
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+)
+
+// NewRequestValidator returns middleware that responds with an incorrect input error listing the field errors
+// of requests whose params or JSON body don't match their operation in doc. The operation of a request is
+// found with the routes it is handled by, whose patterns must be the paths of doc, so the middleware should
+// be used by the router of the API. Requests that don't match an operation are left to the router. Params
+// and fields that aren't in the spec are allowed and security requirements aren't checked.
+func NewRequestValidator(doc *openapi3.T, routes chi.Routes) func(http.Handler) http.Handler {
+	if doc == nil {
+		panic("nil doc")
+	}
+	if routes == nil {
+		panic("nil routes")
+	}
+
+	options := &openapi3filter.Options{
+		MultiError:         true,
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, ok := findRoute(doc, routes, r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			err := openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			})
+			if err != nil {
+				respondWithValidationError(err, w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// findRoute returns the operation of doc that routes handles r with and the values of its path params.
+func findRoute(doc *openapi3.T, routes chi.Routes, r *http.Request) (*routers.Route, map[string]string, bool) {
+	rctx := chi.NewRouteContext()
+	if !routes.Match(rctx, r.Method, requestPath(r)) {
+		return nil, nil, false
+	}
+
+	path := rctx.RoutePattern()
+
+	item := doc.Paths[path]
+	if item == nil {
+		return nil, nil, false
+	}
+
+	op := item.GetOperation(r.Method)
+	if op == nil {
+		return nil, nil, false
+	}
+
+	pathParams := make(map[string]string, len(rctx.URLParams.Keys))
+	for i, key := range rctx.URLParams.Keys {
+		pathParams[key] = rctx.URLParams.Values[i]
+	}
+
+	return &routers.Route{Spec: doc, Path: path, PathItem: item, Method: r.Method, Operation: op}, pathParams, true
+}
+
+// requestPath returns the path of r within the router the validator is used by. It is the path left to
+// route if the router is mounted, e.g. /games for a request to /api/games.
+func requestPath(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePath != "" {
+		return rctx.RoutePath
+	}
+
+	return r.URL.Path
+}
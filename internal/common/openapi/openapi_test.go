@@ -0,0 +1,267 @@
+package openapi
+
+import (
+	"encoding/json"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/common/server/httperr"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testSpec = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test API", "version": "1.0.0"},
+  "servers": [{"url": "/api"}],
+  "paths": {
+    "/games": {
+      "get": {
+        "operationId": "GetGames",
+        "parameters": [
+          {"name": "limit", "in": "query", "schema": {"type": "integer", "minimum": 1, "maximum": 100}},
+          {"name": "q", "in": "query", "required": true, "schema": {"type": "string"}}
+        ],
+        "responses": {"200": {"description": "The games."}}
+      },
+      "post": {
+        "operationId": "CreateGame",
+        "summary": "Creates a game.",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/CreateGame"}}}},
+        "responses": {"200": {"description": "The game was created."}}
+      }
+    },
+    "/games/search": {
+      "get": {"operationId": "SearchGames", "responses": {"200": {"description": "The games."}}}
+    },
+    "/games/{uuid}": {
+      "get": {
+        "operationId": "GetGame",
+        "parameters": [{"name": "uuid", "in": "path", "required": true, "schema": {"type": "string", "minLength": 3}}],
+        "responses": {"200": {"description": "The game."}}
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "CreateGame": {
+        "type": "object",
+        "description": "A game to create.",
+        "required": ["title", "kind", "levels"],
+        "properties": {
+          "title": {"type": "string", "maxLength": 5},
+          "kind": {"type": "string", "enum": ["urban"]},
+          "levels": {"type": "array", "maxItems": 2, "items": {"$ref": "#/components/schemas/Level"}},
+          "location": {"nullable": true, "allOf": [{"$ref": "#/components/schemas/Location"}]},
+          "tags": {"type": "object", "additionalProperties": {"type": "integer"}},
+          "createdAt": {"type": "string", "format": "date-time"}
+        }
+      },
+      "Level": {
+        "type": "object",
+        "required": ["answers"],
+        "properties": {
+          "answers": {"type": "array", "items": {"type": "string"}},
+          "points": {"type": "integer", "minimum": 0}
+        }
+      },
+      "Location": {
+        "type": "object",
+        "required": ["latitude"],
+        "properties": {"latitude": {"type": "number", "minimum": -90, "maximum": 90}}
+      }
+    }
+  }
+}`
+
+func loadTestSpec(t *testing.T) *openapi3.T {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(testSpec))
+	require.NoError(t, err)
+
+	return doc
+}
+
+// validate sends a request through the validator to a router that echoes the body it receives.
+func validate(t *testing.T, method, target, body string) *httptest.ResponseRecorder {
+	router := chi.NewRouter()
+	router.Use(logs.NewStructuredLogger(logrus.StandardLogger()))
+	router.Use(NewRequestValidator(loadTestSpec(t), router))
+
+	echo := func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		_, _ = w.Write(b)
+	}
+	router.Get("/games", echo)
+	router.Post("/games", echo)
+	router.Get("/games/search", echo)
+	router.Get("/games/{uuid}", echo)
+	router.Get("/other", echo)
+
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	return w
+}
+
+func fieldErrors(t *testing.T, w *httptest.ResponseRecorder) []errors.FieldError {
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	var resp httperr.ErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "invalid-request", resp.Slug)
+
+	return resp.Fields
+}
+
+func TestRequestValidator_Body(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		body := `{"title": "Hunt", "kind": "urban", "levels": [{"answers": ["a"]}], "location": null, "extra": true}`
+
+		w := validate(t, http.MethodPost, "/games", body)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, body, w.Body.String(), "the handler should get the whole body")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		body := `{
+			"title": "Scavenger",
+			"kind": "rural",
+			"levels": [{"answers": [1]}, {"points": 1.5}, {"answers": []}],
+			"location": {"latitude": 91},
+			"tags": {"a": "b"},
+			"createdAt": "yesterday"
+		}`
+
+		fields := fieldErrors(t, validate(t, http.MethodPost, "/games", body))
+
+		assert.Equal(t, []errors.FieldError{
+			{Field: "createdAt", Rule: RuleFormat},
+			{Field: "kind", Rule: RuleEnum},
+			{Field: "levels", Rule: RuleMaxItems, Limit: 2},
+			{Field: "levels[0].answers[0]", Rule: RuleType},
+			{Field: "levels[1].answers", Rule: RuleRequired},
+			{Field: "levels[1].points", Rule: RuleType},
+			{Field: "location.latitude", Rule: RuleMaximum, Limit: 90},
+			{Field: "tags.a", Rule: RuleType},
+			{Field: "title", Rule: RuleMaxLength, Limit: 5},
+		}, fields)
+	})
+
+	t.Run("missing fields", func(t *testing.T) {
+		fields := fieldErrors(t, validate(t, http.MethodPost, "/games", `{}`))
+
+		assert.Equal(t, []errors.FieldError{
+			{Field: "kind", Rule: RuleRequired},
+			{Field: "levels", Rule: RuleRequired},
+			{Field: "title", Rule: RuleRequired},
+		}, fields)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		fields := fieldErrors(t, validate(t, http.MethodPost, "/games", `[]`))
+
+		assert.Equal(t, []errors.FieldError{{Field: "body", Rule: RuleType}}, fields)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		fields := fieldErrors(t, validate(t, http.MethodPost, "/games", ""))
+
+		assert.Equal(t, []errors.FieldError{{Field: "body", Rule: RuleRequired}}, fields)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		w := validate(t, http.MethodPost, "/games", `{"title": `)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid-request-body")
+	})
+}
+
+func TestRequestValidator_Params(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		wantFields []errors.FieldError
+	}{
+		{"valid", "/games?q=hunt&limit=10&city=Austin", nil},
+		{"empty params aren't given", "/games?q=hunt&limit=", nil},
+		{"required", "/games", []errors.FieldError{{Field: "q", Rule: RuleRequired}}},
+		{"wrong type", "/games?q=hunt&limit=ten", []errors.FieldError{{Field: "limit", Rule: RuleType}}},
+		{"out of range", "/games?q=hunt&limit=101", []errors.FieldError{{Field: "limit", Rule: RuleMaximum, Limit: 100}}},
+		{"path", "/games/ab", []errors.FieldError{{Field: "uuid", Rule: RuleMinLength, Limit: 3}}},
+		{"static paths are matched first", "/games/search", nil},
+		{"unknown route", "/other?limit=ten", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := validate(t, http.MethodGet, tt.target, "")
+
+			if tt.wantFields == nil {
+				assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+				return
+			}
+
+			assert.Equal(t, tt.wantFields, fieldErrors(t, w))
+		})
+	}
+
+	t.Run("mounted", func(t *testing.T) {
+		api := chi.NewRouter()
+		api.Use(logs.NewStructuredLogger(logrus.StandardLogger()))
+		api.Use(NewRequestValidator(loadTestSpec(t), api))
+		api.Get("/games", func(w http.ResponseWriter, r *http.Request) {})
+
+		root := chi.NewRouter()
+		root.Mount("/api", api)
+
+		w := httptest.NewRecorder()
+		root.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/games?limit=0", nil))
+
+		assert.Equal(t, []errors.FieldError{
+			{Field: "limit", Rule: RuleMinimum, Limit: 1},
+			{Field: "q", Rule: RuleRequired},
+		}, fieldErrors(t, w))
+	})
+}
+
+func TestSwaggerUIHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	SwaggerUIHandler("Test API", "openapi.json", "docs").ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "<title>Test API</title>")
+	assert.Contains(t, w.Body.String(), `url: "openapi.json"`)
+	assert.Contains(t, w.Body.String(), `src="docs/swagger-ui-bundle.js"`)
+	assert.NotContains(t, w.Body.String(), "https://", "the page shouldn't load anything from a CDN")
+}
+
+func TestSwaggerUIAssetHandler(t *testing.T) {
+	router := chi.NewRouter()
+	router.Use(logs.NewStructuredLogger(logrus.StandardLogger()))
+	router.Method(http.MethodGet, "/docs/*", SwaggerUIAssetHandler())
+
+	for _, name := range []string{"swagger-ui.css", "swagger-ui-bundle.js"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/"+name, nil))
+
+		assert.Equal(t, http.StatusOK, w.Code, name)
+		assert.NotEmpty(t, w.Body.Bytes(), name)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/index.html", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,213 @@
+package openapi
+
+import (
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/common/server/httperr"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// invalidRequestSlug is the slug of the errors sent for requests that don't match their operation.
+const invalidRequestSlug = "invalid-request"
+
+// These are the rules a value can break when it is validated against a schema. Rules of JSON Schema that
+// aren't listed here are named after their keyword in kebab case, e.g. unique-items.
+const (
+	RuleRequired  = "required"
+	RuleType      = "type"
+	RuleFormat    = "format"
+	RuleEnum      = "enum"
+	RuleMinimum   = "minimum"
+	RuleMaximum   = "maximum"
+	RuleMinLength = "min-length"
+	RuleMaxLength = "max-length"
+	RuleMinItems  = "min-items"
+	RuleMaxItems  = "max-items"
+)
+
+// respondWithValidationError responds with the field errors of err, which was returned by
+// openapi3filter.ValidateRequest. Bodies that can't be decoded are bad requests without field errors.
+func respondWithValidationError(err error, w http.ResponseWriter, r *http.Request) {
+	var fields []errors.FieldError
+	if err := collectFieldErrors(err, &fields); err != nil {
+		httperr.BadRequest("invalid-request-body", err, w, r)
+		return
+	}
+
+	// Fields are sorted so the same request always gets its field errors in the same order.
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].Field < fields[j].Field })
+
+	messages := make([]string, len(fields))
+	for i, f := range fields {
+		messages[i] = fieldErrorMessage(f)
+	}
+
+	httperr.RespondWithSlugError(errors.NewIncorrectInputError(strings.Join(messages, "; "), invalidRequestSlug, fields...), w, r)
+}
+
+// collectFieldErrors adds the field errors of a validation error to fields. It returns the first error that
+// isn't about a param or field, such as a body that isn't JSON.
+func collectFieldErrors(err error, fields *[]errors.FieldError) error {
+	switch err := err.(type) {
+	case openapi3.MultiError:
+		for _, err := range err {
+			if err := collectFieldErrors(err, fields); err != nil {
+				return err
+			}
+		}
+	case *openapi3filter.RequestError:
+		switch {
+		case err.Err == openapi3filter.ErrInvalidRequired && err.Parameter != nil:
+			*fields = append(*fields, errors.FieldError{Field: err.Parameter.Name, Rule: RuleRequired})
+		case err.Err == openapi3filter.ErrInvalidRequired && err.RequestBody != nil:
+			*fields = append(*fields, errors.FieldError{Field: "body", Rule: RuleRequired})
+		case err.Parameter != nil:
+			if _, ok := err.Err.(*openapi3filter.ParseError); ok {
+				*fields = append(*fields, errors.FieldError{Field: err.Parameter.Name, Rule: RuleType})
+				return nil
+			}
+
+			return collectSchemaErrors(err.Err, err.Parameter.Name, fields)
+		case err.RequestBody != nil && err.Err != nil:
+			if collectSchemaErrors(err.Err, "", fields) != nil {
+				return err
+			}
+		default:
+			return err
+		}
+	default:
+		return err
+	}
+
+	return nil
+}
+
+// collectSchemaErrors adds a field error for each schema error in err, which is a *openapi3.SchemaError or an
+// openapi3.MultiError of them. The fields are relative to parent, which is the name of a param or empty for
+// the root of a body.
+func collectSchemaErrors(err error, parent string, fields *[]errors.FieldError) error {
+	if multi, ok := err.(openapi3.MultiError); ok {
+		for _, err := range multi {
+			if err := collectSchemaErrors(err, parent, fields); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	schemaErr, ok := err.(*openapi3.SchemaError)
+	if !ok {
+		return err
+	}
+
+	field := parent
+	for _, key := range schemaErr.JSONPointer() {
+		if _, err := strconv.Atoi(key); err == nil {
+			field += "[" + key + "]"
+		} else {
+			field = fieldPath(field, key)
+		}
+	}
+
+	// The schemas of allOf are validated against the same value, so their errors are relative to this field.
+	if schemaErr.SchemaField == "allOf" && schemaErr.Origin != nil {
+		return collectSchemaErrors(schemaErr.Origin, field, fields)
+	}
+
+	// A body that is the wrong type as a whole is reported as the field body.
+	if field == "" {
+		field = "body"
+	}
+
+	rule, limit := schemaRule(schemaErr)
+	*fields = append(*fields, errors.FieldError{Field: field, Rule: rule, Limit: limit})
+
+	return nil
+}
+
+// schemaRule returns the rule a schema error broke with the limit of the rule or 0 if it has none.
+func schemaRule(err *openapi3.SchemaError) (string, int) {
+	s := err.Schema
+
+	switch err.SchemaField {
+	case "required":
+		return RuleRequired, 0
+	case "type", "nullable":
+		return RuleType, 0
+	case "format":
+		return RuleFormat, 0
+	case "enum":
+		return RuleEnum, 0
+	case "minimum":
+		return RuleMinimum, int(*s.Min)
+	case "maximum":
+		return RuleMaximum, int(*s.Max)
+	case "minLength":
+		return RuleMinLength, int(s.MinLength)
+	case "maxLength":
+		return RuleMaxLength, int(*s.MaxLength)
+	case "minItems":
+		return RuleMinItems, int(s.MinItems)
+	case "maxItems":
+		return RuleMaxItems, int(*s.MaxItems)
+	default:
+		return kebabCase(err.SchemaField), 0
+	}
+}
+
+// fieldPath returns the path of field in parent, which may be empty.
+func fieldPath(parent, field string) string {
+	if parent == "" {
+		return field
+	}
+
+	return parent + "." + field
+}
+
+// kebabCase converts a JSON Schema keyword such as uniqueItems to kebab case.
+func kebabCase(keyword string) string {
+	var b strings.Builder
+	for _, r := range keyword {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteByte('-')
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// fieldErrorMessage describes a field error for the message of an error.
+func fieldErrorMessage(f errors.FieldError) string {
+	switch f.Rule {
+	case RuleRequired:
+		return fmt.Sprintf("%s is required", f.Field)
+	case RuleType:
+		return fmt.Sprintf("%s has the wrong type", f.Field)
+	case RuleFormat:
+		return fmt.Sprintf("%s has the wrong format", f.Field)
+	case RuleEnum:
+		return fmt.Sprintf("%s isn't one of the allowed values", f.Field)
+	case RuleMinimum:
+		return fmt.Sprintf("%s is less than %d", f.Field, f.Limit)
+	case RuleMaximum:
+		return fmt.Sprintf("%s is more than %d", f.Field, f.Limit)
+	case RuleMinLength:
+		return fmt.Sprintf("%s is shorter than %d characters", f.Field, f.Limit)
+	case RuleMaxLength:
+		return fmt.Sprintf("%s is longer than %d characters", f.Field, f.Limit)
+	case RuleMinItems:
+		return fmt.Sprintf("%s has fewer than %d items", f.Field, f.Limit)
+	case RuleMaxItems:
+		return fmt.Sprintf("%s has more than %d items", f.Field, f.Limit)
+	default:
+		return fmt.Sprintf("%s breaks rule %s", f.Field, f.Rule)
+	}
+}
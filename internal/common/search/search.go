@@ -0,0 +1,140 @@
+// Package search implements a library for analyzing text for full-text search.
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopWords are common words which are left out of the index since they don't help find anything.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "in": true, "is": true, "it": true, "of": true, "on": true, "or": true,
+	"the": true, "this": true, "to": true, "was": true, "with": true,
+}
+
+// Terms splits text into lower cased words, drops stop words and stems what is left.
+func Terms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		if stopWords[w] {
+			continue
+		}
+
+		terms = append(terms, Stem(w))
+	}
+
+	return terms
+}
+
+// suffixes are stripped by Stem in the order given. The first suffix that matches wins.
+var suffixes = []struct {
+	suffix      string
+	replacement string
+}{
+	{"ational", "ate"},
+	{"ization", "ize"},
+	{"fulness", "ful"},
+	{"iveness", "ive"},
+	{"ousness", "ous"},
+	{"ations", "ate"},
+	{"ation", "ate"},
+	{"ings", ""},
+	{"ing", ""},
+	{"ies", "y"},
+	{"edly", ""},
+	{"ed", ""},
+	{"ly", ""},
+	{"sses", "ss"},
+	{"ches", "ch"},
+	{"shes", "sh"},
+	{"xes", "x"},
+	{"s", ""},
+}
+
+// Stem reduces an English word to a root so different forms of the word match each other,
+// e.g. pirates and pirate or walking and walk. It is a light stemmer that only strips
+// common suffixes. Stems may not be real words.
+func Stem(word string) string {
+	// Leave short words alone since stripping them would lose too much meaning.
+	if len([]rune(word)) <= 3 {
+		return word
+	}
+
+	for _, s := range suffixes {
+		if !strings.HasSuffix(word, s.suffix) {
+			continue
+		}
+
+		stem := strings.TrimSuffix(word, s.suffix) + s.replacement
+
+		// Don't strip s from words like glass or bus.
+		if s.suffix == "s" && (strings.HasSuffix(stem, "s") || strings.HasSuffix(stem, "u")) {
+			return word
+		}
+
+		if len([]rune(stem)) < 3 {
+			return word
+		}
+
+		return stem
+	}
+
+	return word
+}
+
+// MaxTypos returns the number of typos allowed when matching a term. Longer terms allow more typos.
+func MaxTypos(term string) int {
+	switch n := len([]rune(term)); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// EditDistance returns the Levenshtein distance between a and b.
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,42 @@
+package search
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"pirate", "downtown", "history"}, Terms("The Pirates of Downtown History!"))
+	assert.Equal(t, []string{"café", "crawl"}, Terms("Café crawl"))
+	assert.Equal(t, []string{}, Terms("the and of"))
+}
+
+func TestStem(t *testing.T) {
+	for word, stem := range map[string]string{
+		"pirates":  "pirate",
+		"stories":  "story",
+		"walking":  "walk",
+		"glass":    "glass",
+		"bus":      "bus",
+		"hunted":   "hunt",
+		"location": "locate",
+		"sing":     "sing",
+		"churches": "church",
+	} {
+		assert.Equal(t, stem, Stem(word), word)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, EditDistance("pirate", "pirate"))
+	assert.Equal(t, 1, EditDistance("pirate", "pirat"))
+	assert.Equal(t, 1, EditDistance("pirate", "piratw"))
+	assert.Equal(t, 2, EditDistance("history", "hsitory"))
+	assert.Equal(t, 3, EditDistance("", "abc"))
+}
+
+func TestMaxTypos(t *testing.T) {
+	assert.Equal(t, 0, MaxTypos("bar"))
+	assert.Equal(t, 1, MaxTypos("pirate"))
+	assert.Equal(t, 2, MaxTypos("downtown"))
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"gopher-cache/internal/common/auth"
+	"gopher-cache/internal/common/server/grpcerr"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// RunGRPCServer runs a gRPC server listening on the port specified by GRPC_PORT in the environment.
+// This function will block until the server is running.
+// On SIGINT or SIGTERM the server will be stopped gracefully.
+// Calls are authenticated with the same ID tokens as the HTTP server, or with an API key found in apiKeys.
+// API keys aren't accepted if it is nil. Errors returned by the services registered by registerServer are
+// sent with the gRPC status for their ErrorType.
+func RunGRPCServer(ctx context.Context, apiKeys auth.APIKeyStore, registerServer func(server *grpc.Server)) {
+	authInterceptor := auth.GrpcAuthInterceptor{Tokens: tokenAuthenticator(nil), APIKeys: apiKeys}
+
+	// Errors are converted last so those of authentication get a status too.
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcerr.UnaryServerInterceptor, authInterceptor.Unary),
+		grpc.ChainStreamInterceptor(grpcerr.StreamServerInterceptor, authInterceptor.Stream),
+	)
+	registerServer(srv)
+
+	listener, err := net.Listen("tcp", ":"+os.Getenv("GRPC_PORT"))
+	if err != nil {
+		logrus.WithError(err).Fatal("Unable to listen for gRPC calls")
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-sigs:
+		case <-ctx.Done():
+		}
+		srv.GracefulStop()
+	}()
+
+	_ = srv.Serve(listener)
+}
@@ -0,0 +1,144 @@
+// Package grpcerr implements a library for dealing with gRPC errors.
+package grpcerr
+
+import (
+	"context"
+	stderrors "errors"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopher-cache/internal/common/errors"
+	"strconv"
+)
+
+// ErrorDomain is the domain of the ErrorInfo sent with errors, whose reason is the slug of the error.
+const ErrorDomain = "gopher-cache"
+
+// Status returns the gRPC status for err based on its ErrorType the same way as httperr.RespondWithSlugError
+// picks the HTTP status. err may wrap a SlugError. Errors that are already a status or are from a context
+// keep their code. If err is a plain error type then the status is Internal.
+//
+// The slug is sent as the reason of an ErrorInfo detail and the fields of incorrect input as a BadRequest
+// detail, whose descriptions are the rule and limit of each field, e.g. "max-length 64".
+func Status(err error) *status.Status {
+	if s, ok := status.FromError(err); ok {
+		return s
+	}
+
+	if stderrors.Is(err, context.Canceled) {
+		return status.New(codes.Canceled, err.Error())
+	}
+
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return status.New(codes.DeadlineExceeded, err.Error())
+	}
+
+	var slugError errors.SlugError
+	if !stderrors.As(err, &slugError) {
+		return withDetails(status.New(codes.Internal, "internal server error"), "internal-server-error", nil)
+	}
+
+	var code codes.Code
+	switch slugError.ErrorType() {
+	case errors.ErrorTypeAuthentication:
+		code = codes.Unauthenticated
+	case errors.ErrorTypeAuthorization:
+		code = codes.PermissionDenied
+	case errors.ErrorTypeIncorrectInput:
+		code = codes.InvalidArgument
+	case errors.ErrorTypeNotFound:
+		code = codes.NotFound
+	case errors.ErrorTypeConflict:
+		// Conflicts depend on the state of what the call changes, so it shouldn't be retried before that
+		// state has changed.
+		code = codes.FailedPrecondition
+	default:
+		code = codes.Internal
+	}
+
+	msg := slugError.Error()
+	if code == codes.Internal {
+		// The messages of internal errors may give away details of the server.
+		msg = "internal server error"
+	}
+
+	return withDetails(status.New(code, msg), slugError.Slug(), slugError.Fields())
+}
+
+func withDetails(s *status.Status, slug string, fields []errors.FieldError) *status.Status {
+	details := []proto.Message{&errdetails.ErrorInfo{Reason: slug, Domain: ErrorDomain}}
+
+	if len(fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, f := range fields {
+			description := f.Rule
+			if f.Limit != 0 {
+				description += " " + strconv.Itoa(f.Limit)
+			}
+
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       f.Field,
+				Description: description,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	withDetails, err := s.WithDetails(details...)
+	if err != nil {
+		return s
+	}
+
+	return withDetails
+}
+
+// UnaryServerInterceptor logs the errors returned by unary calls and converts them to a Status.
+func UnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, respondWithError(err, info.FullMethod)
+	}
+
+	return resp, nil
+}
+
+// StreamServerInterceptor logs the errors returned by streaming calls and converts them to a Status.
+func StreamServerInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := handler(srv, ss); err != nil {
+		return respondWithError(err, info.FullMethod)
+	}
+
+	return nil
+}
+
+func respondWithError(err error, method string) error {
+	s := Status(err)
+
+	slug := ""
+	for _, d := range s.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			slug = info.Reason
+		}
+	}
+
+	logrus.WithError(err).WithFields(logrus.Fields{
+		"grpc_method": method,
+		"grpc_code":   s.Code().String(),
+		"error-slug":  slug,
+	}).Warn("Call failed")
+
+	return s.Err()
+}
@@ -0,0 +1,74 @@
+package grpcerr
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopher-cache/internal/common/errors"
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+		wantSlug string
+	}{
+		{"plain", fmt.Errorf("boom"), codes.Internal, "internal-server-error"},
+		{"authentication", errors.NewAuthenticationError("who?", "invalid-token"), codes.Unauthenticated, "invalid-token"},
+		{"authorization", errors.NewAuthorizationError("no", "not-player"), codes.PermissionDenied, "not-player"},
+		{"incorrect input", errors.NewIncorrectInputError("bad", "invalid-game"), codes.InvalidArgument, "invalid-game"},
+		{"not found", errors.NewNotFoundError("gone", "game-not-found"), codes.NotFound, "game-not-found"},
+		{"conflict", errors.NewConflictError("again", "game-already-exists"), codes.FailedPrecondition, "game-already-exists"},
+		{"unknown type", errors.NewSlugError("boom", "oidc-discovery-failed"), codes.Internal, "oidc-discovery-failed"},
+		{
+			"wrapped",
+			fmt.Errorf("getting game: %w", errors.NewNotFoundError("gone", "game-not-found")),
+			codes.NotFound,
+			"game-not-found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Status(tt.err)
+
+			assert.Equal(t, tt.wantCode, s.Code())
+			require.Len(t, s.Details(), 1)
+			assert.Equal(t, tt.wantSlug, s.Details()[0].(*errdetails.ErrorInfo).Reason)
+		})
+	}
+
+	t.Run("internal errors are hidden", func(t *testing.T) {
+		assert.Equal(t, "internal server error", Status(fmt.Errorf("connecting to 10.0.0.1")).Message())
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		err := errors.NewIncorrectInputError("bad", "invalid-game",
+			errors.FieldError{Field: "title", Rule: "max-length", Limit: 64},
+			errors.FieldError{Field: "levels[0].answers", Rule: "required"},
+		)
+
+		s := Status(err)
+
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+		require.Len(t, s.Details(), 2)
+
+		var fields []string
+		for _, v := range s.Details()[1].(*errdetails.BadRequest).FieldViolations {
+			fields = append(fields, v.Field+": "+v.Description)
+		}
+		assert.Equal(t, []string{"title: max-length 64", "levels[0].answers: required"}, fields)
+	})
+
+	t.Run("statuses and context errors keep their code", func(t *testing.T) {
+		assert.Equal(t, codes.Unavailable, Status(status.Error(codes.Unavailable, "down")).Code())
+		assert.Equal(t, codes.Canceled, Status(context.Canceled).Code())
+		assert.Equal(t, codes.DeadlineExceeded, Status(fmt.Errorf("reading: %w", context.DeadlineExceeded)).Code())
+	})
+}
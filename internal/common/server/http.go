@@ -1,10 +1,11 @@
-// Package server implements a library helping with basic HTTP server operations.
+// Package server implements a library helping with basic HTTP and gRPC server operations.
 package server
 
 import (
 	"context"
 	firebase "firebase.google.com/go"
-	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/api/option"
 	"gopher-cache/internal/common/auth"
@@ -15,17 +16,34 @@ import (
 	"strconv"
 	"sync"
 	"syscall"
+	"time"
 )
 
 // RunHTTPServer runs an HTTP server listening on the port specified by PORT in the environment.
 // This function will block until the server is running.
 // On SIGINT or SIGTERM the server will be shutdown cleanly.
-func RunHTTPServer(ctx context.Context, createHandler func(router chi.Router) http.Handler) {
+// Requests with an API key are authenticated with apiKeys. API keys aren't accepted if it is nil.
+// Routes added by addPublicRoutes are served under /api without authentication, e.g. the documentation
+// of the API. It may be nil.
+func RunHTTPServer(
+	ctx context.Context,
+	apiKeys auth.APIKeyStore,
+	addPublicRoutes func(router chi.Router),
+	createHandler func(router chi.Router) http.Handler,
+) {
+	rootRouter := chi.NewRouter()
+
 	apiRouter := chi.NewRouter()
-	setMiddlewares(apiRouter)
+	authenticatedRouter := chi.NewRouter()
+	setMiddlewares(rootRouter, apiRouter, authenticatedRouter, apiKeys)
 
-	rootRouter := chi.NewRouter()
-	rootRouter.Mount("/api", createHandler(apiRouter))
+	if addPublicRoutes != nil {
+		addPublicRoutes(apiRouter)
+	}
+
+	// The public routes take precedence since routes are matched before paths mounted at /.
+	apiRouter.Mount("/", createHandler(authenticatedRouter))
+	rootRouter.Mount("/api", apiRouter)
 
 	srv := &http.Server{
 		Addr:    ":" + os.Getenv("PORT"),
@@ -52,16 +70,40 @@ func RunHTTPServer(ctx context.Context, createHandler func(router chi.Router) ht
 	wg.Wait()
 }
 
-func setMiddlewares(router *chi.Mux) {
-	router.Use(logs.NewStructuredLogger(logrus.StandardLogger()))
-	addAuthMiddleware(router)
+func setMiddlewares(rootRouter, apiRouter, authenticatedRouter *chi.Mux, apiKeys auth.APIKeyStore) {
+	// Request IDs are logged and sent as the instance of problem details so errors can be traced.
+	apiRouter.Use(middleware.RequestID)
+	apiRouter.Use(logs.NewStructuredLogger(logrus.StandardLogger()))
+
+	authMiddleware := auth.TokenHttpMiddleware(tokenAuthenticator(rootRouter))
+	if apiKeys != nil {
+		authMiddleware = auth.APIKeyHttpMiddleware{Keys: apiKeys, Fallback: authMiddleware}.Middleware
+	}
+
+	authenticatedRouter.Use(authMiddleware)
 }
 
-func addAuthMiddleware(router *chi.Mux) {
+// tokenAuthenticator returns the authenticator for the ID tokens of the configured identity provider.
+// The local identity provider is served using rootRouter, which is nil for servers that only verify its
+// tokens.
+func tokenAuthenticator(rootRouter *chi.Mux) auth.TokenAuthenticator {
 	if mockAuth, _ := strconv.ParseBool(os.Getenv("MOCK_AUTH")); mockAuth {
 		logrus.Info("Using JWT mock auth")
-		router.Use(auth.HttpMockMiddleware)
-		return
+		return auth.MockTokenAuthenticator{}
+	}
+
+	if localOIDC, _ := strconv.ParseBool(os.Getenv("LOCAL_OIDC")); localOIDC {
+		return localOIDCAuthenticator(rootRouter)
+	}
+
+	if issuer := os.Getenv("OIDC_ISSUER"); issuer != "" {
+		audience := os.Getenv("OIDC_AUDIENCE")
+		if audience == "" {
+			logrus.Fatal("OIDC_AUDIENCE must be set with OIDC_ISSUER")
+		}
+
+		logrus.WithField("issuer", issuer).Info("Using OIDC auth")
+		return auth.NewOIDCHttpMiddleware(issuer, audience, claimNamesFromEnv(), nil)
 	}
 
 	var opts []option.ClientOption
@@ -80,5 +122,50 @@ func addAuthMiddleware(router *chi.Mux) {
 		logrus.WithError(err).Fatal("Unable to create firebase Auth client")
 	}
 
-	router.Use(auth.FirebaseHttpMiddleware{authClient}.Middleware)
+	return auth.FirebaseHttpMiddleware{AuthClient: authClient, Claims: claimNamesFromEnv()}
+}
+
+// claimNamesFromEnv returns the claim names set by AUTH_CLAIM_UUID, AUTH_CLAIM_NAME, AUTH_CLAIM_EMAIL,
+// AUTH_CLAIM_NUMBER and AUTH_CLAIM_ROLES. Those that aren't set are left empty for the default.
+func claimNamesFromEnv() auth.ClaimNames {
+	return auth.ClaimNames{
+		UUID:        os.Getenv("AUTH_CLAIM_UUID"),
+		DisplayName: os.Getenv("AUTH_CLAIM_NAME"),
+		Email:       os.Getenv("AUTH_CLAIM_EMAIL"),
+		Number:      os.Getenv("AUTH_CLAIM_NUMBER"),
+		Roles:       os.Getenv("AUTH_CLAIM_ROLES"),
+	}
+}
+
+// localOIDCAuthenticator serves a development identity provider at /oidc and verifies its tokens with
+// the same discovery and JWKS requests as for a real provider. If rootRouter is nil the provider is
+// expected to be served by the HTTP server.
+func localOIDCAuthenticator(rootRouter *chi.Mux) auth.TokenAuthenticator {
+	url := os.Getenv("LOCAL_OIDC_URL")
+	if url == "" {
+		url = "http://localhost:" + os.Getenv("PORT") + "/oidc"
+	}
+
+	audience := os.Getenv("OIDC_AUDIENCE")
+	if audience == "" {
+		audience = "gopher-cache"
+	}
+
+	if rootRouter == nil {
+		logrus.WithField("issuer", url).Info("Using local OIDC auth")
+		return auth.NewOIDCHttpMiddleware(url, audience, auth.DefaultClaimNames, nil)
+	}
+
+	issuer, err := auth.NewLocalIssuer(url, audience, time.Hour)
+	if err != nil {
+		logrus.WithError(err).Fatal("Unable to create local OIDC issuer")
+	}
+
+	logrus.WithField("issuer", issuer.URL()).Warn("Using local OIDC issuer, which issues tokens to anyone")
+	oidcRouter := chi.NewRouter()
+	oidcRouter.Use(logs.NewStructuredLogger(logrus.StandardLogger()))
+	oidcRouter.Mount("/", issuer.Handler())
+	rootRouter.Mount("/oidc", oidcRouter)
+
+	return auth.NewOIDCHttpMiddleware(issuer.URL(), issuer.Audience(), auth.DefaultClaimNames, nil)
 }
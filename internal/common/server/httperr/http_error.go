@@ -2,6 +2,7 @@
 package httperr
 
 import (
+	stderrors "errors"
 	"github.com/go-chi/render"
 	"gopher-cache/internal/common/errors"
 	"gopher-cache/internal/common/logs"
@@ -18,42 +19,96 @@ func Unauthorised(slug string, err error, w http.ResponseWriter, r *http.Request
 	httpRespondWithError(err, slug, w, r, "Unauthorised", http.StatusUnauthorized)
 }
 
+// Forbidden sends an ErrorResponse to the client with a forbidden error status.
+func Forbidden(slug string, err error, w http.ResponseWriter, r *http.Request) {
+	httpRespondWithError(err, slug, w, r, "Forbidden", http.StatusForbidden)
+}
+
 // BadRequest sends an ErrorResponse to the client with a bad request error status.
 func BadRequest(slug string, err error, w http.ResponseWriter, r *http.Request) {
 	httpRespondWithError(err, slug, w, r, "Bad request", http.StatusBadRequest)
 }
 
-// RespondWithSlugError sends an ErrorResponse to the client based on the ErrorType of the given err.
-// If err is a plain error type then the client will receive an ErrorResponse with an internal error status.
+// NotFound sends an ErrorResponse to the client with a not found error status.
+func NotFound(slug string, err error, w http.ResponseWriter, r *http.Request) {
+	httpRespondWithError(err, slug, w, r, "Not found", http.StatusNotFound)
+}
+
+// MethodNotAllowed sends an ErrorResponse to the client with a method not allowed error status.
+func MethodNotAllowed(slug string, err error, w http.ResponseWriter, r *http.Request) {
+	httpRespondWithError(err, slug, w, r, "Method not allowed", http.StatusMethodNotAllowed)
+}
+
+// Conflict sends an ErrorResponse to the client with a conflict error status.
+func Conflict(slug string, err error, w http.ResponseWriter, r *http.Request) {
+	httpRespondWithError(err, slug, w, r, "Conflict", http.StatusConflict)
+}
+
+// RespondWithSlugError sends an ErrorResponse to the client based on the ErrorType of the given err, which
+// may wrap a SlugError. If err is a plain error type then the client will receive an ErrorResponse with an
+// internal error status.
 func RespondWithSlugError(err error, w http.ResponseWriter, r *http.Request) {
-	slugError, ok := err.(errors.SlugError)
-	if !ok {
+	var slugError errors.SlugError
+	if !stderrors.As(err, &slugError) {
 		InternalError("internal-server-error", err, w, r)
 		return
 	}
 
 	switch slugError.ErrorType() {
+	case errors.ErrorTypeAuthentication:
+		Unauthorised(slugError.Slug(), err, w, r)
 	case errors.ErrorTypeAuthorization:
-		Unauthorised(slugError.Slug(), slugError, w, r)
+		Forbidden(slugError.Slug(), err, w, r)
 	case errors.ErrorTypeIncorrectInput:
-		BadRequest(slugError.Slug(), slugError, w, r)
+		BadRequest(slugError.Slug(), err, w, r)
+	case errors.ErrorTypeNotFound:
+		NotFound(slugError.Slug(), err, w, r)
+	case errors.ErrorTypeConflict:
+		Conflict(slugError.Slug(), err, w, r)
 	default:
-		InternalError(slugError.Slug(), slugError, w, r)
+		InternalError(slugError.Slug(), err, w, r)
 	}
 }
 
 func httpRespondWithError(err error, slug string, w http.ResponseWriter, r *http.Request, logMSg string, status int) {
 	logs.GetLogEntry(r).WithError(err).WithField("error-slug", slug).Warn(logMSg)
-	resp := ErrorResponse{slug, status}
+
+	var fields []errors.FieldError
+
+	var slugError errors.SlugError
+	if stderrors.As(err, &slugError) {
+		fields = slugError.Fields()
+	}
+
+	// The format of errors depends on the Accept header so caches must keep each format apart.
+	w.Header().Add("Vary", "Accept")
+
+	if acceptsProblem(r) {
+		problem := newProblem(err, slug, status, r)
+		if len(fields) > 0 {
+			problem.Extensions["fields"] = fields
+		}
+
+		if err := problem.write(w); err != nil {
+			panic(err)
+		}
+
+		return
+	}
+
+	resp := ErrorResponse{Slug: slug, Fields: fields, httpStatus: status}
 
 	if err := render.Render(w, r, resp); err != nil {
 		panic(err)
 	}
 }
 
-// ErrorResponse represents the response to the client of the HTTP server.
+// ErrorResponse represents the response to the client of the HTTP server. Clients that accept
+// application/problem+json get a Problem instead.
 type ErrorResponse struct {
-	Slug       string `json:"slug"`
+	Slug string `json:"slug"`
+	// Fields lists what is wrong with each incorrect field of the request if that is known.
+	Fields     []errors.FieldError `json:"fields,omitempty"`
 	httpStatus int
 }
 
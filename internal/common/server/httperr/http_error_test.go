@@ -0,0 +1,131 @@
+package httperr
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/common/logs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRespondWithSlugError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantSlug   string
+	}{
+		{"plain", fmt.Errorf("boom"), http.StatusInternalServerError, "internal-server-error"},
+		{"authentication", errors.NewAuthenticationError("who?", "invalid-token"), http.StatusUnauthorized, "invalid-token"},
+		{"authorization", errors.NewAuthorizationError("no", "not-player"), http.StatusForbidden, "not-player"},
+		{"incorrect input", errors.NewIncorrectInputError("bad", "invalid-game"), http.StatusBadRequest, "invalid-game"},
+		{"not found", errors.NewNotFoundError("gone", "game-not-found"), http.StatusNotFound, "game-not-found"},
+		{"conflict", errors.NewConflictError("again", "game-already-exists"), http.StatusConflict, "game-already-exists"},
+		{
+			"wrapped",
+			fmt.Errorf("getting game: %w", errors.NewNotFoundError("gone", "game-not-found")),
+			http.StatusNotFound,
+			"game-not-found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := respond(tt.err, "")
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			var resp ErrorResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, tt.wantSlug, resp.Slug)
+			assert.Empty(t, resp.Fields)
+		})
+	}
+
+	t.Run("fields", func(t *testing.T) {
+		fields := []errors.FieldError{
+			{Field: "title", Rule: "max-length", Limit: 64},
+			{Field: "levels[0].answers", Rule: "required"},
+		}
+
+		w := respond(errors.NewIncorrectInputError("bad", "invalid-game", fields...), "application/json")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{
+			"slug": "invalid-game",
+			"fields": [
+				{"field": "title", "rule": "max-length", "limit": 64},
+				{"field": "levels[0].answers", "rule": "required"}
+			]
+		}`, w.Body.String())
+	})
+}
+
+func TestRespondWithSlugError_Problem(t *testing.T) {
+	t.Run("fields", func(t *testing.T) {
+		err := errors.NewIncorrectInputError("title is required", "invalid-game",
+			errors.FieldError{Field: "title", Rule: "required"})
+
+		w := respond(err, "application/problem+json, application/json;q=0.9")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, "Accept", w.Header().Get("Vary"))
+
+		var problem map[string]interface{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+		assert.NotEmpty(t, problem["instance"])
+		delete(problem, "instance")
+
+		assert.Equal(t, map[string]interface{}{
+			"type":   "https://gophercache.com/problems/invalid-game",
+			"title":  "Bad Request",
+			"status": float64(http.StatusBadRequest),
+			"detail": "title is required",
+			"slug":   "invalid-game",
+			"fields": []interface{}{
+				map[string]interface{}{"field": "title", "rule": "required"},
+			},
+		}, problem)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		w := respond(fmt.Errorf("database password is hunter2"), "application/problem+json")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.NotContains(t, w.Body.String(), "hunter2")
+		assert.Contains(t, w.Body.String(), `"type":"https://gophercache.com/problems/internal-server-error"`)
+	})
+
+	t.Run("not accepted", func(t *testing.T) {
+		w := respond(errors.NewNotFoundError("gone", "game-not-found"), "application/problem+json;q=0, */*")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, `{"slug": "game-not-found"}`, w.Body.String())
+	})
+}
+
+// respond records the response to a request that fails with err. The request goes through the same
+// middlewares as API requests since errors are logged with the request's log entry and ID.
+func respond(err error, accept string) *httptest.ResponseRecorder {
+	handler := middleware.RequestID(logs.NewStructuredLogger(logrus.StandardLogger())(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			RespondWithSlugError(err, w, r)
+		})))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	return w
+}
@@ -0,0 +1,110 @@
+package httperr
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5/middleware"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeURI is the base of the type URI of problems. The slug of the error is appended to it.
+var ProblemTypeURI = "https://gophercache.com/problems/"
+
+// Problem is an RFC 7807 problem details response. It is sent instead of an ErrorResponse to clients that
+// accept application/problem+json.
+type Problem struct {
+	// Type is a URI identifying the type of problem, which is the same for every error with the same slug.
+	Type string `json:"type"`
+	// Title is a short summary of the type of problem.
+	Title string `json:"title"`
+	// Status is the HTTP status of the response.
+	Status int `json:"status"`
+	// Detail explains this occurrence of the problem. It is left empty for internal errors so they don't
+	// leak anything about the server.
+	Detail string `json:"detail,omitempty"`
+	// Instance is the ID of the request, which is logged as req_id.
+	Instance string `json:"instance,omitempty"`
+	// Extensions are members particular to the type of problem, such as the fields of incorrect input.
+	// They are sent as members of the problem alongside the standard members.
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// newProblem creates the Problem of an error sent with slug and status.
+func newProblem(err error, slug string, status int, r *http.Request) Problem {
+	p := Problem{
+		Type:       ProblemTypeURI + slug,
+		Title:      http.StatusText(status),
+		Status:     status,
+		Instance:   middleware.GetReqID(r.Context()),
+		Extensions: map[string]interface{}{"slug": slug},
+	}
+
+	if status < http.StatusInternalServerError && err != nil {
+		p.Detail = err.Error()
+	}
+
+	return p
+}
+
+// MarshalJSON marshals the standard members of the problem and its extensions into one object. Extensions
+// can't replace standard members.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		members[k] = v
+	}
+
+	members["type"] = p.Type
+	members["title"] = p.Title
+	members["status"] = p.Status
+
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	}
+
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	}
+
+	return json.Marshal(members)
+}
+
+// write writes the problem with its content type and HTTP status.
+func (p Problem) write(w http.ResponseWriter) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	_, err = w.Write(body)
+
+	return err
+}
+
+// acceptsProblem reports whether the Accept header of r asks for application/problem+json. Clients that
+// don't keep getting an ErrorResponse.
+func acceptsProblem(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(mediaRange)
+			if err != nil || mediaType != ProblemContentType {
+				continue
+			}
+
+			if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+				continue
+			}
+
+			return true
+		}
+	}
+
+	return false
+}
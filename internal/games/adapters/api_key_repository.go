@@ -0,0 +1,239 @@
+package adapters
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"errors"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopher-cache/internal/common/auth"
+	"sort"
+	"sync"
+	"time"
+)
+
+const apiKeys = "api-keys"
+
+// MemoryAPIKeyRepository stores API keys in memory. It is intended for tests and local development where
+// the Firestore emulator is not available.
+type MemoryAPIKeyRepository struct {
+	lock *sync.RWMutex
+	keys map[string]auth.APIKey
+}
+
+// NewMemoryAPIKeyRepository creates a new in memory API key repository.
+func NewMemoryAPIKeyRepository() *MemoryAPIKeyRepository {
+	return &MemoryAPIKeyRepository{
+		lock: &sync.RWMutex{},
+		keys: make(map[string]auth.APIKey),
+	}
+}
+
+func (r *MemoryAPIKeyRepository) AddAPIKey(_ context.Context, key auth.APIKey) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.keys[key.ID]; ok {
+		return auth.ErrorAPIKeyAlreadyExists
+	}
+
+	r.keys[key.ID] = copyAPIKey(key)
+
+	return nil
+}
+
+func (r *MemoryAPIKeyRepository) GetAPIKeyByHash(_ context.Context, hash string) (auth.APIKey, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	for _, k := range r.keys {
+		if k.Hash == hash {
+			return copyAPIKey(k), nil
+		}
+	}
+
+	return auth.APIKey{}, auth.ErrorAPIKeyNotFound
+}
+
+func (r *MemoryAPIKeyRepository) RevokeAPIKey(_ context.Context, id string, at time.Time) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	k, ok := r.keys[id]
+	if !ok {
+		return auth.ErrorAPIKeyNotFound
+	}
+
+	// Revoking a key again keeps the time it was first revoked.
+	if k.RevokedAt.IsZero() {
+		k.RevokedAt = at
+		r.keys[id] = k
+	}
+
+	return nil
+}
+
+func (r *MemoryAPIKeyRepository) ReadAPIKeys(_ context.Context) ([]auth.APIKey, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	keys := make([]auth.APIKey, 0, len(r.keys))
+	for _, k := range r.keys {
+		keys = append(keys, copyAPIKey(k))
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].CreatedAt.After(keys[j].CreatedAt)
+		}
+
+		return keys[i].ID < keys[j].ID
+	})
+
+	return keys, nil
+}
+
+func copyAPIKey(k auth.APIKey) auth.APIKey {
+	k.Scopes = append([]string(nil), k.Scopes...)
+	return k
+}
+
+type firestoreAPIKeyModel struct {
+	ID        string    `firestore:"id"`
+	Name      string    `firestore:"name"`
+	Prefix    string    `firestore:"prefix"`
+	Hash      string    `firestore:"hash"`
+	Scopes    []string  `firestore:"scopes"`
+	CreatedAt time.Time `firestore:"createdAt"`
+	ExpiresAt time.Time `firestore:"expiresAt"`
+	RevokedAt time.Time `firestore:"revokedAt"`
+}
+
+// FirestoreAPIKeyRepository stores API keys in Firestore.
+type FirestoreAPIKeyRepository struct {
+	client *firestore.Client
+}
+
+// NewFirestoreAPIKeyRepository creates a new Firestore API key repository.
+func NewFirestoreAPIKeyRepository(client *firestore.Client) (FirestoreAPIKeyRepository, error) {
+	if client == nil {
+		return FirestoreAPIKeyRepository{}, errors.New("nil firestore client")
+	}
+
+	return FirestoreAPIKeyRepository{client: client}, nil
+}
+
+func (r FirestoreAPIKeyRepository) AddAPIKey(ctx context.Context, key auth.APIKey) error {
+	_, err := r.client.Collection(apiKeys).Doc(key.ID).Create(ctx, firestoreAPIKeyModel{
+		ID:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Hash:      key.Hash,
+		Scopes:    key.Scopes,
+		CreatedAt: key.CreatedAt,
+		ExpiresAt: key.ExpiresAt,
+		RevokedAt: key.RevokedAt,
+	})
+	if status.Code(err) == codes.AlreadyExists {
+		return auth.ErrorAPIKeyAlreadyExists
+	}
+
+	return err
+}
+
+func (r FirestoreAPIKeyRepository) GetAPIKeyByHash(ctx context.Context, hash string) (auth.APIKey, error) {
+	iter := r.client.Collection(apiKeys).Where("hash", "==", hash).Limit(1).Documents(ctx)
+	defer iter.Stop()
+
+	doc, err := iter.Next()
+	if err == iterator.Done {
+		return auth.APIKey{}, auth.ErrorAPIKeyNotFound
+	}
+	if err != nil {
+		return auth.APIKey{}, err
+	}
+
+	return unmarshalAPIKey(doc)
+}
+
+func (r FirestoreAPIKeyRepository) RevokeAPIKey(ctx context.Context, id string, at time.Time) error {
+	ref := r.client.Collection(apiKeys).Doc(id)
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return auth.ErrorAPIKeyNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		key, err := unmarshalAPIKey(doc)
+		if err != nil {
+			return err
+		}
+
+		// Revoking a key again keeps the time it was first revoked.
+		if !key.RevokedAt.IsZero() {
+			return nil
+		}
+
+		return tx.Update(ref, []firestore.Update{{Path: "revokedAt", Value: at}})
+	})
+}
+
+func (r FirestoreAPIKeyRepository) ReadAPIKeys(ctx context.Context) ([]auth.APIKey, error) {
+	iter := r.client.Collection(apiKeys).
+		OrderBy("createdAt", firestore.Desc).
+		OrderBy("id", firestore.Asc).
+		Documents(ctx)
+	defer iter.Stop()
+
+	keys := []auth.APIKey{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := unmarshalAPIKey(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func unmarshalAPIKey(doc *firestore.DocumentSnapshot) (auth.APIKey, error) {
+	model := firestoreAPIKeyModel{}
+	if err := doc.DataTo(&model); err != nil {
+		return auth.APIKey{}, err
+	}
+
+	// Firestore doesn't keep the location of times, so zero times come back as the zero time in UTC.
+	zero := func(t time.Time) time.Time {
+		if t.IsZero() {
+			return time.Time{}
+		}
+
+		return t.UTC()
+	}
+
+	return auth.APIKey{
+		ID:        model.ID,
+		Name:      model.Name,
+		Prefix:    model.Prefix,
+		Hash:      model.Hash,
+		Scopes:    model.Scopes,
+		CreatedAt: model.CreatedAt.UTC(),
+		ExpiresAt: zero(model.ExpiresAt),
+		RevokedAt: zero(model.RevokedAt),
+	}, nil
+}
@@ -0,0 +1,102 @@
+package adapters
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/common/auth"
+	"gopher-cache/internal/common/emulators"
+	"gopher-cache/internal/games/app/query"
+	"testing"
+	"time"
+)
+
+type apiKeyRepository interface {
+	auth.APIKeyStore
+	AddAPIKey(ctx context.Context, key auth.APIKey) error
+	RevokeAPIKey(ctx context.Context, id string, at time.Time) error
+	query.APIKeysReadModel
+}
+
+// testAPIKeyRepositories runs test against every API key repository. The Firestore repository is
+// skipped when running short tests since it requires the emulator.
+func testAPIKeyRepositories(t *testing.T, test func(t *testing.T, repo apiKeyRepository)) {
+	t.Run("memory", func(t *testing.T) {
+		test(t, NewMemoryAPIKeyRepository())
+	})
+
+	t.Run("firestore", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip()
+		}
+
+		client, cleanup := emulators.NewFirestoreClient(context.Background())
+		defer func() {
+			_ = client.Close()
+			cleanup()
+		}()
+
+		repo, err := NewFirestoreAPIKeyRepository(client)
+		require.NoError(t, err)
+
+		test(t, repo)
+	})
+}
+
+func TestAPIKeyRepository(t *testing.T) {
+	testAPIKeyRepositories(t, func(t *testing.T, repo apiKeyRepository) {
+		ctx := context.Background()
+
+		expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Millisecond)
+
+		older, olderSecret, err := auth.NewAPIKey("SMS gateway", []string{"play-games"}, expiresAt)
+		require.NoError(t, err)
+		older.CreatedAt = older.CreatedAt.Add(-time.Minute).Truncate(time.Millisecond)
+		require.NoError(t, repo.AddAPIKey(ctx, older))
+
+		newer, _, err := auth.NewAPIKey("Importer", []string{"create-games"}, time.Time{})
+		require.NoError(t, err)
+		newer.CreatedAt = newer.CreatedAt.Truncate(time.Millisecond)
+		require.NoError(t, repo.AddAPIKey(ctx, newer))
+
+		got, err := repo.GetAPIKeyByHash(ctx, auth.HashAPIKey(olderSecret))
+		require.NoError(t, err)
+		assert.Equal(t, older, got)
+
+		_, err = repo.GetAPIKeyByHash(ctx, auth.HashAPIKey("gc_unknown"))
+		assert.Equal(t, auth.ErrorAPIKeyNotFound, err)
+
+		// Keys are listed newest first. Other tests may have added keys to the emulator so only the keys
+		// added here are compared.
+		keys, err := repo.ReadAPIKeys(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []auth.APIKey{newer, older}, filterAPIKeys(keys, newer.ID, older.ID))
+
+		revokedAt := time.Now().UTC().Truncate(time.Millisecond)
+		require.NoError(t, repo.RevokeAPIKey(ctx, older.ID, revokedAt))
+
+		// Revoking again keeps the first time.
+		require.NoError(t, repo.RevokeAPIKey(ctx, older.ID, revokedAt.Add(time.Minute)))
+
+		got, err = repo.GetAPIKeyByHash(ctx, auth.HashAPIKey(olderSecret))
+		require.NoError(t, err)
+		assert.Equal(t, revokedAt, got.RevokedAt)
+		assert.False(t, got.Active(time.Now()))
+
+		err = repo.RevokeAPIKey(ctx, "missing", revokedAt)
+		assert.Equal(t, auth.ErrorAPIKeyNotFound, err)
+	})
+}
+
+func filterAPIKeys(keys []auth.APIKey, ids ...string) []auth.APIKey {
+	var filtered []auth.APIKey
+	for _, k := range keys {
+		for _, id := range ids {
+			if k.ID == id {
+				filtered = append(filtered, k)
+			}
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,1078 @@
+package adapters
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"errors"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopher-cache/internal/common/geo"
+	"gopher-cache/internal/games/app/query"
+	"gopher-cache/internal/games/domain/game"
+	"math"
+	"time"
+)
+
+const (
+	gameProjections   = "game-projections"
+	playerProjections = "player-projections"
+	stateProjections  = "state-projections"
+
+	gameAnalyticsProjections  = "game-analytics-projections"
+	reviewProjections         = "review-projections"
+	moderationCaseProjections = "moderation-case-projections"
+
+	// projectionLag is the document holding the projection lag of every instance.
+	projectionMetadata = "projection-metadata"
+	projectionLag      = projectionMetadata + "/lag"
+)
+
+type firestoreGameProjectionModel struct {
+	UUID                     string                  `firestore:"uuid"`
+	CreatorUUID              string                  `firestore:"creatorUUID"`
+	CreatorName              string                  `firestore:"creatorName"`
+	Title                    string                  `firestore:"title"`
+	Description              string                  `firestore:"description"`
+	Kind                     string                  `firestore:"kind"`
+	City                     string                  `firestore:"city"`
+	State                    string                  `firestore:"state"`
+	Country                  string                  `firestore:"country"`
+	Levels                   int                     `firestore:"levels"`
+	Value                    int                     `firestore:"value"`
+	PlayCount                int                     `firestore:"playCount"`
+	CompletionCount          int                     `firestore:"completionCount"`
+	AverageCompletionSeconds float64                 `firestore:"averageCompletionSeconds"`
+	RatingCount              int                     `firestore:"ratingCount"`
+	RatingTotal              int                     `firestore:"ratingTotal"`
+	RatingRevision           int                     `firestore:"ratingRevision"`
+	AverageRating            float64                 `firestore:"averageRating"`
+	CreatedAt                time.Time               `firestore:"createdAt"`
+	Status                   string                  `firestore:"status"`
+	Location                 *firestoreLocationModel `firestore:"location"`
+	// Geohash indexes the location so games can be queried by distance. It is empty if there is no location.
+	Geohash string `firestore:"geohash"`
+}
+
+type firestorePlayerProjectionModel struct {
+	UUID          string                     `firestore:"uuid"`
+	GamesStarted  int                        `firestore:"gamesStarted"`
+	GamesFinished int                        `firestore:"gamesFinished"`
+	TotalPoints   int                        `firestore:"totalPoints"`
+	History       []firestorePlayedGameModel `firestore:"history"`
+}
+
+type firestorePlayedGameModel struct {
+	StateUUID  string    `firestore:"stateUUID"`
+	GameUUID   string    `firestore:"gameUUID"`
+	GameTitle  string    `firestore:"gameTitle"`
+	Completed  bool      `firestore:"completed"`
+	Quit       bool      `firestore:"quit"`
+	Points     int       `firestore:"points"`
+	StartedAt  time.Time `firestore:"startedAt"`
+	FinishedAt time.Time `firestore:"finishedAt"`
+}
+
+type firestoreStateProjectionModel struct {
+	UUID            string                      `firestore:"uuid"`
+	PlayerUUID      string                      `firestore:"playerUUID"`
+	GameUUID        string                      `firestore:"gameUUID"`
+	GameLevels      int                         `firestore:"gameLevels"`
+	Level           int                         `firestore:"level"`
+	Completed       bool                        `firestore:"completed"`
+	Quit            bool                        `firestore:"quit"`
+	CurrentResponse game.Response               `firestore:"currentResponse"`
+	CurrentLevel    *firestoreCurrentLevelModel `firestore:"currentLevel"`
+	UpdatedAt       time.Time                   `firestore:"updatedAt"`
+}
+
+type firestoreCurrentLevelModel struct {
+	Number      int    `firestore:"number"`
+	Title       string `firestore:"title"`
+	Description string `firestore:"description"`
+}
+
+type firestoreProjectionLagModel struct {
+	Events       int   `firestore:"events"`
+	LastNanos    int64 `firestore:"lastNanos"`
+	MaxNanos     int64 `firestore:"maxNanos"`
+	AverageNanos int64 `firestore:"averageNanos"`
+}
+
+type firestoreGameAnalyticsProjectionModel struct {
+	GameUUID    string                                   `firestore:"gameUUID"`
+	CreatorUUID string                                   `firestore:"creatorUUID"`
+	Title       string                                   `firestore:"title"`
+	Levels      []firestoreLevelAnalyticsProjectionModel `firestore:"levels"`
+}
+
+type firestoreLevelAnalyticsProjectionModel struct {
+	Level            int            `firestore:"level"`
+	PlayersReached   int            `firestore:"playersReached"`
+	PlayersCompleted int            `firestore:"playersCompleted"`
+	PlayersSkipped   int            `firestore:"playersSkipped"`
+	Attempts         int            `firestore:"attempts"`
+	CluesShown       int            `firestore:"cluesShown"`
+	WrongAnswers     map[string]int `firestore:"wrongAnswers"`
+}
+
+type firestoreReviewProjectionModel struct {
+	ID         string    `firestore:"id"`
+	GameUUID   string    `firestore:"gameUUID"`
+	PlayerUUID string    `firestore:"playerUUID"`
+	PlayerName string    `firestore:"playerName"`
+	Stars      int       `firestore:"stars"`
+	Review     string    `firestore:"review"`
+	RatedAt    time.Time `firestore:"ratedAt"`
+}
+
+type firestoreModerationCaseProjectionModel struct {
+	GameUUID        string                         `firestore:"gameUUID"`
+	CreatorUUID     string                         `firestore:"creatorUUID"`
+	Title           string                         `firestore:"title"`
+	Status          string                         `firestore:"status"`
+	Reports         int                            `firestore:"reports"`
+	Reasons         map[string]int                 `firestore:"reasons"`
+	Details         []string                       `firestore:"details"`
+	Flags           []firestoreModerationFlagModel `firestore:"flags"`
+	FirstReportedAt time.Time                      `firestore:"firstReportedAt"`
+	LastReportedAt  time.Time                      `firestore:"lastReportedAt"`
+}
+
+var (
+	_ query.ProjectionStore = FirestoreProjectionRepository{}
+	_ query.GameReadModel   = FirestoreProjectionRepository{}
+	_ query.GamesReadModel  = FirestoreProjectionRepository{}
+	_ query.PlayerReadModel = FirestoreProjectionRepository{}
+	_ query.StateReadModel  = FirestoreProjectionRepository{}
+
+	_ query.GameAnalyticsReadModel = FirestoreProjectionRepository{}
+	_ query.ReviewsReadModel       = FirestoreProjectionRepository{}
+
+	_ query.ModerationQueueReadModel = FirestoreProjectionRepository{}
+	_ query.ProjectionLagReadModel   = FirestoreProjectionRepository{}
+	_ query.GameProjectionWatcher    = FirestoreProjectionRepository{}
+)
+
+// FirestoreProjectionRepository stores the projections backing the read models in Firestore.
+type FirestoreProjectionRepository struct {
+	// firestoreProjections reads and writes projections outside of a transaction.
+	firestoreProjections
+	client *firestore.Client
+}
+
+// NewFirestoreProjectionRepository creates a new projection repository using Firestore.
+func NewFirestoreProjectionRepository(client *firestore.Client) (FirestoreProjectionRepository, error) {
+	if client == nil {
+		return FirestoreProjectionRepository{}, errors.New("nil firestore client")
+	}
+
+	return FirestoreProjectionRepository{
+		firestoreProjections: firestoreProjections{docs: firestoreClientDocuments{client: client}},
+		client:               client,
+	}, nil
+}
+
+func (r FirestoreProjectionRepository) UpdateProjections(
+	ctx context.Context,
+	updateFn func(ctx context.Context, tx query.ProjectionTransaction) error,
+) error {
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docs := &firestoreTransactionDocuments{client: r.client, tx: tx}
+
+		if err := updateFn(ctx, firestoreProjections{docs: docs}); err != nil {
+			return err
+		}
+
+		return docs.commit()
+	})
+}
+
+func (r FirestoreProjectionRepository) RecordProjectionLag(ctx context.Context, lag time.Duration) error {
+	ref := r.client.Doc(projectionLag)
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		model := firestoreProjectionLagModel{}
+
+		docsnap, err := tx.Get(ref)
+		if err == nil {
+			err = docsnap.DataTo(&model)
+		}
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+
+		l := unmarshalProjectionLag(model)
+		l.Record(lag)
+
+		return tx.Set(ref, firestoreProjectionLagModel{
+			Events:       l.Events,
+			LastNanos:    int64(l.Last),
+			MaxNanos:     int64(l.Max),
+			AverageNanos: int64(l.Average),
+		})
+	})
+}
+
+func (r FirestoreProjectionRepository) ReadProjectionLag(ctx context.Context) (*query.ProjectionLag, error) {
+	model := firestoreProjectionLagModel{}
+
+	err := r.docs.get(ctx, projectionLag, &model)
+	if err != nil && !errors.Is(err, query.ErrorProjectionNotFound) {
+		return nil, err
+	}
+
+	l := unmarshalProjectionLag(model)
+
+	return &l, nil
+}
+
+func unmarshalProjectionLag(model firestoreProjectionLagModel) query.ProjectionLag {
+	return query.ProjectionLag{
+		Events:  model.Events,
+		Last:    time.Duration(model.LastNanos),
+		Max:     time.Duration(model.MaxNanos),
+		Average: time.Duration(model.AverageNanos),
+	}
+}
+
+// firestoreProjections reads and writes the projections in Firestore documents.
+type firestoreProjections struct {
+	docs firestoreDocuments
+}
+
+// firestoreDocuments gets and changes documents either directly or in a transaction.
+type firestoreDocuments interface {
+	// get returns query.ErrorProjectionNotFound if the document doesn't exist.
+	get(ctx context.Context, path string, model interface{}) error
+	set(ctx context.Context, path string, model interface{}) error
+	delete(ctx context.Context, path string) error
+}
+
+// firestoreClientDocuments gets and changes documents directly.
+type firestoreClientDocuments struct {
+	client *firestore.Client
+}
+
+func (d firestoreClientDocuments) get(ctx context.Context, path string, model interface{}) error {
+	docsnap, err := d.client.Doc(path).Get(ctx)
+
+	return dataTo(docsnap, err, model)
+}
+
+func (d firestoreClientDocuments) set(ctx context.Context, path string, model interface{}) error {
+	_, err := d.client.Doc(path).Set(ctx, model)
+	return err
+}
+
+func (d firestoreClientDocuments) delete(ctx context.Context, path string) error {
+	_, err := d.client.Doc(path).Delete(ctx)
+	return err
+}
+
+// firestoreTransactionDocuments gets and changes documents in a transaction. Firestore requires every read
+// of a transaction to come before its writes, so the writes are queued until commit is called.
+type firestoreTransactionDocuments struct {
+	client *firestore.Client
+	tx     *firestore.Transaction
+	writes []func() error
+}
+
+func (d *firestoreTransactionDocuments) get(_ context.Context, path string, model interface{}) error {
+	docsnap, err := d.tx.Get(d.client.Doc(path))
+
+	return dataTo(docsnap, err, model)
+}
+
+func (d *firestoreTransactionDocuments) set(_ context.Context, path string, model interface{}) error {
+	ref := d.client.Doc(path)
+	d.writes = append(d.writes, func() error { return d.tx.Set(ref, model) })
+
+	return nil
+}
+
+func (d *firestoreTransactionDocuments) delete(_ context.Context, path string) error {
+	ref := d.client.Doc(path)
+	d.writes = append(d.writes, func() error { return d.tx.Delete(ref) })
+
+	return nil
+}
+
+// commit adds the queued writes to the transaction.
+func (d *firestoreTransactionDocuments) commit() error {
+	for _, write := range d.writes {
+		if err := write(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dataTo populates model with the document got or returns query.ErrorProjectionNotFound if it doesn't exist.
+func dataTo(docsnap *firestore.DocumentSnapshot, err error, model interface{}) error {
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return query.ErrorProjectionNotFound
+		}
+
+		return err
+	}
+
+	return docsnap.DataTo(model)
+}
+
+func (f firestoreProjections) GetGameProjection(ctx context.Context, uuid string) (*query.Game, error) {
+	model := new(firestoreGameProjectionModel)
+
+	if err := f.docs.get(ctx, gameProjections+"/"+uuid, model); err != nil {
+		return nil, err
+	}
+
+	return unmarshalGameProjection(model), nil
+}
+
+func (f firestoreProjections) SaveGameProjection(ctx context.Context, g *query.Game) error {
+	model := firestoreGameProjectionModel{
+		UUID:                     g.UUID,
+		CreatorUUID:              g.CreatorUUID,
+		CreatorName:              g.CreatorName,
+		Title:                    g.Title,
+		Description:              g.Description,
+		Kind:                     g.Kind,
+		City:                     g.City,
+		State:                    g.State,
+		Country:                  g.Country,
+		Levels:                   g.Levels,
+		Value:                    g.Value,
+		PlayCount:                g.PlayCount,
+		CompletionCount:          g.CompletionCount,
+		AverageCompletionSeconds: g.AverageCompletionSeconds,
+		RatingCount:              g.RatingCount,
+		RatingTotal:              g.RatingTotal,
+		RatingRevision:           g.RatingRevision,
+		AverageRating:            g.AverageRating,
+		CreatedAt:                g.CreatedAt,
+		Status:                   g.Status,
+	}
+
+	if g.Location != nil {
+		model.Location = &firestoreLocationModel{
+			Latitude:  g.Location.Latitude,
+			Longitude: g.Location.Longitude,
+		}
+		model.Geohash = geo.Encode(g.Location.Latitude, g.Location.Longitude, geo.MaxPrecision)
+	}
+
+	return f.docs.set(ctx, gameProjections+"/"+g.UUID, model)
+}
+
+func (f firestoreProjections) GetPlayerProjection(ctx context.Context, uuid string) (*query.Player, error) {
+	model := new(firestorePlayerProjectionModel)
+
+	if err := f.docs.get(ctx, playerProjections+"/"+uuid, model); err != nil {
+		return nil, err
+	}
+
+	p := &query.Player{
+		UUID:          model.UUID,
+		GamesStarted:  model.GamesStarted,
+		GamesFinished: model.GamesFinished,
+		TotalPoints:   model.TotalPoints,
+		History:       []query.PlayedGame{},
+	}
+
+	for _, h := range model.History {
+		p.History = append(p.History, query.PlayedGame{
+			StateUUID:  h.StateUUID,
+			GameUUID:   h.GameUUID,
+			GameTitle:  h.GameTitle,
+			Completed:  h.Completed,
+			Quit:       h.Quit,
+			Points:     h.Points,
+			StartedAt:  h.StartedAt.UTC(),
+			FinishedAt: h.FinishedAt.UTC(),
+		})
+	}
+
+	return p, nil
+}
+
+func (f firestoreProjections) SavePlayerProjection(ctx context.Context, p *query.Player) error {
+	model := firestorePlayerProjectionModel{
+		UUID:          p.UUID,
+		GamesStarted:  p.GamesStarted,
+		GamesFinished: p.GamesFinished,
+		TotalPoints:   p.TotalPoints,
+	}
+
+	for _, h := range p.History {
+		model.History = append(model.History, firestorePlayedGameModel{
+			StateUUID:  h.StateUUID,
+			GameUUID:   h.GameUUID,
+			GameTitle:  h.GameTitle,
+			Completed:  h.Completed,
+			Quit:       h.Quit,
+			Points:     h.Points,
+			StartedAt:  h.StartedAt,
+			FinishedAt: h.FinishedAt,
+		})
+	}
+
+	return f.docs.set(ctx, playerProjections+"/"+p.UUID, model)
+}
+
+func (f firestoreProjections) GetStateProjection(ctx context.Context, uuid string) (*query.State, error) {
+	model := new(firestoreStateProjectionModel)
+
+	if err := f.docs.get(ctx, stateProjections+"/"+uuid, model); err != nil {
+		return nil, err
+	}
+
+	return unmarshalStateProjection(model), nil
+}
+
+func unmarshalStateProjection(model *firestoreStateProjectionModel) *query.State {
+	return &query.State{
+		UUID:            model.UUID,
+		PlayerUUID:      model.PlayerUUID,
+		GameUUID:        model.GameUUID,
+		GameLevels:      model.GameLevels,
+		Level:           model.Level,
+		Completed:       model.Completed,
+		Quit:            model.Quit,
+		CurrentResponse: model.CurrentResponse,
+		CurrentLevel:    (*query.Level)(model.CurrentLevel),
+		UpdatedAt:       model.UpdatedAt.UTC(),
+	}
+}
+
+func (f firestoreProjections) SaveStateProjection(ctx context.Context, s *query.State) error {
+	model := firestoreStateProjectionModel{
+		UUID:            s.UUID,
+		PlayerUUID:      s.PlayerUUID,
+		GameUUID:        s.GameUUID,
+		GameLevels:      s.GameLevels,
+		Level:           s.Level,
+		Completed:       s.Completed,
+		Quit:            s.Quit,
+		CurrentResponse: s.CurrentResponse,
+		CurrentLevel:    (*firestoreCurrentLevelModel)(s.CurrentLevel),
+		UpdatedAt:       s.UpdatedAt,
+	}
+
+	return f.docs.set(ctx, stateProjections+"/"+s.UUID, model)
+}
+
+func (f firestoreProjections) GetGameAnalyticsProjection(ctx context.Context, gameUUID string) (*query.GameAnalytics, error) {
+	model := new(firestoreGameAnalyticsProjectionModel)
+
+	if err := f.docs.get(ctx, gameAnalyticsProjections+"/"+gameUUID, model); err != nil {
+		return nil, err
+	}
+
+	return unmarshalGameAnalyticsProjection(model), nil
+}
+
+func (f firestoreProjections) SaveGameAnalyticsProjection(ctx context.Context, a *query.GameAnalytics) error {
+	model := firestoreGameAnalyticsProjectionModel{
+		GameUUID:    a.GameUUID,
+		CreatorUUID: a.CreatorUUID,
+		Title:       a.Title,
+	}
+
+	for _, l := range a.Levels {
+		model.Levels = append(model.Levels, firestoreLevelAnalyticsProjectionModel{
+			Level:            l.Level,
+			PlayersReached:   l.PlayersReached,
+			PlayersCompleted: l.PlayersCompleted,
+			PlayersSkipped:   l.PlayersSkipped,
+			Attempts:         l.Attempts,
+			CluesShown:       l.CluesShown,
+			WrongAnswers:     l.WrongAnswers,
+		})
+	}
+
+	return f.docs.set(ctx, gameAnalyticsProjections+"/"+a.GameUUID, model)
+}
+
+func (f firestoreProjections) SaveReviewProjection(ctx context.Context, review *query.Review) error {
+	model := firestoreReviewProjectionModel{
+		ID:         review.ID,
+		GameUUID:   review.GameUUID,
+		PlayerUUID: review.PlayerUUID,
+		PlayerName: review.PlayerName,
+		Stars:      review.Stars,
+		Review:     review.Review,
+		RatedAt:    review.RatedAt,
+	}
+
+	return f.docs.set(ctx, reviewProjections+"/"+review.ID, model)
+}
+
+func (f firestoreProjections) GetModerationCaseProjection(ctx context.Context, gameUUID string) (*query.ModerationCase, error) {
+	model := new(firestoreModerationCaseProjectionModel)
+
+	if err := f.docs.get(ctx, moderationCaseProjections+"/"+gameUUID, model); err != nil {
+		return nil, err
+	}
+
+	return unmarshalModerationCaseProjection(model), nil
+}
+
+func (f firestoreProjections) SaveModerationCaseProjection(ctx context.Context, c *query.ModerationCase) error {
+	model := firestoreModerationCaseProjectionModel{
+		GameUUID:        c.GameUUID,
+		CreatorUUID:     c.CreatorUUID,
+		Title:           c.Title,
+		Status:          c.Status,
+		Reports:         c.Reports,
+		Reasons:         c.Reasons,
+		Details:         c.Details,
+		FirstReportedAt: c.FirstReportedAt,
+		LastReportedAt:  c.LastReportedAt,
+	}
+
+	for _, flag := range c.Flags {
+		model.Flags = append(model.Flags, firestoreModerationFlagModel{
+			Field: flag.Field,
+			Rule:  flag.Rule,
+			Match: flag.Match,
+		})
+	}
+
+	return f.docs.set(ctx, moderationCaseProjections+"/"+c.GameUUID, model)
+}
+
+func (f firestoreProjections) DeleteModerationCaseProjection(ctx context.Context, gameUUID string) error {
+	return f.docs.delete(ctx, moderationCaseProjections+"/"+gameUUID)
+}
+
+func (r FirestoreProjectionRepository) ClearProjections(ctx context.Context) error {
+	for _, collection := range []string{
+		gameProjections,
+		playerProjections,
+		stateProjections,
+		gameAnalyticsProjections,
+		reviewProjections,
+		moderationCaseProjections,
+		projectionMetadata,
+	} {
+		refs, err := r.client.Collection(collection).DocumentRefs(ctx).GetAll()
+		if err != nil {
+			return err
+		}
+
+		// Firestore limits a batch to 500 writes.
+		for len(refs) > 0 {
+			n := len(refs)
+			if n > 500 {
+				n = 500
+			}
+
+			batch := r.client.Batch()
+			for _, ref := range refs[:n] {
+				batch.Delete(ref)
+			}
+
+			if _, err := batch.Commit(ctx); err != nil {
+				return err
+			}
+
+			refs = refs[n:]
+		}
+	}
+
+	return nil
+}
+
+func (r FirestoreProjectionRepository) ReadGame(ctx context.Context, uuid string) (*query.Game, error) {
+	return r.GetGameProjection(ctx, uuid)
+}
+
+func (r FirestoreProjectionRepository) ReadGamesByUUID(ctx context.Context, uuids []string) ([]*query.Game, error) {
+	snaps, err := r.getAll(ctx, gameProjections, uuids)
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]*query.Game, len(snaps))
+	for i, snap := range snaps {
+		if snap == nil {
+			continue
+		}
+
+		model := new(firestoreGameProjectionModel)
+		if err := snap.DataTo(model); err != nil {
+			return nil, err
+		}
+
+		games[i] = unmarshalGameProjection(model)
+	}
+
+	return games, nil
+}
+
+func (r FirestoreProjectionRepository) ReadGames(ctx context.Context, gq query.GameQuery, page query.Page) ([]*query.Game, error) {
+	q := r.client.Collection(gameProjections).Query
+
+	for _, option := range gq.Options {
+		q = q.Where(option.Key, option.Op, option.Value)
+	}
+
+	order := gq.Order()
+
+	direction := firestore.Asc
+	if order.Descending {
+		direction = firestore.Desc
+	}
+
+	// Firestore breaks ties by document ID in the direction of the last sort which matches query.GameQuery.
+	if order.Key != "" {
+		q = q.OrderBy(order.Key, direction)
+	}
+
+	if after := page.After; after != nil {
+		// Starting after a cursor needs the document ID order to be explicit.
+		q = q.OrderBy(firestore.DocumentID, direction)
+
+		if order.Key != "" {
+			q = q.StartAfter(after.Value, after.UUID)
+		} else {
+			q = q.StartAfter(after.UUID)
+		}
+	}
+
+	q = q.Offset(page.Offset).Limit(page.Limit)
+	iter := q.Documents(ctx)
+	defer iter.Stop()
+
+	// If no games are found return empty non-nil slice.
+	results := []*query.Game{}
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return results, err
+		}
+
+		model := new(firestoreGameProjectionModel)
+
+		err = doc.DataTo(model)
+		if err != nil {
+			return results, err
+		}
+
+		results = append(results, unmarshalGameProjection(model))
+	}
+
+	return results, nil
+}
+
+// WatchGameProjections listens to the game projections so changes made by any instance are seen.
+func (r FirestoreProjectionRepository) WatchGameProjections(
+	ctx context.Context,
+	changed func(ctx context.Context, changes query.GameProjectionChanges) error,
+) error {
+	iter := r.client.Collection(gameProjections).Snapshots(ctx)
+	defer iter.Stop()
+
+	// The first snapshot has every document as added.
+	all := true
+
+	for {
+		snap, err := iter.Next()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			return err
+		}
+
+		changes := query.GameProjectionChanges{All: all}
+		all = false
+
+		for _, change := range snap.Changes {
+			if change.Kind == firestore.DocumentRemoved {
+				changes.Removed = append(changes.Removed, change.Doc.Ref.ID)
+				continue
+			}
+
+			model := new(firestoreGameProjectionModel)
+			if err := change.Doc.DataTo(model); err != nil {
+				return err
+			}
+
+			changes.Saved = append(changes.Saved, unmarshalGameProjection(model))
+		}
+
+		if err := changed(ctx, changes); err != nil {
+			return err
+		}
+	}
+}
+
+// nearSearchStartKm is the radius games near a location are first searched within. It is doubled until a full
+// page is found or it reaches the radius asked for, so a page only reads the games up to about twice as far
+// as its last game rather than every game within the radius.
+const nearSearchStartKm = 1.0
+
+func (r FirestoreProjectionRepository) ReadGamesNear(ctx context.Context, near query.Near, gq query.GameQuery, page query.Page) ([]*query.Game, error) {
+	within := near
+	within.RadiusKm = math.Min(nearSearchStartKm, near.RadiusKm)
+
+	// Every game on the page is further away than the last game of the previous page.
+	if page.After != nil {
+		if d, ok := page.After.Value.(float64); ok {
+			within.RadiusKm = math.Min(math.Max(within.RadiusKm, d), near.RadiusKm)
+		}
+	}
+
+	for {
+		games, err := r.readGamesWithin(ctx, within, gq)
+		if err != nil {
+			return []*query.Game{}, err
+		}
+
+		sortGames(games, distanceSortValue, false)
+		games = pageGames(games, page, distanceSortValue)
+
+		// Games further away than the radius searched can't come before the games found within it.
+		if len(games) == page.Limit || within.RadiusKm >= near.RadiusKm {
+			return games, nil
+		}
+
+		within.RadiusKm = math.Min(within.RadiusKm*2, near.RadiusKm)
+	}
+}
+
+// readGamesWithin returns the games matching q with a location within near.RadiusKm in no particular order.
+func (r FirestoreProjectionRepository) readGamesWithin(ctx context.Context, near query.Near, gq query.GameQuery) ([]*query.Game, error) {
+	// Games with the same UUID may be found by more than one prefix so collect them in a map.
+	found := map[string]*query.Game{}
+
+	for _, prefix := range geo.SearchPrefixes(near.Latitude, near.Longitude, near.RadiusKm) {
+		q := r.client.Collection(gameProjections).Query
+
+		for _, option := range gq.Options {
+			q = q.Where(option.Key, option.Op, option.Value)
+		}
+
+		iter := q.OrderBy("geohash", firestore.Asc).StartAt(prefix).EndAt(prefix + "~").Documents(ctx)
+
+		for {
+			doc, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				iter.Stop()
+				return nil, err
+			}
+
+			model := new(firestoreGameProjectionModel)
+
+			err = doc.DataTo(model)
+			if err != nil {
+				iter.Stop()
+				return nil, err
+			}
+
+			g := unmarshalGameProjection(model)
+			if withinRadius(g, near) {
+				found[g.UUID] = g
+			}
+		}
+
+		iter.Stop()
+	}
+
+	results := []*query.Game{}
+	for _, g := range found {
+		results = append(results, g)
+	}
+
+	return results, nil
+}
+
+func (r FirestoreProjectionRepository) ReadGameReviews(ctx context.Context, gameUUID string, page query.Page) ([]*query.Review, error) {
+	q := r.client.Collection(reviewProjections).
+		Where("gameUUID", "==", gameUUID).
+		OrderBy("ratedAt", firestore.Desc).
+		OrderBy(firestore.DocumentID, firestore.Desc)
+
+	if page.After != nil {
+		q = q.StartAfter(page.After.Value, page.After.UUID)
+	}
+
+	iter := q.Offset(page.Offset).Limit(page.Limit).Documents(ctx)
+	defer iter.Stop()
+
+	// If no reviews are found return empty non-nil slice.
+	results := []*query.Review{}
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return results, err
+		}
+
+		model := new(firestoreReviewProjectionModel)
+
+		err = doc.DataTo(model)
+		if err != nil {
+			return results, err
+		}
+
+		results = append(results, &query.Review{
+			ID:         model.ID,
+			GameUUID:   model.GameUUID,
+			PlayerUUID: model.PlayerUUID,
+			PlayerName: model.PlayerName,
+			Stars:      model.Stars,
+			Review:     model.Review,
+			RatedAt:    model.RatedAt.UTC(),
+		})
+	}
+
+	return results, nil
+}
+
+func (r FirestoreProjectionRepository) ReadModerationQueue(ctx context.Context, page query.Page) ([]*query.ModerationCase, error) {
+	q := r.client.Collection(moderationCaseProjections).
+		OrderBy("firstReportedAt", firestore.Asc).
+		OrderBy(firestore.DocumentID, firestore.Asc)
+
+	if page.After != nil {
+		q = q.StartAfter(page.After.Value, page.After.UUID)
+	}
+
+	iter := q.Offset(page.Offset).Limit(page.Limit).Documents(ctx)
+	defer iter.Stop()
+
+	// If the queue is empty return empty non-nil slice.
+	results := []*query.ModerationCase{}
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return results, err
+		}
+
+		model := new(firestoreModerationCaseProjectionModel)
+
+		err = doc.DataTo(model)
+		if err != nil {
+			return results, err
+		}
+
+		results = append(results, unmarshalModerationCaseProjection(model))
+	}
+
+	return results, nil
+}
+
+func (r FirestoreProjectionRepository) ReadPlayer(ctx context.Context, uuid string) (*query.Player, error) {
+	return r.GetPlayerProjection(ctx, uuid)
+}
+
+func (r FirestoreProjectionRepository) ReadState(ctx context.Context, uuid string) (*query.State, error) {
+	return r.GetStateProjection(ctx, uuid)
+}
+
+func (r FirestoreProjectionRepository) ReadStatesByUUID(ctx context.Context, uuids []string) ([]*query.State, error) {
+	snaps, err := r.getAll(ctx, stateProjections, uuids)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]*query.State, len(snaps))
+	for i, snap := range snaps {
+		if snap == nil {
+			continue
+		}
+
+		model := new(firestoreStateProjectionModel)
+		if err := snap.DataTo(model); err != nil {
+			return nil, err
+		}
+
+		states[i] = unmarshalStateProjection(model)
+	}
+
+	return states, nil
+}
+
+func (r FirestoreProjectionRepository) ReadGameAnalytics(ctx context.Context, gameUUID string) (*query.GameAnalytics, error) {
+	return r.GetGameAnalyticsProjection(ctx, gameUUID)
+}
+
+func (r FirestoreProjectionRepository) ReadCreatorGameAnalytics(ctx context.Context, creatorUUID string) ([]*query.GameAnalytics, error) {
+	iter := r.client.Collection(gameAnalyticsProjections).Where("creatorUUID", "==", creatorUUID).Documents(ctx)
+	defer iter.Stop()
+
+	// If the creator has no games return empty non-nil slice.
+	results := []*query.GameAnalytics{}
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return results, err
+		}
+
+		model := new(firestoreGameAnalyticsProjectionModel)
+
+		err = doc.DataTo(model)
+		if err != nil {
+			return results, err
+		}
+
+		results = append(results, unmarshalGameAnalyticsProjection(model))
+	}
+
+	return results, nil
+}
+
+// getAll gets the documents of a collection with the ids in one round trip. The snapshots are in the same
+// order as the ids and are nil for documents that don't exist.
+func (r FirestoreProjectionRepository) getAll(ctx context.Context, collection string, ids []string) ([]*firestore.DocumentSnapshot, error) {
+	snaps := make([]*firestore.DocumentSnapshot, len(ids))
+
+	var refs []*firestore.DocumentRef
+	var indexes []int
+	for i, id := range ids {
+		// Doc returns nil for IDs that can't be documents, which can't exist either.
+		if ref := r.client.Collection(collection).Doc(id); ref != nil {
+			refs = append(refs, ref)
+			indexes = append(indexes, i)
+		}
+	}
+
+	if len(refs) == 0 {
+		return snaps, nil
+	}
+
+	found, err := r.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, snap := range found {
+		if snap.Exists() {
+			snaps[indexes[j]] = snap
+		}
+	}
+
+	return snaps, nil
+}
+
+func unmarshalGameProjection(model *firestoreGameProjectionModel) *query.Game {
+	g := &query.Game{
+		UUID:                     model.UUID,
+		CreatorUUID:              model.CreatorUUID,
+		CreatorName:              model.CreatorName,
+		Title:                    model.Title,
+		Description:              model.Description,
+		Kind:                     model.Kind,
+		City:                     model.City,
+		State:                    model.State,
+		Country:                  model.Country,
+		Levels:                   model.Levels,
+		Value:                    model.Value,
+		PlayCount:                model.PlayCount,
+		CompletionCount:          model.CompletionCount,
+		AverageCompletionSeconds: model.AverageCompletionSeconds,
+		RatingCount:              model.RatingCount,
+		RatingTotal:              model.RatingTotal,
+		RatingRevision:           model.RatingRevision,
+		AverageRating:            model.AverageRating,
+		CreatedAt:                model.CreatedAt.UTC(),
+		Status:                   model.Status,
+	}
+
+	if model.Location != nil {
+		g.Location = &query.Location{
+			Latitude:  model.Location.Latitude,
+			Longitude: model.Location.Longitude,
+		}
+	}
+
+	return g
+}
+
+func unmarshalModerationCaseProjection(model *firestoreModerationCaseProjectionModel) *query.ModerationCase {
+	c := &query.ModerationCase{
+		GameUUID:        model.GameUUID,
+		CreatorUUID:     model.CreatorUUID,
+		Title:           model.Title,
+		Status:          model.Status,
+		Reports:         model.Reports,
+		Reasons:         model.Reasons,
+		Details:         model.Details,
+		FirstReportedAt: model.FirstReportedAt.UTC(),
+		LastReportedAt:  model.LastReportedAt.UTC(),
+	}
+
+	if c.Reasons == nil {
+		c.Reasons = map[string]int{}
+	}
+
+	for _, flag := range model.Flags {
+		c.Flags = append(c.Flags, query.ModerationFlag{Field: flag.Field, Rule: flag.Rule, Match: flag.Match})
+	}
+
+	return c
+}
+
+// withinRadius sets the distance of the game from the location near and reports whether it is within the radius.
+func withinRadius(g *query.Game, near query.Near) bool {
+	if g.Location == nil {
+		return false
+	}
+
+	d := geo.DistanceKm(near.Latitude, near.Longitude, g.Location.Latitude, g.Location.Longitude)
+	g.DistanceKm = &d
+
+	return d <= near.RadiusKm
+}
+
+func unmarshalGameAnalyticsProjection(model *firestoreGameAnalyticsProjectionModel) *query.GameAnalytics {
+	a := &query.GameAnalytics{
+		GameUUID:    model.GameUUID,
+		CreatorUUID: model.CreatorUUID,
+		Title:       model.Title,
+		Levels:      []query.LevelAnalytics{},
+	}
+
+	for _, l := range model.Levels {
+		wrongAnswers := l.WrongAnswers
+		if wrongAnswers == nil {
+			wrongAnswers = map[string]int{}
+		}
+
+		a.Levels = append(a.Levels, query.LevelAnalytics{
+			Level:            l.Level,
+			PlayersReached:   l.PlayersReached,
+			PlayersCompleted: l.PlayersCompleted,
+			PlayersSkipped:   l.PlayersSkipped,
+			Attempts:         l.Attempts,
+			CluesShown:       l.CluesShown,
+			WrongAnswers:     wrongAnswers,
+		})
+	}
+
+	return a
+}
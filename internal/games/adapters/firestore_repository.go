@@ -8,38 +8,93 @@ import (
 	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"gopher-cache/internal/games/app/query"
 	"gopher-cache/internal/games/domain/game"
+	"time"
 )
 
 type firestoreGameModel struct {
-	UUID        string                `firestore:"uuid"`
-	CreatorUUID string                `firestore:"creatorUUID"`
-	Title       string                `firestore:"title"`
-	Description string                `firestore:"description"`
-	Levels      []firestoreLevelModel `firestore:"levels"`
-	Ending      string                `firestore:"ending"`
-	Kind        string                `firestore:"kind"`
-	City        string                `firestore:"city"`
-	State       string                `firestore:"state"`
-	Country     string                `firestore:"country"`
-	Value       int                   `firestore:"value"`
+	UUID        string                  `firestore:"uuid"`
+	CreatorUUID string                  `firestore:"creatorUUID"`
+	CreatorName string                  `firestore:"creatorName"`
+	Title       string                  `firestore:"title"`
+	Description string                  `firestore:"description"`
+	Levels      []firestoreLevelModel   `firestore:"levels"`
+	Ending      string                  `firestore:"ending"`
+	Kind        string                  `firestore:"kind"`
+	City        string                  `firestore:"city"`
+	State       string                  `firestore:"state"`
+	Country     string                  `firestore:"country"`
+	Value       int                     `firestore:"value"`
+	CreatedAt   time.Time               `firestore:"createdAt"`
+	Location    *firestoreLocationModel `firestore:"location"`
+	Status      string                  `firestore:"status"`
+	// Clues is nil for games stored before clue settings were added.
+	Clues        *firestoreClueSettingsModel `firestore:"clues"`
+	Locale       string                      `firestore:"locale"`
+	Translations []firestoreTranslationModel `firestore:"translations"`
 }
 
-type firestoreLevelModel struct {
+type firestoreTranslationModel struct {
+	Locale      string                           `firestore:"locale"`
+	Title       string                           `firestore:"title"`
+	Description string                           `firestore:"description"`
+	Ending      string                           `firestore:"ending"`
+	Levels      []firestoreLevelTranslationModel `firestore:"levels"`
+}
+
+type firestoreLevelTranslationModel struct {
 	Title       string   `firestore:"title"`
 	Description string   `firestore:"description"`
 	Clues       []string `firestore:"clues"`
 	Answers     []string `firestore:"answers"`
 }
 
+type firestoreClueSettingsModel struct {
+	WrongAnswersRevealClues bool `firestore:"wrongAnswersRevealClues"`
+	CooldownSeconds         int  `firestore:"cooldownSeconds"`
+}
+
+type firestoreLevelModel struct {
+	Title       string                  `firestore:"title"`
+	Description string                  `firestore:"description"`
+	Clues       []string                `firestore:"clues"`
+	Answers     []string                `firestore:"answers"`
+	Location    *firestoreLocationModel `firestore:"location"`
+}
+
+type firestoreLocationModel struct {
+	Latitude  float64 `firestore:"latitude"`
+	Longitude float64 `firestore:"longitude"`
+}
+
 type firestorePlayerModel struct {
-	UUID                 string `firestore:"uuid"`
-	Number               string `firestore:"number"`
-	GamesStarted         int    `firestore:"gamesStarted"`
-	GamesFinished        int    `firestore:"gamesFinished"`
-	TotalPoints          int    `firestore:"totalPoints"`
-	CurrentGameStateUUID string `firestore:"currentGameStateUUID"`
+	UUID                 string                      `firestore:"uuid"`
+	Number               string                      `firestore:"number"`
+	GamesStarted         int                         `firestore:"gamesStarted"`
+	GamesFinished        int                         `firestore:"gamesFinished"`
+	TotalPoints          int                         `firestore:"totalPoints"`
+	CurrentGameStateUUID string                      `firestore:"currentGameStateUUID"`
+	NumberVerifiedAt     time.Time                   `firestore:"numberVerifiedAt"`
+	Verification         *firestoreVerificationModel `firestore:"verification"`
+	ConsentedAt          time.Time                   `firestore:"consentedAt"`
+	OptedOutAt           time.Time                   `firestore:"optedOutAt"`
+	Locale               string                      `firestore:"locale"`
+}
+
+type firestoreVerificationModel struct {
+	CodeHash  string    `firestore:"codeHash"`
+	SentAt    time.Time `firestore:"sentAt"`
+	ExpiresAt time.Time `firestore:"expiresAt"`
+	Attempts  int       `firestore:"attempts"`
+}
+
+type firestoreConsentRecordModel struct {
+	UUID       string    `firestore:"uuid"`
+	PlayerUUID string    `firestore:"playerUUID"`
+	Number     string    `firestore:"number"`
+	Action     string    `firestore:"action"`
+	Source     string    `firestore:"source"`
+	RecordedAt time.Time `firestore:"recordedAt"`
 }
 
 type firestoreStateModel struct {
@@ -49,8 +104,58 @@ type firestoreStateModel struct {
 	GameLevels      int           `firestore:"gameLevels"`
 	Level           int           `firestore:"level"`
 	Clue            int           `firestore:"clue"`
+	Skipped         int           `firestore:"skipped"`
 	Completed       bool          `firestore:"completed"`
 	CurrentResponse game.Response `firestore:"currentResponse"`
+	StartedAt       time.Time     `firestore:"startedAt"`
+	FinishedAt      time.Time     `firestore:"finishedAt"`
+	ClueRevealedAt  time.Time     `firestore:"clueRevealedAt"`
+}
+
+type firestoreAttemptModel struct {
+	UUID        string    `firestore:"uuid"`
+	StateUUID   string    `firestore:"stateUUID"`
+	GameUUID    string    `firestore:"gameUUID"`
+	PlayerUUID  string    `firestore:"playerUUID"`
+	Level       int       `firestore:"level"`
+	Input       string    `firestore:"input"`
+	Correct     bool      `firestore:"correct"`
+	ClueShown   int       `firestore:"clueShown"`
+	SubmittedAt time.Time `firestore:"submittedAt"`
+}
+
+type firestoreRatingModel struct {
+	GameUUID   string    `firestore:"gameUUID"`
+	PlayerUUID string    `firestore:"playerUUID"`
+	PlayerName string    `firestore:"playerName"`
+	Stars      int       `firestore:"stars"`
+	Review     string    `firestore:"review"`
+	RatedAt    time.Time `firestore:"ratedAt"`
+}
+
+type firestoreRatingSummaryModel struct {
+	Count    int `firestore:"count"`
+	Total    int `firestore:"total"`
+	Revision int `firestore:"revision"`
+}
+
+type firestoreReportModel struct {
+	UUID         string                         `firestore:"uuid"`
+	GameUUID     string                         `firestore:"gameUUID"`
+	ReporterUUID string                         `firestore:"reporterUUID"`
+	Reason       string                         `firestore:"reason"`
+	Details      string                         `firestore:"details"`
+	Flags        []firestoreModerationFlagModel `firestore:"flags"`
+	ReportedAt   time.Time                      `firestore:"reportedAt"`
+	// Resolved is stored as well as ResolvedAt so open reports can be queried.
+	Resolved   bool      `firestore:"resolved"`
+	ResolvedAt time.Time `firestore:"resolvedAt"`
+}
+
+type firestoreModerationFlagModel struct {
+	Field string `firestore:"field"`
+	Rule  string `firestore:"rule"`
+	Match string `firestore:"match"`
 }
 
 var _ game.Repository = FirestoreGameRepository{}
@@ -69,33 +174,63 @@ func NewFirestoreGameRepository(client *firestore.Client) (FirestoreGameReposito
 	return FirestoreGameRepository{client: client}, nil
 }
 
-func (r FirestoreGameRepository) AddGame(ctx context.Context, game *game.Game) error {
+func (r FirestoreGameRepository) AddGame(ctx context.Context, g *game.Game) error {
 	model := firestoreGameModel{
-		UUID:        game.UUID(),
-		CreatorUUID: game.CreatorUUID(),
-		Title:       game.Title(),
-		Description: game.Description(),
-		Ending:      game.Ending(),
-		Kind:        game.Kind(),
-		City:        game.City(),
-		State:       game.State(),
-		Country:     game.Country(),
-		Value:       game.Value(),
-	}
-
-	for _, level := range game.Levels() {
+		UUID:        g.UUID(),
+		CreatorUUID: g.CreatorUUID(),
+		CreatorName: g.CreatorName(),
+		Title:       g.Title(),
+		Description: g.Description(),
+		Ending:      g.Ending(),
+		Kind:        g.Kind(),
+		City:        g.City(),
+		State:       g.State(),
+		Country:     g.Country(),
+		Value:       g.Value(),
+		CreatedAt:   g.CreatedAt(),
+		Location:    marshalLocation(g.Location()),
+		Status:      string(g.Status()),
+		Clues: &firestoreClueSettingsModel{
+			WrongAnswersRevealClues: g.ClueSettings().WrongAnswersRevealClues(),
+			CooldownSeconds:         int(g.ClueSettings().Cooldown() / time.Second),
+		},
+		Locale: g.Locale(),
+	}
+
+	for _, t := range g.Translations() {
+		translation := firestoreTranslationModel{
+			Locale:      t.Locale(),
+			Title:       t.Title(),
+			Description: t.Description(),
+			Ending:      t.Ending(),
+		}
+
+		for _, level := range t.Levels() {
+			translation.Levels = append(translation.Levels, firestoreLevelTranslationModel{
+				Title:       level.Title(),
+				Description: level.Description(),
+				Clues:       level.Clues(),
+				Answers:     level.Answers(),
+			})
+		}
+
+		model.Translations = append(model.Translations, translation)
+	}
+
+	for _, level := range g.Levels() {
 		model.Levels = append(model.Levels, firestoreLevelModel{
 			Title:       level.Title(),
 			Description: level.Description(),
 			Clues:       level.Clues(),
 			Answers:     level.Answers(),
+			Location:    marshalLocation(level.Location()),
 		})
 	}
 
-	doc := r.client.Doc("games/" + game.UUID())
+	doc := r.client.Doc("games/" + g.UUID())
 	_, err := doc.Create(ctx, model)
 	if err != nil {
-		return err
+		return repositoryError(err, nil, game.ErrorGameAlreadyExists)
 	}
 
 	return nil
@@ -104,7 +239,7 @@ func (r FirestoreGameRepository) AddGame(ctx context.Context, game *game.Game) e
 func (r FirestoreGameRepository) GetGame(ctx context.Context, gameUUID string) (*game.Game, error) {
 	docsnap, err := r.client.Doc("games/" + gameUUID).Get(ctx)
 	if err != nil {
-		return nil, err
+		return nil, repositoryError(err, game.ErrorGameNotFound, nil)
 	}
 
 	model := new(firestoreGameModel)
@@ -114,47 +249,51 @@ func (r FirestoreGameRepository) GetGame(ctx context.Context, gameUUID string) (
 		return nil, err
 	}
 
-	var levels []*game.Level
-	for _, level := range model.Levels {
-		levels = append(levels, game.UnmarshalLevelFromDatabase(
-			level.Title,
-			level.Description,
-			level.Clues,
-			level.Answers))
-	}
-	g, err := game.UnmarshalFromDataBase(
-		model.UUID,
-		model.CreatorUUID,
-		model.Title,
-		model.Description,
-		levels,
-		model.Ending,
-		model.Kind,
-		model.City,
-		model.State,
-		model.Country,
-		model.Value)
-	if err != nil {
-		return nil, err
-	}
+	return unmarshalGame(model)
+}
+
+func (r FirestoreGameRepository) AddReport(ctx context.Context, report *game.Report) error {
+	_, err := r.client.Doc("reports/"+report.UUID()).Create(ctx, marshalReport(report))
 
-	return g, nil
+	return repositoryError(err, nil, game.ErrorReportAlreadyExists)
 }
 
-func (r FirestoreGameRepository) AddPlayer(ctx context.Context, player *game.Player) error {
-	model := firestorePlayerModel{
-		UUID:                 player.UUID(),
-		Number:               player.Number(),
-		GamesStarted:         player.GamesStarted(),
-		GamesFinished:        player.GamesFinished(),
-		TotalPoints:          player.TotalPoints(),
-		CurrentGameStateUUID: player.CurrentGameStateUUID(),
-	}
+func (r FirestoreGameRepository) GetOpenReports(ctx context.Context, gameUUID string) ([]*game.Report, error) {
+	iter := r.client.Collection("reports").
+		Where("gameUUID", "==", gameUUID).
+		Where("resolved", "==", false).
+		Documents(ctx)
+
+	return r.reports(iter)
+}
+
+func (r FirestoreGameRepository) UpdateGameAndReports(ctx context.Context, g *game.Game, reports []*game.Report) error {
+	doc := r.client.Doc("games/" + g.UUID())
 
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		err := tx.Update(doc, []firestore.Update{{Path: "status", Value: string(g.Status())}})
+		if err != nil {
+			return err
+		}
+
+		for _, report := range reports {
+			err := tx.Set(r.client.Doc("reports/"+report.UUID()), marshalReport(report))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return repositoryError(err, game.ErrorGameNotFound, nil)
+}
+
+func (r FirestoreGameRepository) AddPlayer(ctx context.Context, player *game.Player) error {
 	doc := r.client.Doc("players/" + player.UUID())
-	_, err := doc.Create(ctx, model)
+	_, err := doc.Create(ctx, marshalPlayer(player))
 	if err != nil {
-		return err
+		return repositoryError(err, nil, game.ErrorPlayerAlreadyExists)
 	}
 
 	return nil
@@ -177,13 +316,7 @@ func (r FirestoreGameRepository) GetPlayer(ctx context.Context, playerUUID strin
 		return nil, err
 	}
 
-	return game.UnmarshalPlayerFromDatabase(
-		model.UUID,
-		model.Number,
-		model.GamesStarted,
-		model.GamesFinished,
-		model.TotalPoints,
-		model.CurrentGameStateUUID), nil
+	return unmarshalPlayer(model), nil
 }
 
 func (r FirestoreGameRepository) GetPlayerByNumber(ctx context.Context, playerNumber string) (*game.Player, error) {
@@ -210,13 +343,63 @@ func (r FirestoreGameRepository) GetPlayerByNumber(ctx context.Context, playerNu
 		return nil, err
 	}
 
-	return game.UnmarshalPlayerFromDatabase(
-		model.UUID,
-		model.Number,
-		model.GamesStarted,
-		model.GamesFinished,
-		model.TotalPoints,
-		model.CurrentGameStateUUID), nil
+	return unmarshalPlayer(model), nil
+}
+
+func (r FirestoreGameRepository) UpdatePlayer(ctx context.Context, player *game.Player) error {
+	_, err := r.client.Doc("players/"+player.UUID()).Set(ctx, marshalPlayer(player))
+	return err
+}
+
+func (r FirestoreGameRepository) AddConsentRecord(ctx context.Context, record *game.ConsentRecord) error {
+	model := firestoreConsentRecordModel{
+		UUID:       record.UUID(),
+		PlayerUUID: record.PlayerUUID(),
+		Number:     record.Number(),
+		Action:     string(record.Action()),
+		Source:     record.Source(),
+		RecordedAt: record.RecordedAt(),
+	}
+
+	_, err := r.client.Doc("consent-records/"+record.UUID()).Create(ctx, model)
+	return err
+}
+
+func (r FirestoreGameRepository) GetConsentRecords(ctx context.Context, playerUUID string) ([]*game.ConsentRecord, error) {
+	iter := r.client.Collection("consent-records").
+		Where("playerUUID", "==", playerUUID).
+		OrderBy("recordedAt", firestore.Asc).
+		Documents(ctx)
+	defer iter.Stop()
+
+	var records []*game.ConsentRecord
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		model := new(firestoreConsentRecordModel)
+
+		err = doc.DataTo(model)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, game.UnmarshalConsentRecordFromDatabase(
+			model.UUID,
+			model.PlayerUUID,
+			model.Number,
+			game.ConsentAction(model.Action),
+			model.Source,
+			model.RecordedAt))
+	}
+
+	return records, nil
 }
 
 func (r FirestoreGameRepository) AddState(ctx context.Context, state *game.State) error {
@@ -227,13 +410,17 @@ func (r FirestoreGameRepository) AddState(ctx context.Context, state *game.State
 		GameLevels:      state.GameLevels(),
 		Level:           state.Level(),
 		Clue:            state.Clue(),
+		Skipped:         state.Skipped(),
 		Completed:       state.Completed(),
 		CurrentResponse: state.CurrentResponse(),
+		StartedAt:       state.StartedAt(),
+		FinishedAt:      state.FinishedAt(),
+		ClueRevealedAt:  state.ClueRevealedAt(),
 	}
 
 	_, err := r.client.Doc("game-states/"+state.UUID()).Create(ctx, model)
 	if err != nil {
-		return err
+		return repositoryError(err, nil, game.ErrorStateAlreadyExists)
 	}
 
 	return nil
@@ -242,7 +429,7 @@ func (r FirestoreGameRepository) AddState(ctx context.Context, state *game.State
 func (r FirestoreGameRepository) GetState(ctx context.Context, uuid string) (*game.State, error) {
 	docsnap, err := r.client.Doc("game-states/" + uuid).Get(ctx)
 	if err != nil {
-		return nil, err
+		return nil, repositoryError(err, game.ErrorStateNotFound, nil)
 	}
 
 	model := new(firestoreStateModel)
@@ -252,15 +439,38 @@ func (r FirestoreGameRepository) GetState(ctx context.Context, uuid string) (*ga
 		return nil, err
 	}
 
-	return game.UnmarshalGameStateFromDatabase(
-		model.UUID,
-		model.PlayerUUID,
-		model.GameUUID,
-		model.GameLevels,
-		model.Level,
-		model.Clue,
-		model.Completed,
-		model.CurrentResponse), nil
+	return unmarshalState(model), nil
+}
+
+func (r FirestoreGameRepository) GetPlayerGameStates(ctx context.Context, playerUUID, gameUUID string) ([]*game.State, error) {
+	iter := r.client.Collection("game-states").
+		Where("playerUUID", "==", playerUUID).
+		Where("gameUUID", "==", gameUUID).
+		Documents(ctx)
+	defer iter.Stop()
+
+	var states []*game.State
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		model := new(firestoreStateModel)
+
+		err = doc.DataTo(model)
+		if err != nil {
+			return nil, err
+		}
+
+		states = append(states, unmarshalState(model))
+	}
+
+	return states, nil
 }
 
 func (r FirestoreGameRepository) UpdateState(ctx context.Context, state *game.State) error {
@@ -271,8 +481,12 @@ func (r FirestoreGameRepository) UpdateState(ctx context.Context, state *game.St
 		GameLevels:      state.GameLevels(),
 		Level:           state.Level(),
 		Clue:            state.Clue(),
+		Skipped:         state.Skipped(),
 		Completed:       state.Completed(),
 		CurrentResponse: state.CurrentResponse(),
+		StartedAt:       state.StartedAt(),
+		FinishedAt:      state.FinishedAt(),
+		ClueRevealedAt:  state.ClueRevealedAt(),
 	}
 
 	_, err := r.client.Doc("game-states/"+state.UUID()).Set(ctx, model)
@@ -291,23 +505,20 @@ func (r FirestoreGameRepository) AddStateAndUpdatePlayer(ctx context.Context, st
 		GameLevels:      state.GameLevels(),
 		Level:           state.Level(),
 		Clue:            state.Clue(),
+		Skipped:         state.Skipped(),
 		Completed:       state.Completed(),
 		CurrentResponse: state.CurrentResponse(),
+		StartedAt:       state.StartedAt(),
+		FinishedAt:      state.FinishedAt(),
+		ClueRevealedAt:  state.ClueRevealedAt(),
 	}
 
-	playerModel := firestorePlayerModel{
-		UUID:                 player.UUID(),
-		Number:               player.Number(),
-		GamesStarted:         player.GamesStarted(),
-		GamesFinished:        player.GamesFinished(),
-		TotalPoints:          player.TotalPoints(),
-		CurrentGameStateUUID: player.CurrentGameStateUUID(),
-	}
+	playerModel := marshalPlayer(player)
 
 	s := r.client.Doc("game-states/" + state.UUID())
 	p := r.client.Doc("players/" + player.UUID())
 
-	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
 		err := tx.Create(s, stateModel)
 		if err != nil {
 			return err
@@ -315,9 +526,20 @@ func (r FirestoreGameRepository) AddStateAndUpdatePlayer(ctx context.Context, st
 
 		return tx.Set(p, playerModel)
 	})
+
+	return repositoryError(err, nil, game.ErrorStateAlreadyExists)
 }
 
 func (r FirestoreGameRepository) UpdateStateAndPlayer(ctx context.Context, state *game.State, player *game.Player) error {
+	return r.updateStateAndPlayer(ctx, state, player, nil)
+}
+
+func (r FirestoreGameRepository) UpdateStateAndPlayerWithAttempt(ctx context.Context, state *game.State, player *game.Player, attempt *game.Attempt) error {
+	return r.updateStateAndPlayer(ctx, state, player, attempt)
+}
+
+// updateStateAndPlayer updates the state and player and adds the attempt if it isn't nil in one transaction.
+func (r FirestoreGameRepository) updateStateAndPlayer(ctx context.Context, state *game.State, player *game.Player, attempt *game.Attempt) error {
 	stateModel := firestoreStateModel{
 		UUID:            state.UUID(),
 		PlayerUUID:      state.PlayerUUID(),
@@ -325,18 +547,15 @@ func (r FirestoreGameRepository) UpdateStateAndPlayer(ctx context.Context, state
 		GameLevels:      state.GameLevels(),
 		Level:           state.Level(),
 		Clue:            state.Clue(),
+		Skipped:         state.Skipped(),
 		Completed:       state.Completed(),
 		CurrentResponse: state.CurrentResponse(),
+		StartedAt:       state.StartedAt(),
+		FinishedAt:      state.FinishedAt(),
+		ClueRevealedAt:  state.ClueRevealedAt(),
 	}
 
-	playerModel := firestorePlayerModel{
-		UUID:                 player.UUID(),
-		Number:               player.Number(),
-		GamesStarted:         player.GamesStarted(),
-		GamesFinished:        player.GamesFinished(),
-		TotalPoints:          player.TotalPoints(),
-		CurrentGameStateUUID: player.CurrentGameStateUUID(),
-	}
+	playerModel := marshalPlayer(player)
 
 	s := r.client.Doc("game-states/" + state.UUID())
 	p := r.client.Doc("players/" + player.UUID())
@@ -347,23 +566,118 @@ func (r FirestoreGameRepository) UpdateStateAndPlayer(ctx context.Context, state
 			return err
 		}
 
-		return tx.Set(p, playerModel)
+		err = tx.Set(p, playerModel)
+		if err != nil {
+			return err
+		}
+
+		if attempt == nil {
+			return nil
+		}
+
+		return tx.Create(r.client.Doc("attempts/"+attempt.UUID()), marshalAttempt(attempt))
 	})
 }
 
-func (r FirestoreGameRepository) ReadGames(ctx context.Context, limit, offset int, options ...query.GameOption) ([]*query.Game, error) {
-	q := r.client.Collection("games").Query
+func marshalAttempt(attempt *game.Attempt) firestoreAttemptModel {
+	return firestoreAttemptModel{
+		UUID:        attempt.UUID(),
+		StateUUID:   attempt.StateUUID(),
+		GameUUID:    attempt.GameUUID(),
+		PlayerUUID:  attempt.PlayerUUID(),
+		Level:       attempt.Level(),
+		Input:       attempt.Input(),
+		Correct:     attempt.Correct(),
+		ClueShown:   attempt.ClueShown(),
+		SubmittedAt: attempt.SubmittedAt(),
+	}
+}
 
-	for _, option := range options {
-		q = q.Where(option.Key, option.Op, option.Value)
+func (r FirestoreGameRepository) GetRating(ctx context.Context, gameUUID, playerUUID string) (*game.Rating, error) {
+	docsnap, err := r.client.Doc("ratings/" + ratingID(gameUUID, playerUUID)).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, game.ErrorRatingNotFound
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	q = q.Offset(offset).Limit(limit).Select("uuid", "title", "description")
-	iter := q.Documents(ctx)
+	model := new(firestoreRatingModel)
+
+	err = docsnap.DataTo(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalRating(model), nil
+}
+
+func (r FirestoreGameRepository) SaveRating(
+	ctx context.Context,
+	rating *game.Rating,
+	updateSummary func(previous *game.Rating, summary *game.RatingSummary) error,
+) error {
+	ratingDoc := r.client.Doc("ratings/" + ratingID(rating.GameUUID(), rating.PlayerUUID()))
+	summaryDoc := r.client.Doc("rating-summaries/" + rating.GameUUID())
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		var previous *game.Rating
+
+		docsnap, err := tx.Get(ratingDoc)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			model := new(firestoreRatingModel)
+			if err := docsnap.DataTo(model); err != nil {
+				return err
+			}
+
+			previous = unmarshalRating(model)
+		}
+
+		summaryModel := new(firestoreRatingSummaryModel)
+
+		docsnap, err = tx.Get(summaryDoc)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			if err := docsnap.DataTo(summaryModel); err != nil {
+				return err
+			}
+		}
+
+		summary := game.UnmarshalRatingSummaryFromDatabase(summaryModel.Count, summaryModel.Total, summaryModel.Revision)
+		if err := updateSummary(previous, &summary); err != nil {
+			return err
+		}
+
+		err = tx.Set(ratingDoc, firestoreRatingModel{
+			GameUUID:   rating.GameUUID(),
+			PlayerUUID: rating.PlayerUUID(),
+			PlayerName: rating.PlayerName(),
+			Stars:      rating.Stars(),
+			Review:     rating.Review(),
+			RatedAt:    rating.RatedAt(),
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.Set(summaryDoc, firestoreRatingSummaryModel{
+			Count:    summary.Count(),
+			Total:    summary.Total(),
+			Revision: summary.Revision(),
+		})
+	})
+}
+
+func (r FirestoreGameRepository) AllGames(ctx context.Context) ([]*game.Game, error) {
+	iter := r.client.Collection("games").Documents(ctx)
 	defer iter.Stop()
 
-	// If no games are found return empty non-nil slice.
-	results := []*query.Game{}
+	var games []*game.Game
 
 	for {
 		doc, err := iter.Next()
@@ -371,70 +685,398 @@ func (r FirestoreGameRepository) ReadGames(ctx context.Context, limit, offset in
 			break
 		}
 		if err != nil {
-			return results, err
+			return nil, err
 		}
 
-		model := new(query.Game)
+		model := new(firestoreGameModel)
 
 		err = doc.DataTo(model)
 		if err != nil {
-			return results, err
+			return nil, err
 		}
 
-		results = append(results, &query.Game{
-			UUID:        model.UUID,
-			Title:       model.Title,
-			Description: model.Description,
-		})
+		g, err := unmarshalGame(model)
+		if err != nil {
+			return nil, err
+		}
+
+		games = append(games, g)
 	}
 
-	return results, nil
+	return games, nil
 }
 
-func (r FirestoreGameRepository) ReadState(ctx context.Context, uuid string) (*query.State, error) {
-	q := r.client.Collection("game-states").
-		Query.Limit(1).
-		Select("currentResponse").
-		Where("uuid", "==", uuid)
+func (r FirestoreGameRepository) AllStates(ctx context.Context) ([]*game.State, error) {
+	iter := r.client.Collection("game-states").Documents(ctx)
+	defer iter.Stop()
 
-	iter := q.Documents(ctx)
+	var states []*game.State
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		model := new(firestoreStateModel)
+
+		err = doc.DataTo(model)
+		if err != nil {
+			return nil, err
+		}
+
+		states = append(states, unmarshalState(model))
+	}
+
+	return states, nil
+}
+
+func (r FirestoreGameRepository) AllPlayers(ctx context.Context) ([]*game.Player, error) {
+	iter := r.client.Collection("players").Documents(ctx)
 	defer iter.Stop()
 
-	doc, err := iter.Next()
-	if err != nil {
-		return nil, err
+	var players []*game.Player
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		model := new(firestorePlayerModel)
+
+		err = doc.DataTo(model)
+		if err != nil {
+			return nil, err
+		}
+
+		players = append(players, unmarshalPlayer(model))
 	}
 
-	st := new(query.State)
+	return players, nil
+}
 
-	err = doc.DataTo(st)
-	if err != nil {
-		return nil, err
+func (r FirestoreGameRepository) AllAttempts(ctx context.Context) ([]*game.Attempt, error) {
+	iter := r.client.Collection("attempts").Documents(ctx)
+	defer iter.Stop()
+
+	var attempts []*game.Attempt
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		model := new(firestoreAttemptModel)
+
+		err = doc.DataTo(model)
+		if err != nil {
+			return nil, err
+		}
+
+		attempts = append(attempts, game.UnmarshalAttemptFromDatabase(
+			model.UUID,
+			model.StateUUID,
+			model.GameUUID,
+			model.PlayerUUID,
+			model.Level,
+			model.Input,
+			model.Correct,
+			model.ClueShown,
+			model.SubmittedAt.UTC()))
 	}
 
-	return st, nil
+	return attempts, nil
 }
 
-func (r FirestoreGameRepository) ReadPlayer(ctx context.Context, uuid string) (*query.Player, error) {
-	q := r.client.Collection("players").
-		Query.Limit(1).
-		Select("gamesFinished", "totalPoints").
-		Where("uuid", "==", uuid)
+func (r FirestoreGameRepository) AllRatings(ctx context.Context) ([]*game.Rating, error) {
+	iter := r.client.Collection("ratings").Documents(ctx)
+	defer iter.Stop()
 
-	iter := q.Documents(ctx)
+	var ratings []*game.Rating
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		model := new(firestoreRatingModel)
+
+		err = doc.DataTo(model)
+		if err != nil {
+			return nil, err
+		}
+
+		ratings = append(ratings, unmarshalRating(model))
+	}
+
+	return ratings, nil
+}
+
+func (r FirestoreGameRepository) AllOpenReports(ctx context.Context) ([]*game.Report, error) {
+	return r.reports(r.client.Collection("reports").Where("resolved", "==", false).Documents(ctx))
+}
+
+func (r FirestoreGameRepository) reports(iter *firestore.DocumentIterator) ([]*game.Report, error) {
 	defer iter.Stop()
 
-	doc, err := iter.Next()
-	if err != nil {
-		return nil, err
+	var reports []*game.Report
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		model := new(firestoreReportModel)
+
+		err = doc.DataTo(model)
+		if err != nil {
+			return nil, err
+		}
+
+		reports = append(reports, unmarshalReport(model))
 	}
 
-	p := new(query.Player)
+	return reports, nil
+}
 
-	err = doc.DataTo(p)
-	if err != nil {
-		return nil, err
+func marshalPlayer(player *game.Player) firestorePlayerModel {
+	model := firestorePlayerModel{
+		UUID:                 player.UUID(),
+		Number:               player.Number(),
+		GamesStarted:         player.GamesStarted(),
+		GamesFinished:        player.GamesFinished(),
+		TotalPoints:          player.TotalPoints(),
+		CurrentGameStateUUID: player.CurrentGameStateUUID(),
+		NumberVerifiedAt:     player.NumberVerifiedAt(),
+		ConsentedAt:          player.ConsentedAt(),
+		OptedOutAt:           player.OptedOutAt(),
+		Locale:               player.Locale(),
+	}
+
+	if v := player.Verification(); v.Pending() {
+		model.Verification = &firestoreVerificationModel{
+			CodeHash:  v.CodeHash(),
+			SentAt:    v.SentAt(),
+			ExpiresAt: v.ExpiresAt(),
+			Attempts:  v.Attempts(),
+		}
+	}
+
+	return model
+}
+
+func unmarshalPlayer(model *firestorePlayerModel) *game.Player {
+	var verification game.Verification
+	if v := model.Verification; v != nil {
+		verification = game.UnmarshalVerificationFromDatabase(v.CodeHash, v.SentAt, v.ExpiresAt, v.Attempts)
+	}
+
+	// Fields added since a player was created are read as their zero value.
+	return game.UnmarshalPlayerFromDatabase(
+		model.UUID,
+		model.Number,
+		model.GamesStarted,
+		model.GamesFinished,
+		model.TotalPoints,
+		model.CurrentGameStateUUID,
+		model.NumberVerifiedAt,
+		verification,
+		model.ConsentedAt,
+		model.OptedOutAt,
+		model.Locale)
+}
+
+// ratingID is the ID of a rating. Players only have one rating per game so it is made from both.
+func ratingID(gameUUID, playerUUID string) string {
+	return gameUUID + "_" + playerUUID
+}
+
+func unmarshalRating(model *firestoreRatingModel) *game.Rating {
+	return game.UnmarshalRatingFromDatabase(
+		model.GameUUID,
+		model.PlayerUUID,
+		model.PlayerName,
+		model.Stars,
+		model.Review,
+		model.RatedAt.UTC())
+}
+
+func marshalReport(report *game.Report) firestoreReportModel {
+	model := firestoreReportModel{
+		UUID:         report.UUID(),
+		GameUUID:     report.GameUUID(),
+		ReporterUUID: report.ReporterUUID(),
+		Reason:       string(report.Reason()),
+		Details:      report.Details(),
+		ReportedAt:   report.ReportedAt(),
+		Resolved:     report.Resolved(),
+		ResolvedAt:   report.ResolvedAt(),
+	}
+
+	for _, flag := range report.Flags() {
+		model.Flags = append(model.Flags, firestoreModerationFlagModel{
+			Field: flag.Field,
+			Rule:  flag.Rule,
+			Match: flag.Match,
+		})
+	}
+
+	return model
+}
+
+func unmarshalReport(model *firestoreReportModel) *game.Report {
+	var flags []game.ModerationFlag
+	for _, flag := range model.Flags {
+		flags = append(flags, game.ModerationFlag{Field: flag.Field, Rule: flag.Rule, Match: flag.Match})
+	}
+
+	resolvedAt := time.Time{}
+	if model.Resolved {
+		resolvedAt = model.ResolvedAt.UTC()
+	}
+
+	return game.UnmarshalReportFromDatabase(
+		model.UUID,
+		model.GameUUID,
+		model.ReporterUUID,
+		game.ReportReason(model.Reason),
+		model.Details,
+		flags,
+		model.ReportedAt.UTC(),
+		resolvedAt)
+}
+
+func unmarshalGame(model *firestoreGameModel) (*game.Game, error) {
+	var levels []*game.Level
+	for _, level := range model.Levels {
+		levels = append(levels, game.UnmarshalLevelFromDatabase(
+			level.Title,
+			level.Description,
+			level.Clues,
+			level.Answers,
+			unmarshalLocation(level.Location)))
+	}
+
+	return game.UnmarshalFromDataBase(
+		model.UUID,
+		model.CreatorUUID,
+		model.CreatorName,
+		model.Title,
+		model.Description,
+		levels,
+		model.Ending,
+		model.Kind,
+		model.City,
+		model.State,
+		model.Country,
+		model.Value,
+		model.CreatedAt.UTC(),
+		unmarshalLocation(model.Location),
+		game.Status(model.Status),
+		unmarshalClueSettings(model.Clues),
+		model.Locale,
+		unmarshalTranslations(model.Translations))
+}
+
+func unmarshalTranslations(models []firestoreTranslationModel) []game.Translation {
+	var translations []game.Translation
+	for _, model := range models {
+		var levels []game.LevelTranslation
+		for _, level := range model.Levels {
+			levels = append(levels, game.UnmarshalLevelTranslationFromDatabase(
+				level.Title,
+				level.Description,
+				level.Clues,
+				level.Answers))
+		}
+
+		translations = append(translations, game.UnmarshalTranslationFromDatabase(
+			model.Locale,
+			model.Title,
+			model.Description,
+			model.Ending,
+			levels))
+	}
+
+	return translations
+}
+
+func unmarshalClueSettings(model *firestoreClueSettingsModel) game.ClueSettings {
+	if model == nil {
+		return game.DefaultClueSettings()
+	}
+
+	return game.UnmarshalClueSettingsFromDatabase(model.WrongAnswersRevealClues, time.Duration(model.CooldownSeconds)*time.Second)
+}
+
+func marshalLocation(location *game.Location) *firestoreLocationModel {
+	if location == nil {
+		return nil
+	}
+
+	return &firestoreLocationModel{
+		Latitude:  location.Latitude(),
+		Longitude: location.Longitude(),
+	}
+}
+
+func unmarshalLocation(model *firestoreLocationModel) *game.Location {
+	if model == nil {
+		return nil
+	}
+
+	return game.UnmarshalLocationFromDatabase(model.Latitude, model.Longitude)
+}
+
+func unmarshalState(model *firestoreStateModel) *game.State {
+	return game.UnmarshalGameStateFromDatabase(
+		model.UUID,
+		model.PlayerUUID,
+		model.GameUUID,
+		model.GameLevels,
+		model.Level,
+		model.Clue,
+		model.Skipped,
+		model.Completed,
+		model.CurrentResponse,
+		model.StartedAt.UTC(),
+		model.FinishedAt.UTC(),
+		model.ClueRevealedAt.UTC())
+}
+
+// repositoryError returns notFound or alreadyExists in place of the Firestore errors for a document that
+// doesn't exist or already exists, if they are given, so callers get the errors of game.Repository. Any
+// other error is returned unchanged.
+func repositoryError(err, notFound, alreadyExists error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		if notFound != nil {
+			return notFound
+		}
+	case codes.AlreadyExists:
+		if alreadyExists != nil {
+			return alreadyExists
+		}
 	}
 
-	return p, nil
+	return err
 }
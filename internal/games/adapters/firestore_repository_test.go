@@ -6,9 +6,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopher-cache/internal/common/emulators"
-	"gopher-cache/internal/games/app/query"
 	"gopher-cache/internal/games/domain/game"
 	"testing"
+	"time"
 )
 
 func TestFirestoreGameRepository_AddGame(t *testing.T) {
@@ -114,7 +114,7 @@ func TestFirestoreGameRepository_AddPlayer(t *testing.T) {
 	assert.Equal(t, expectedPlayer, gotPlayer)
 }
 
-func TestFirestoreGameRepository_AddState(t *testing.T) {
+func TestFirestoreGameRepository_UpdatePlayer(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
@@ -133,9 +133,7 @@ func TestFirestoreGameRepository_AddState(t *testing.T) {
 	userID, err := uuid.NewRandom()
 	require.NoError(t, err)
 
-	userNumber := "15734497033"
-
-	u, err := game.NewUser(userID.String(), userNumber)
+	u, err := game.NewUser(userID.String(), "15734497033")
 	require.NoError(t, err)
 
 	p, err := game.NewPlayerFromUser(u)
@@ -144,46 +142,37 @@ func TestFirestoreGameRepository_AddState(t *testing.T) {
 	err = repo.AddPlayer(ctx, p)
 	require.NoError(t, err)
 
-	g, err := game.NewUrbanGame(
-		u,
-		"An Awesome Game",
-		"This is an awesome game",
-		"The end!",
-		"Austin",
-		"Texas",
-		"USA",
-		game.NewLevelAdder(
-			"Level One",
-			"This is Level One",
-			[]string{"Who is the best?", "Level One is the best", "Say I am the best"},
-			[]string{"Level One is the best"}),
-		game.NewLevelAdder(
-			"Level Two",
-			"This is Level Two",
-			[]string{"Who is the best?", "Level Two is the best", "Say I am the best"},
-			[]string{"Level Two is the best"}),
-		game.NewLevelAdder(
-			"Level Three",
-			"This is Level Three",
-			[]string{"Who is the best?", "Level Three is the best", "Say I am the best"},
-			[]string{"Level Three is the best"}),
-	)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	// The pending verification is saved.
+	err = p.StartNumberVerification("123456", now)
 	require.NoError(t, err)
 
-	expectedState, _, err := game.Start(g, p)
+	err = p.VerifyNumber("654321", now)
+	require.Error(t, err)
+
+	err = repo.UpdatePlayer(ctx, p)
 	require.NoError(t, err)
 
-	// Add state
-	err = repo.AddState(ctx, expectedState)
+	gotPlayer, err := repo.GetPlayer(ctx, p.UUID())
 	require.NoError(t, err)
+	assert.Equal(t, p.Verification(), gotPlayer.Verification())
 
-	// Get state
-	gotState, err := repo.GetState(ctx, expectedState.UUID())
+	// And so is the verified number.
+	err = gotPlayer.VerifyNumber("123456", now)
 	require.NoError(t, err)
-	assert.Equal(t, expectedState, gotState)
+
+	err = repo.UpdatePlayer(ctx, gotPlayer)
+	require.NoError(t, err)
+
+	gotPlayer, err = repo.GetPlayer(ctx, p.UUID())
+	require.NoError(t, err)
+	assert.True(t, gotPlayer.NumberVerified())
+	assert.Equal(t, now, gotPlayer.NumberVerifiedAt().UTC())
+	assert.False(t, gotPlayer.Verification().Pending())
 }
 
-func TestFirestoreGameRepository_AddStateAndUpdatePlayer(t *testing.T) {
+func TestFirestoreGameRepository_ConsentRecords(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
@@ -202,62 +191,34 @@ func TestFirestoreGameRepository_AddStateAndUpdatePlayer(t *testing.T) {
 	userID, err := uuid.NewRandom()
 	require.NoError(t, err)
 
-	userNumber := "15734497033"
-
-	u, err := game.NewUser(userID.String(), userNumber)
+	u, err := game.NewUser(userID.String(), "15734497033")
 	require.NoError(t, err)
 
-	expectedPlayer, err := game.NewPlayerFromUser(u)
+	p, err := game.NewPlayerFromUser(u)
 	require.NoError(t, err)
 
-	err = repo.AddPlayer(ctx, expectedPlayer)
+	given, err := p.GiveConsent(game.ConsentSourceStartGame)
 	require.NoError(t, err)
 
-	g, err := game.NewUrbanGame(
-		u,
-		"An Awesome Game",
-		"This is an awesome game",
-		"The end!",
-		"Austin",
-		"Texas",
-		"USA",
-		game.NewLevelAdder(
-			"Level One",
-			"This is Level One",
-			[]string{"Who is the best?", "Level One is the best", "Say I am the best"},
-			[]string{"Level One is the best"}),
-		game.NewLevelAdder(
-			"Level Two",
-			"This is Level Two",
-			[]string{"Who is the best?", "Level Two is the best", "Say I am the best"},
-			[]string{"Level Two is the best"}),
-		game.NewLevelAdder(
-			"Level Three",
-			"This is Level Three",
-			[]string{"Who is the best?", "Level Three is the best", "Say I am the best"},
-			[]string{"Level Three is the best"}),
-	)
+	withdrawn, err := p.OptOut("sms:STOP")
 	require.NoError(t, err)
 
-	expectedState, _, err := game.Start(g, expectedPlayer)
+	err = repo.AddPlayer(ctx, p)
 	require.NoError(t, err)
 
-	// Add state and update player
-	err = repo.AddStateAndUpdatePlayer(ctx, expectedState, expectedPlayer)
-	require.NoError(t, err)
+	require.NoError(t, repo.AddConsentRecord(ctx, given))
+	require.NoError(t, repo.AddConsentRecord(ctx, withdrawn))
 
-	// Get state
-	gotState, err := repo.GetState(ctx, expectedState.UUID())
+	records, err := repo.GetConsentRecords(ctx, p.UUID())
 	require.NoError(t, err)
-	assert.Equal(t, expectedState, gotState)
+	assert.Equal(t, []*game.ConsentRecord{given, withdrawn}, records)
 
-	// Get player
-	gotPlayer, err := repo.GetPlayer(ctx, expectedPlayer.UUID())
+	gotPlayer, err := repo.GetPlayer(ctx, p.UUID())
 	require.NoError(t, err)
-	assert.Equal(t, expectedPlayer, gotPlayer)
+	assert.True(t, gotPlayer.OptedOut())
 }
 
-func TestFirestoreGameRepository_UpdateState(t *testing.T) {
+func TestFirestoreGameRepository_AddState(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
@@ -316,23 +277,17 @@ func TestFirestoreGameRepository_UpdateState(t *testing.T) {
 	expectedState, _, err := game.Start(g, p)
 	require.NoError(t, err)
 
-	// Add state and update player
-	err = repo.AddStateAndUpdatePlayer(ctx, expectedState, p)
-	require.NoError(t, err)
-
-	resp, err := expectedState.Update(g, "Level One is the best", p)
+	// Add state
+	err = repo.AddState(ctx, expectedState)
 	require.NoError(t, err)
-	// Make sure the state has changed.
-	require.Equal(t, game.LevelResponse, resp.Kind)
-
-	err = repo.UpdateState(ctx, expectedState)
 
+	// Get state
 	gotState, err := repo.GetState(ctx, expectedState.UUID())
 	require.NoError(t, err)
 	assert.Equal(t, expectedState, gotState)
 }
 
-func TestFirestoreGameRepository_UpdateStateAndPlayer(t *testing.T) {
+func TestFirestoreGameRepository_AddStateAndUpdatePlayer(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
@@ -395,31 +350,18 @@ func TestFirestoreGameRepository_UpdateStateAndPlayer(t *testing.T) {
 	err = repo.AddStateAndUpdatePlayer(ctx, expectedState, expectedPlayer)
 	require.NoError(t, err)
 
-	resp, err := expectedState.Update(g, "Level One is the best", expectedPlayer)
-	require.NoError(t, err)
-
-	resp, err = expectedState.Update(g, "Level Two is the best", expectedPlayer)
-	require.NoError(t, err)
-
-	resp, err = expectedState.Update(g, "Level Three is the best", expectedPlayer)
-	require.NoError(t, err)
-
-	// Make sure the state has changed.
-	require.Equal(t, game.EndResponse, resp.Kind)
-	require.Equal(t, 1, expectedPlayer.GamesFinished())
-
-	err = repo.UpdateStateAndPlayer(ctx, expectedState, expectedPlayer)
-
+	// Get state
 	gotState, err := repo.GetState(ctx, expectedState.UUID())
 	require.NoError(t, err)
 	assert.Equal(t, expectedState, gotState)
 
+	// Get player
 	gotPlayer, err := repo.GetPlayer(ctx, expectedPlayer.UUID())
 	require.NoError(t, err)
 	assert.Equal(t, expectedPlayer, gotPlayer)
 }
 
-func TestFirestoreGameRepository_ReadGames(t *testing.T) {
+func TestFirestoreGameRepository_UpdateState(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
@@ -443,41 +385,18 @@ func TestFirestoreGameRepository_ReadGames(t *testing.T) {
 	u, err := game.NewUser(userID.String(), userNumber)
 	require.NoError(t, err)
 
-	austinGame, err := game.NewUrbanGame(
-		u,
-		"An Awesome Game",
-		"This is an awesome game",
-		"The end!",
-		"Austin",
-		"Texas",
-		"USA",
-		game.NewLevelAdder(
-			"Level One",
-			"This is Level One",
-			[]string{"Who is the best?", "Level One is the best", "Say I am the best"},
-			[]string{"Level One is the best"}),
-		game.NewLevelAdder(
-			"Level Two",
-			"This is Level Two",
-			[]string{"Who is the best?", "Level Two is the best", "Say I am the best"},
-			[]string{"Level Two is the best"}),
-		game.NewLevelAdder(
-			"Level Three",
-			"This is Level Three",
-			[]string{"Who is the best?", "Level Three is the best", "Say I am the best"},
-			[]string{"Level Three is the best"}),
-	)
+	p, err := game.NewPlayerFromUser(u)
 	require.NoError(t, err)
 
-	err = repo.AddGame(ctx, austinGame)
+	err = repo.AddPlayer(ctx, p)
 	require.NoError(t, err)
 
-	dallasGame, err := game.NewUrbanGame(
+	g, err := game.NewUrbanGame(
 		u,
 		"An Awesome Game",
 		"This is an awesome game",
 		"The end!",
-		"Dallas",
+		"Austin",
 		"Texas",
 		"USA",
 		game.NewLevelAdder(
@@ -498,114 +417,26 @@ func TestFirestoreGameRepository_ReadGames(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	err = repo.AddGame(ctx, dallasGame)
-	require.NoError(t, err)
-
-	chicagoGame, err := game.NewUrbanGame(
-		u,
-		"An Awesome Game",
-		"This is an awesome game",
-		"The end!",
-		"Chicago",
-		"Illinois",
-		"USA",
-		game.NewLevelAdder(
-			"Level One",
-			"This is Level One",
-			[]string{"Who is the best?", "Level One is the best", "Say I am the best"},
-			[]string{"Level One is the best"}),
-		game.NewLevelAdder(
-			"Level Two",
-			"This is Level Two",
-			[]string{"Who is the best?", "Level Two is the best", "Say I am the best"},
-			[]string{"Level Two is the best"}),
-		game.NewLevelAdder(
-			"Level Three",
-			"This is Level Three",
-			[]string{"Who is the best?", "Level Three is the best", "Say I am the best"},
-			[]string{"Level Three is the best"}),
-	)
-	require.NoError(t, err)
-
-	err = repo.AddGame(ctx, chicagoGame)
-	require.NoError(t, err)
-
-	games, err := repo.ReadGames(ctx, 10, 0)
-	assert.NoError(t, err)
-	assert.Equal(t, 3, len(games))
-
-	games, err = repo.ReadGames(ctx, 2, 0)
-	assert.NoError(t, err)
-	assert.Equal(t, 2, len(games))
-
-	games, err = repo.ReadGames(ctx, 10, 0, query.GameOption{
-		Key:   "country",
-		Op:    "==",
-		Value: "USA",
-	})
-	assert.NoError(t, err)
-	assert.Equal(t, 3, len(games))
-
-	games, err = repo.ReadGames(ctx, 10, 0, query.GameOption{
-		Key:   "state",
-		Op:    "==",
-		Value: "Texas",
-	})
-	assert.NoError(t, err)
-	assert.Equal(t, 2, len(games))
-
-	games, err = repo.ReadGames(ctx, 10, 0, query.GameOption{
-		Key:   "state",
-		Op:    "==",
-		Value: "Illinois",
-	})
-	assert.NoError(t, err)
-	assert.Equal(t, 1, len(games))
-
-	games, err = repo.ReadGames(ctx, 10, 0, query.GameOption{
-		Key:   "city",
-		Op:    "==",
-		Value: "Austin",
-	})
-	assert.NoError(t, err)
-	assert.Equal(t, 1, len(games))
-}
-
-func TestFirestoreGameRepository_ReadPlayer(t *testing.T) {
-	if testing.Short() {
-		t.Skip()
-	}
-
-	ctx := context.Background()
-
-	client, cleanup := emulators.NewFirestoreClient(ctx)
-	defer func() {
-		_ = client.Close()
-		cleanup()
-	}()
-
-	repo, err := NewFirestoreGameRepository(client)
-	require.NoError(t, err)
-
-	userID, err := uuid.NewRandom()
+	expectedState, _, err := game.Start(g, p)
 	require.NoError(t, err)
 
-	userNumber := "15734497033"
-
-	u, err := game.NewUser(userID.String(), userNumber)
+	// Add state and update player
+	err = repo.AddStateAndUpdatePlayer(ctx, expectedState, p)
 	require.NoError(t, err)
 
-	commandPlayer, err := game.NewPlayerFromUser(u)
+	resp, err := expectedState.Update(g, "Level One is the best", p)
 	require.NoError(t, err)
+	// Make sure the state has changed.
+	require.Equal(t, game.LevelResponse, resp.Kind)
 
-	err = repo.AddPlayer(ctx, commandPlayer)
-	require.NoError(t, err)
+	err = repo.UpdateState(ctx, expectedState)
 
-	_, err = repo.ReadPlayer(ctx, commandPlayer.UUID())
+	gotState, err := repo.GetState(ctx, expectedState.UUID())
 	require.NoError(t, err)
+	assert.Equal(t, expectedState, gotState)
 }
 
-func TestFirestoreGameRepository_ReadState(t *testing.T) {
+func TestFirestoreGameRepository_UpdateStateAndPlayer(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
@@ -661,13 +492,96 @@ func TestFirestoreGameRepository_ReadState(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	commandState, _, err := game.Start(g, expectedPlayer)
+	expectedState, _, err := game.Start(g, expectedPlayer)
+	require.NoError(t, err)
+
+	// Add state and update player
+	err = repo.AddStateAndUpdatePlayer(ctx, expectedState, expectedPlayer)
+	require.NoError(t, err)
+
+	resp, err := expectedState.Update(g, "Level One is the best", expectedPlayer)
 	require.NoError(t, err)
 
-	err = repo.AddState(ctx, commandState)
+	resp, err = expectedState.Update(g, "Level Two is the best", expectedPlayer)
 	require.NoError(t, err)
 
-	queryState, err := repo.ReadState(ctx, commandState.UUID())
+	resp, err = expectedState.Update(g, "Level Three is the best", expectedPlayer)
 	require.NoError(t, err)
-	assert.Equal(t, commandState.CurrentResponse(), queryState.CurrentResponse)
+
+	// Make sure the state has changed.
+	require.Equal(t, game.EndResponse, resp.Kind)
+	require.Equal(t, 1, expectedPlayer.GamesFinished())
+
+	err = repo.UpdateStateAndPlayer(ctx, expectedState, expectedPlayer)
+
+	gotState, err := repo.GetState(ctx, expectedState.UUID())
+	require.NoError(t, err)
+	assert.Equal(t, expectedState, gotState)
+
+	gotPlayer, err := repo.GetPlayer(ctx, expectedPlayer.UUID())
+	require.NoError(t, err)
+	assert.Equal(t, expectedPlayer, gotPlayer)
+}
+
+// testGameRepositories runs test against every game repository. The Firestore repository is skipped when
+// running short tests since it requires the emulator.
+func testGameRepositories(t *testing.T, test func(t *testing.T, repo game.Repository)) {
+	t.Run("memory", func(t *testing.T) {
+		test(t, NewMemoryGameRepository())
+	})
+
+	t.Run("firestore", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip()
+		}
+
+		client, cleanup := emulators.NewFirestoreClient(context.Background())
+		defer func() {
+			_ = client.Close()
+			cleanup()
+		}()
+
+		repo, err := NewFirestoreGameRepository(client)
+		require.NoError(t, err)
+
+		test(t, repo)
+	})
+}
+
+func TestGameRepository_Errors(t *testing.T) {
+	testGameRepositories(t, func(t *testing.T, repo game.Repository) {
+		ctx := context.Background()
+
+		userID, err := uuid.NewRandom()
+		require.NoError(t, err)
+
+		u, err := game.NewUser(userID.String(), "15734497033")
+		require.NoError(t, err)
+
+		g, err := game.NewUrbanGame(u, "An Awesome Game", "This is an awesome game", "The end!", "Austin",
+			"Texas", "USA",
+			game.NewLevelAdder("Level One", "This is Level One", nil, []string{"Level One is the best"}),
+		)
+		require.NoError(t, err)
+
+		_, err = repo.GetGame(ctx, g.UUID())
+		assert.Equal(t, game.ErrorGameNotFound, err)
+
+		require.NoError(t, repo.AddGame(ctx, g))
+		assert.Equal(t, game.ErrorGameAlreadyExists, repo.AddGame(ctx, g))
+
+		p, err := game.NewPlayerFromUser(u)
+		require.NoError(t, err)
+		require.NoError(t, repo.AddPlayer(ctx, p))
+		assert.Equal(t, game.ErrorPlayerAlreadyExists, repo.AddPlayer(ctx, p))
+
+		s, _, err := game.Start(g, p)
+		require.NoError(t, err)
+
+		_, err = repo.GetState(ctx, s.UUID())
+		assert.Equal(t, game.ErrorStateNotFound, err)
+
+		require.NoError(t, repo.AddState(ctx, s))
+		assert.Equal(t, game.ErrorStateAlreadyExists, repo.AddState(ctx, s))
+	})
 }
@@ -0,0 +1,24 @@
+package adapters
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+)
+
+// LogSMSSender logs text messages instead of sending them. It is intended for local development where
+// there is no SMS provider, e.g. to read verification codes from the logs.
+type LogSMSSender struct{}
+
+// NewLogSMSSender creates a new SMS sender that logs messages.
+func NewLogSMSSender() LogSMSSender {
+	return LogSMSSender{}
+}
+
+func (s LogSMSSender) SendSMS(_ context.Context, number, message string) error {
+	logrus.WithFields(logrus.Fields{
+		"number":  number,
+		"message": message,
+	}).Info("Sending SMS")
+
+	return nil
+}
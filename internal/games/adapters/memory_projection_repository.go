@@ -0,0 +1,551 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"gopher-cache/internal/games/app/query"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	_ query.ProjectionStore = &MemoryProjectionRepository{}
+	_ query.GameReadModel   = &MemoryProjectionRepository{}
+	_ query.GamesReadModel  = &MemoryProjectionRepository{}
+	_ query.PlayerReadModel = &MemoryProjectionRepository{}
+	_ query.StateReadModel  = &MemoryProjectionRepository{}
+
+	_ query.GameAnalyticsReadModel = &MemoryProjectionRepository{}
+	_ query.ReviewsReadModel       = &MemoryProjectionRepository{}
+
+	_ query.ModerationQueueReadModel = &MemoryProjectionRepository{}
+	_ query.ProjectionLagReadModel   = &MemoryProjectionRepository{}
+)
+
+// MemoryProjectionRepository stores the projections backing the read models in memory.
+type MemoryProjectionRepository struct {
+	lock *sync.RWMutex
+	// txLock serializes transactions so every read-modify-write of a projection is isolated.
+	txLock *sync.Mutex
+
+	games     map[string]query.Game
+	players   map[string]query.Player
+	states    map[string]query.State
+	analytics map[string]query.GameAnalytics
+	reviews   map[string]query.Review
+	cases     map[string]query.ModerationCase
+	lag       query.ProjectionLag
+}
+
+// NewMemoryProjectionRepository creates a new in memory projection repository.
+func NewMemoryProjectionRepository() *MemoryProjectionRepository {
+	return &MemoryProjectionRepository{
+		lock:      &sync.RWMutex{},
+		txLock:    &sync.Mutex{},
+		games:     make(map[string]query.Game),
+		players:   make(map[string]query.Player),
+		states:    make(map[string]query.State),
+		analytics: make(map[string]query.GameAnalytics),
+		reviews:   make(map[string]query.Review),
+		cases:     make(map[string]query.ModerationCase),
+	}
+}
+
+func (r *MemoryProjectionRepository) UpdateProjections(
+	ctx context.Context,
+	updateFn func(ctx context.Context, tx query.ProjectionTransaction) error,
+) error {
+	r.txLock.Lock()
+	defer r.txLock.Unlock()
+
+	return updateFn(ctx, r)
+}
+
+func (r *MemoryProjectionRepository) RecordProjectionLag(_ context.Context, lag time.Duration) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.lag.Record(lag)
+
+	return nil
+}
+
+func (r *MemoryProjectionRepository) ReadProjectionLag(_ context.Context) (*query.ProjectionLag, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	lag := r.lag
+
+	return &lag, nil
+}
+
+func (r *MemoryProjectionRepository) GetGameProjection(_ context.Context, uuid string) (*query.Game, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	g, ok := r.games[uuid]
+	if !ok {
+		return nil, query.ErrorProjectionNotFound
+	}
+
+	return &g, nil
+}
+
+func (r *MemoryProjectionRepository) SaveGameProjection(_ context.Context, g *query.Game) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.games[g.UUID] = *g
+
+	return nil
+}
+
+func (r *MemoryProjectionRepository) GetPlayerProjection(_ context.Context, uuid string) (*query.Player, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	p, ok := r.players[uuid]
+	if !ok {
+		return nil, query.ErrorProjectionNotFound
+	}
+
+	p.History = append([]query.PlayedGame{}, p.History...)
+
+	return &p, nil
+}
+
+func (r *MemoryProjectionRepository) SavePlayerProjection(_ context.Context, p *query.Player) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	saved := *p
+	saved.History = append([]query.PlayedGame{}, p.History...)
+	r.players[p.UUID] = saved
+
+	return nil
+}
+
+func (r *MemoryProjectionRepository) GetStateProjection(_ context.Context, uuid string) (*query.State, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	s, ok := r.states[uuid]
+	if !ok {
+		return nil, query.ErrorProjectionNotFound
+	}
+
+	return &s, nil
+}
+
+func (r *MemoryProjectionRepository) SaveStateProjection(_ context.Context, s *query.State) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.states[s.UUID] = *s
+
+	return nil
+}
+
+func (r *MemoryProjectionRepository) GetGameAnalyticsProjection(_ context.Context, gameUUID string) (*query.GameAnalytics, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	a, ok := r.analytics[gameUUID]
+	if !ok {
+		return nil, query.ErrorProjectionNotFound
+	}
+
+	return copyGameAnalytics(a), nil
+}
+
+func (r *MemoryProjectionRepository) SaveGameAnalyticsProjection(_ context.Context, a *query.GameAnalytics) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.analytics[a.GameUUID] = *copyGameAnalytics(*a)
+
+	return nil
+}
+
+func (r *MemoryProjectionRepository) SaveReviewProjection(_ context.Context, review *query.Review) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.reviews[review.ID] = *review
+
+	return nil
+}
+
+func (r *MemoryProjectionRepository) GetModerationCaseProjection(_ context.Context, gameUUID string) (*query.ModerationCase, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	c, ok := r.cases[gameUUID]
+	if !ok {
+		return nil, query.ErrorProjectionNotFound
+	}
+
+	return copyModerationCase(c), nil
+}
+
+func (r *MemoryProjectionRepository) SaveModerationCaseProjection(_ context.Context, c *query.ModerationCase) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.cases[c.GameUUID] = *copyModerationCase(*c)
+
+	return nil
+}
+
+func (r *MemoryProjectionRepository) DeleteModerationCaseProjection(_ context.Context, gameUUID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.cases, gameUUID)
+
+	return nil
+}
+
+func (r *MemoryProjectionRepository) ClearProjections(_ context.Context) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.games = make(map[string]query.Game)
+	r.players = make(map[string]query.Player)
+	r.states = make(map[string]query.State)
+	r.analytics = make(map[string]query.GameAnalytics)
+	r.reviews = make(map[string]query.Review)
+	r.cases = make(map[string]query.ModerationCase)
+	r.lag = query.ProjectionLag{}
+
+	return nil
+}
+
+func (r *MemoryProjectionRepository) ReadGame(ctx context.Context, uuid string) (*query.Game, error) {
+	return r.GetGameProjection(ctx, uuid)
+}
+
+func (r *MemoryProjectionRepository) ReadGamesByUUID(_ context.Context, uuids []string) ([]*query.Game, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	games := make([]*query.Game, len(uuids))
+	for i, uuid := range uuids {
+		if g, ok := r.games[uuid]; ok {
+			games[i] = &g
+		}
+	}
+
+	return games, nil
+}
+
+func (r *MemoryProjectionRepository) ReadGames(_ context.Context, q query.GameQuery, page query.Page) ([]*query.Game, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	// If no games are found return empty non-nil slice.
+	results := []*query.Game{}
+
+	for _, g := range r.games {
+		match, err := gameMatches(g, q.Options)
+		if err != nil {
+			return results, err
+		}
+
+		if match {
+			g := g
+			results = append(results, &g)
+		}
+	}
+
+	order := q.Order()
+	value := gameFieldSortValue(order.Key)
+	sortGames(results, value, order.Descending)
+
+	return pageGames(results, page, value), nil
+}
+
+func (r *MemoryProjectionRepository) ReadGamesNear(_ context.Context, near query.Near, q query.GameQuery, page query.Page) ([]*query.Game, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	results := []*query.Game{}
+
+	for _, g := range r.games {
+		match, err := gameMatches(g, q.Options)
+		if err != nil {
+			return []*query.Game{}, err
+		}
+
+		g := g
+		if match && withinRadius(&g, near) {
+			results = append(results, &g)
+		}
+	}
+
+	sortGames(results, distanceSortValue, false)
+
+	return pageGames(results, page, distanceSortValue), nil
+}
+
+func (r *MemoryProjectionRepository) ReadGameReviews(_ context.Context, gameUUID string, page query.Page) ([]*query.Review, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	// If no reviews are found return empty non-nil slice.
+	results := []*query.Review{}
+
+	for _, review := range r.reviews {
+		if review.GameUUID == gameUUID {
+			review := review
+			results = append(results, &review)
+		}
+	}
+
+	// Newest first, like Firestore, with ties broken by ID in the same direction.
+	compare := func(review *query.Review, ratedAt time.Time, id string) int {
+		c, _ := compareValues(review.RatedAt, ratedAt)
+		if c == 0 {
+			c = strings.Compare(review.ID, id)
+		}
+
+		return c
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return compare(results[i], results[j].RatedAt, results[j].ID) > 0
+	})
+
+	if after := page.After; after != nil {
+		ratedAt, _ := after.Value.(time.Time)
+		i := sort.Search(len(results), func(i int) bool {
+			return compare(results[i], ratedAt, after.UUID) < 0
+		})
+
+		results = results[i:]
+	}
+
+	if page.Offset >= len(results) {
+		return []*query.Review{}, nil
+	}
+
+	results = results[page.Offset:]
+
+	if page.Limit < len(results) {
+		results = results[:page.Limit]
+	}
+
+	return results, nil
+}
+
+func (r *MemoryProjectionRepository) ReadModerationQueue(_ context.Context, page query.Page) ([]*query.ModerationCase, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	// If the queue is empty return empty non-nil slice.
+	results := []*query.ModerationCase{}
+
+	for _, c := range r.cases {
+		results = append(results, copyModerationCase(c))
+	}
+
+	// Oldest first, like Firestore, with ties broken by game UUID.
+	compare := func(c *query.ModerationCase, reportedAt time.Time, gameUUID string) int {
+		cmp, _ := compareValues(c.FirstReportedAt, reportedAt)
+		if cmp == 0 {
+			cmp = strings.Compare(c.GameUUID, gameUUID)
+		}
+
+		return cmp
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return compare(results[i], results[j].FirstReportedAt, results[j].GameUUID) < 0
+	})
+
+	if after := page.After; after != nil {
+		reportedAt, _ := after.Value.(time.Time)
+		i := sort.Search(len(results), func(i int) bool {
+			return compare(results[i], reportedAt, after.UUID) > 0
+		})
+
+		results = results[i:]
+	}
+
+	if page.Offset >= len(results) {
+		return []*query.ModerationCase{}, nil
+	}
+
+	results = results[page.Offset:]
+
+	if page.Limit < len(results) {
+		results = results[:page.Limit]
+	}
+
+	return results, nil
+}
+
+func (r *MemoryProjectionRepository) ReadPlayer(ctx context.Context, uuid string) (*query.Player, error) {
+	return r.GetPlayerProjection(ctx, uuid)
+}
+
+func (r *MemoryProjectionRepository) ReadState(ctx context.Context, uuid string) (*query.State, error) {
+	return r.GetStateProjection(ctx, uuid)
+}
+
+func (r *MemoryProjectionRepository) ReadStatesByUUID(_ context.Context, uuids []string) ([]*query.State, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	states := make([]*query.State, len(uuids))
+	for i, uuid := range uuids {
+		if s, ok := r.states[uuid]; ok {
+			states[i] = &s
+		}
+	}
+
+	return states, nil
+}
+
+func (r *MemoryProjectionRepository) ReadGameAnalytics(ctx context.Context, gameUUID string) (*query.GameAnalytics, error) {
+	return r.GetGameAnalyticsProjection(ctx, gameUUID)
+}
+
+func (r *MemoryProjectionRepository) ReadCreatorGameAnalytics(_ context.Context, creatorUUID string) ([]*query.GameAnalytics, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	results := []*query.GameAnalytics{}
+
+	for _, a := range r.analytics {
+		if a.CreatorUUID == creatorUUID {
+			results = append(results, copyGameAnalytics(a))
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].GameUUID < results[j].GameUUID
+	})
+
+	return results, nil
+}
+
+// copyGameAnalytics makes a deep copy so callers can't modify what is stored.
+func copyGameAnalytics(a query.GameAnalytics) *query.GameAnalytics {
+	levels := make([]query.LevelAnalytics, len(a.Levels))
+
+	for i, l := range a.Levels {
+		levels[i] = l
+		levels[i].WrongAnswers = make(map[string]int, len(l.WrongAnswers))
+
+		for answer, count := range l.WrongAnswers {
+			levels[i].WrongAnswers[answer] = count
+		}
+	}
+
+	a.Levels = levels
+
+	return &a
+}
+
+// copyModerationCase makes a deep copy so callers can't modify what is stored.
+func copyModerationCase(c query.ModerationCase) *query.ModerationCase {
+	reasons := make(map[string]int, len(c.Reasons))
+	for reason, count := range c.Reasons {
+		reasons[reason] = count
+	}
+
+	c.Reasons = reasons
+	c.Details = append([]string(nil), c.Details...)
+	c.Flags = append([]query.ModerationFlag(nil), c.Flags...)
+
+	return &c
+}
+
+func gameMatches(g query.Game, options []query.GameOption) (bool, error) {
+	for _, option := range options {
+		value, ok := g.FieldValue(option.Key)
+		if !ok {
+			return false, nil
+		}
+
+		match, err := optionMatches(option, value)
+		if err != nil || !match {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func optionMatches(option query.GameOption, value interface{}) (bool, error) {
+	if option.Op == query.OpIn {
+		values, ok := option.Value.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("in needs a slice of values not %T", option.Value)
+		}
+
+		for _, v := range values {
+			if c, ok := compareValues(value, v); ok && c == 0 {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	c, ok := compareValues(value, option.Value)
+	if !ok {
+		// Like Firestore, values of a different type never match.
+		return false, nil
+	}
+
+	switch option.Op {
+	case query.OpEqual:
+		return c == 0, nil
+	case query.OpGreaterThan:
+		return c > 0, nil
+	case query.OpGreaterThanOrEqual:
+		return c >= 0, nil
+	case query.OpLessThan:
+		return c < 0, nil
+	case query.OpLessThanOrEqual:
+		return c <= 0, nil
+	default:
+		return false, fmt.Errorf("unsupported operator %q", option.Op)
+	}
+}
+
+// compareValues returns -1, 0 or 1 if a is less than, equal to or greater than b. It reports false if
+// the values can't be compared.
+func compareValues(a, b interface{}) (int, bool) {
+	switch a := a.(type) {
+	case string:
+		b, ok := b.(string)
+		return strings.Compare(a, b), ok
+	case int:
+		b, ok := b.(int)
+		return compareFloats(float64(a), float64(b)), ok
+	case float64:
+		b, ok := b.(float64)
+		return compareFloats(a, b), ok
+	case time.Time:
+		b, ok := b.(time.Time)
+		return compareFloats(float64(a.Sub(b)), 0), ok
+	default:
+		return 0, false
+	}
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
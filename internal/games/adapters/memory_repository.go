@@ -0,0 +1,382 @@
+package adapters
+
+import (
+	"context"
+	"gopher-cache/internal/games/domain/game"
+	"sync"
+)
+
+var _ game.Repository = &MemoryGameRepository{}
+
+// MemoryGameRepository implements the game repository in memory. It is intended for tests and local
+// development where the Firestore emulator is not available.
+type MemoryGameRepository struct {
+	lock     *sync.RWMutex
+	games    map[string]game.Game
+	players  map[string]game.Player
+	states   map[string]game.State
+	attempts []game.Attempt
+	// ratings are keyed by ratingID.
+	ratings map[string]game.Rating
+	// ratingSummaries are keyed by game UUID.
+	ratingSummaries map[string]game.RatingSummary
+	reports         map[string]game.Report
+	// consentRecords are kept in the order they were added.
+	consentRecords []game.ConsentRecord
+}
+
+// NewMemoryGameRepository creates a new in memory game repository.
+func NewMemoryGameRepository() *MemoryGameRepository {
+	return &MemoryGameRepository{
+		lock:            &sync.RWMutex{},
+		games:           make(map[string]game.Game),
+		players:         make(map[string]game.Player),
+		states:          make(map[string]game.State),
+		ratings:         make(map[string]game.Rating),
+		ratingSummaries: make(map[string]game.RatingSummary),
+		reports:         make(map[string]game.Report),
+	}
+}
+
+func (r *MemoryGameRepository) AddGame(_ context.Context, g *game.Game) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.games[g.UUID()]; ok {
+		return game.ErrorGameAlreadyExists
+	}
+
+	r.games[g.UUID()] = *g
+
+	return nil
+}
+
+func (r *MemoryGameRepository) GetGame(_ context.Context, uuid string) (*game.Game, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	g, ok := r.games[uuid]
+	if !ok {
+		return nil, game.ErrorGameNotFound
+	}
+
+	return &g, nil
+}
+
+func (r *MemoryGameRepository) AddReport(_ context.Context, report *game.Report) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.reports[report.UUID()]; ok {
+		return game.ErrorReportAlreadyExists
+	}
+
+	r.reports[report.UUID()] = *report
+
+	return nil
+}
+
+func (r *MemoryGameRepository) GetOpenReports(_ context.Context, gameUUID string) ([]*game.Report, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var reports []*game.Report
+	for _, report := range r.reports {
+		if report.GameUUID() == gameUUID && !report.Resolved() {
+			report := report
+			reports = append(reports, &report)
+		}
+	}
+
+	return reports, nil
+}
+
+func (r *MemoryGameRepository) UpdateGameAndReports(_ context.Context, g *game.Game, reports []*game.Report) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.games[g.UUID()]; !ok {
+		return game.ErrorGameNotFound
+	}
+
+	r.games[g.UUID()] = *g
+
+	for _, report := range reports {
+		r.reports[report.UUID()] = *report
+	}
+
+	return nil
+}
+
+func (r *MemoryGameRepository) AddPlayer(_ context.Context, p *game.Player) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.players[p.UUID()]; ok {
+		return game.ErrorPlayerAlreadyExists
+	}
+
+	r.players[p.UUID()] = *p
+
+	return nil
+}
+
+func (r *MemoryGameRepository) GetPlayer(_ context.Context, uuid string) (*game.Player, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	p, ok := r.players[uuid]
+	if !ok {
+		return nil, game.ErrorPlayerNotFound
+	}
+
+	return &p, nil
+}
+
+func (r *MemoryGameRepository) GetPlayerByNumber(_ context.Context, playerNumber string) (*game.Player, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	for _, p := range r.players {
+		if p.Number() == playerNumber {
+			return &p, nil
+		}
+	}
+
+	return nil, game.ErrorPlayerNotFound
+}
+
+func (r *MemoryGameRepository) UpdatePlayer(_ context.Context, p *game.Player) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.players[p.UUID()] = *p
+
+	return nil
+}
+
+func (r *MemoryGameRepository) AddConsentRecord(_ context.Context, record *game.ConsentRecord) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.consentRecords = append(r.consentRecords, *record)
+
+	return nil
+}
+
+func (r *MemoryGameRepository) GetConsentRecords(_ context.Context, playerUUID string) ([]*game.ConsentRecord, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var records []*game.ConsentRecord
+	for _, record := range r.consentRecords {
+		if record.PlayerUUID() == playerUUID {
+			record := record
+			records = append(records, &record)
+		}
+	}
+
+	return records, nil
+}
+
+func (r *MemoryGameRepository) AddState(_ context.Context, s *game.State) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.states[s.UUID()]; ok {
+		return game.ErrorStateAlreadyExists
+	}
+
+	r.states[s.UUID()] = *s
+
+	return nil
+}
+
+func (r *MemoryGameRepository) GetState(_ context.Context, uuid string) (*game.State, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	s, ok := r.states[uuid]
+	if !ok {
+		return nil, game.ErrorStateNotFound
+	}
+
+	return &s, nil
+}
+
+func (r *MemoryGameRepository) GetPlayerGameStates(_ context.Context, playerUUID, gameUUID string) ([]*game.State, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var states []*game.State
+	for _, s := range r.states {
+		if s.PlayerUUID() == playerUUID && s.GameUUID() == gameUUID {
+			s := s
+			states = append(states, &s)
+		}
+	}
+
+	return states, nil
+}
+
+func (r *MemoryGameRepository) UpdateState(_ context.Context, s *game.State) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.states[s.UUID()] = *s
+
+	return nil
+}
+
+func (r *MemoryGameRepository) AddStateAndUpdatePlayer(_ context.Context, s *game.State, p *game.Player) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.states[s.UUID()]; ok {
+		return game.ErrorStateAlreadyExists
+	}
+
+	r.states[s.UUID()] = *s
+	r.players[p.UUID()] = *p
+
+	return nil
+}
+
+func (r *MemoryGameRepository) UpdateStateAndPlayer(_ context.Context, s *game.State, p *game.Player) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.states[s.UUID()] = *s
+	r.players[p.UUID()] = *p
+
+	return nil
+}
+
+func (r *MemoryGameRepository) UpdateStateAndPlayerWithAttempt(_ context.Context, s *game.State, p *game.Player, a *game.Attempt) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.states[s.UUID()] = *s
+	r.players[p.UUID()] = *p
+	r.attempts = append(r.attempts, *a)
+
+	return nil
+}
+
+func (r *MemoryGameRepository) GetRating(_ context.Context, gameUUID, playerUUID string) (*game.Rating, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	rating, ok := r.ratings[ratingID(gameUUID, playerUUID)]
+	if !ok {
+		return nil, game.ErrorRatingNotFound
+	}
+
+	return &rating, nil
+}
+
+func (r *MemoryGameRepository) SaveRating(
+	_ context.Context,
+	rating *game.Rating,
+	updateSummary func(previous *game.Rating, summary *game.RatingSummary) error,
+) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	id := ratingID(rating.GameUUID(), rating.PlayerUUID())
+
+	var previous *game.Rating
+	if p, ok := r.ratings[id]; ok {
+		previous = &p
+	}
+
+	summary := r.ratingSummaries[rating.GameUUID()]
+	if err := updateSummary(previous, &summary); err != nil {
+		return err
+	}
+
+	r.ratings[id] = *rating
+	r.ratingSummaries[rating.GameUUID()] = summary
+
+	return nil
+}
+
+func (r *MemoryGameRepository) AllGames(_ context.Context) ([]*game.Game, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var games []*game.Game
+	for _, g := range r.games {
+		g := g
+		games = append(games, &g)
+	}
+
+	return games, nil
+}
+
+func (r *MemoryGameRepository) AllStates(_ context.Context) ([]*game.State, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var states []*game.State
+	for _, s := range r.states {
+		s := s
+		states = append(states, &s)
+	}
+
+	return states, nil
+}
+
+func (r *MemoryGameRepository) AllPlayers(_ context.Context) ([]*game.Player, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var players []*game.Player
+	for _, p := range r.players {
+		p := p
+		players = append(players, &p)
+	}
+
+	return players, nil
+}
+
+func (r *MemoryGameRepository) AllAttempts(_ context.Context) ([]*game.Attempt, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var attempts []*game.Attempt
+	for _, a := range r.attempts {
+		a := a
+		attempts = append(attempts, &a)
+	}
+
+	return attempts, nil
+}
+
+func (r *MemoryGameRepository) AllRatings(_ context.Context) ([]*game.Rating, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var ratings []*game.Rating
+	for _, rating := range r.ratings {
+		rating := rating
+		ratings = append(ratings, &rating)
+	}
+
+	return ratings, nil
+}
+
+func (r *MemoryGameRepository) AllOpenReports(_ context.Context) ([]*game.Report, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var reports []*game.Report
+	for _, report := range r.reports {
+		if !report.Resolved() {
+			report := report
+			reports = append(reports, &report)
+		}
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,232 @@
+package adapters
+
+import (
+	"context"
+	"gopher-cache/internal/common/search"
+	"gopher-cache/internal/games/app/query"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	_ query.GameSearchIndex     = &MemorySearchIndex{}
+	_ query.GameSearchReadModel = &MemorySearchIndex{}
+)
+
+// fieldBoosts weighs matches in each indexed field of a game. A match in the title counts the most.
+var fieldBoosts = map[string]float64{
+	"title":       3,
+	"city":        2,
+	"description": 1,
+}
+
+// indexedFields are the fields of a game which are searched.
+var indexedFields = []string{"title", "city", "description"}
+
+// MemorySearchIndex is an in process inverted index for full-text search of games.
+// Since it is kept in memory each instance has its own, which query.SyncGameSearchIndex fills and keeps up to
+// date with the games projected by every instance.
+type MemorySearchIndex struct {
+	lock *sync.RWMutex
+	// postings maps a term to the games containing it and how often it occurs in each field.
+	postings map[string]map[string]map[string]int
+	games    map[string]indexedGame
+}
+
+type indexedGame struct {
+	game  query.Game
+	terms []string
+}
+
+// NewMemorySearchIndex creates a new empty search index.
+func NewMemorySearchIndex() *MemorySearchIndex {
+	return &MemorySearchIndex{
+		lock:     &sync.RWMutex{},
+		postings: make(map[string]map[string]map[string]int),
+		games:    make(map[string]indexedGame),
+	}
+}
+
+func (i *MemorySearchIndex) IndexGame(_ context.Context, g *query.Game) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.remove(g.UUID)
+
+	indexed := indexedGame{game: *g}
+
+	for field, text := range map[string]string{
+		"title":       g.Title,
+		"city":        g.City,
+		"description": g.Description,
+	} {
+		for _, term := range search.Terms(text) {
+			games, ok := i.postings[term]
+			if !ok {
+				games = make(map[string]map[string]int)
+				i.postings[term] = games
+			}
+
+			fields, ok := games[g.UUID]
+			if !ok {
+				fields = make(map[string]int)
+				games[g.UUID] = fields
+				indexed.terms = append(indexed.terms, term)
+			}
+
+			fields[field]++
+		}
+	}
+
+	i.games[g.UUID] = indexed
+
+	return nil
+}
+
+func (i *MemorySearchIndex) RemoveGame(_ context.Context, uuid string) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.remove(uuid)
+
+	return nil
+}
+
+// remove takes a game out of the index. The lock must be held when calling it.
+func (i *MemorySearchIndex) remove(uuid string) {
+	indexed, ok := i.games[uuid]
+	if !ok {
+		return
+	}
+
+	for _, term := range indexed.terms {
+		delete(i.postings[term], uuid)
+
+		if len(i.postings[term]) == 0 {
+			delete(i.postings, term)
+		}
+	}
+
+	delete(i.games, uuid)
+}
+
+func (i *MemorySearchIndex) ClearIndex(_ context.Context) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.postings = make(map[string]map[string]map[string]int)
+	i.games = make(map[string]indexedGame)
+
+	return nil
+}
+
+// SearchGames ranks games with TF-IDF weighted by field. Query terms also match indexed terms within
+// search.MaxTypos edits, at a lower weight the more edits are needed. Games matching more of the
+// query terms rank higher.
+func (i *MemorySearchIndex) SearchGames(_ context.Context, s query.GameSearch, page query.Page) ([]*query.Game, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	terms := search.Terms(s.Text)
+
+	scores := map[string]float64{}
+	matched := map[string]int{}
+
+	for _, term := range terms {
+		found := map[string]bool{}
+
+		matches := i.matchingTerms(term)
+
+		// Add the scores up in the same order every time so a game always gets exactly the same
+		// score, which cursors rely on.
+		indexedTerms := make([]string, 0, len(matches))
+		for indexed := range matches {
+			indexedTerms = append(indexedTerms, indexed)
+		}
+		sort.Strings(indexedTerms)
+
+		for _, indexed := range indexedTerms {
+			weight := matches[indexed]
+			games := i.postings[indexed]
+			idf := math.Log(1 + float64(len(i.games))/float64(len(games)))
+
+			for uuid, fields := range games {
+				if !facetsMatch(i.games[uuid].game, s) {
+					continue
+				}
+
+				for _, field := range indexedFields {
+					scores[uuid] += weight * idf * fieldBoosts[field] * float64(fields[field])
+				}
+
+				found[uuid] = true
+			}
+		}
+
+		for uuid := range found {
+			matched[uuid]++
+		}
+	}
+
+	results := []*query.Game{}
+
+	for uuid, score := range scores {
+		g := i.games[uuid].game
+		score := score * float64(matched[uuid]) / float64(len(terms))
+		g.Score = &score
+		results = append(results, &g)
+	}
+
+	sortGames(results, scoreSortValue, true)
+
+	return pageGames(results, page, scoreSortValue), nil
+}
+
+// matchingTerms returns the indexed terms close enough to term to match it and the weight of the match.
+// The lock must be held when calling it.
+func (i *MemorySearchIndex) matchingTerms(term string) map[string]float64 {
+	matches := map[string]float64{}
+
+	if _, ok := i.postings[term]; ok {
+		matches[term] = 1
+	}
+
+	maxTypos := search.MaxTypos(term)
+	if maxTypos == 0 {
+		return matches
+	}
+
+	for indexed := range i.postings {
+		if indexed == term {
+			continue
+		}
+
+		diff := len([]rune(indexed)) - len([]rune(term))
+		if diff > maxTypos || -diff > maxTypos {
+			continue
+		}
+
+		if d := search.EditDistance(term, indexed); d <= maxTypos {
+			matches[indexed] = 1 / float64(1+d)
+		}
+	}
+
+	return matches
+}
+
+func facetsMatch(g query.Game, s query.GameSearch) bool {
+	for _, facet := range [][2]string{
+		{s.Kind, g.Kind},
+		{s.City, g.City},
+		{s.State, g.State},
+		{s.Country, g.Country},
+	} {
+		if facet[0] != "" && !strings.EqualFold(facet[0], facet[1]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,61 @@
+package adapters
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/games/app/query"
+	"testing"
+)
+
+func TestMemorySearchIndex_SearchGames(t *testing.T) {
+	ctx := context.Background()
+	index := NewMemorySearchIndex()
+
+	for _, g := range []*query.Game{
+		{UUID: "1", Title: "Pirate Treasure", Description: "Find the buried gold", Kind: "urban", City: "Galveston", State: "Texas", Country: "USA"},
+		{UUID: "2", Title: "Downtown History Walk", Description: "Learn the history of downtown", Kind: "urban", City: "Austin", State: "Texas", Country: "USA"},
+		{UUID: "3", Title: "Capitol Tour", Description: "A walk past pirates and other downtown history", Kind: "urban", City: "Austin", State: "Texas", Country: "USA"},
+		{UUID: "4", Title: "Lakeside Pirates", Description: "Arr", Kind: "urban", City: "Chicago", State: "Illinois", Country: "USA"},
+	} {
+		require.NoError(t, index.IndexGame(ctx, g))
+	}
+
+	games, err := index.SearchGames(ctx, query.GameSearch{Text: "pirate"}, query.Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(games))
+	// Title matches rank above description matches.
+	assert.Equal(t, "3", games[2].UUID)
+
+	games, err = index.SearchGames(ctx, query.GameSearch{Text: "downtown history"}, query.Page{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(games))
+	assert.Equal(t, "2", games[0].UUID)
+	assert.True(t, *games[0].Score > *games[1].Score)
+
+	// Typos still match.
+	games, err = index.SearchGames(ctx, query.GameSearch{Text: "pirat tresure"}, query.Page{Limit: 10})
+	require.NoError(t, err)
+	require.NotEmpty(t, games)
+	assert.Equal(t, "1", games[0].UUID)
+
+	games, err = index.SearchGames(ctx, query.GameSearch{Text: "pirates", State: "illinois"}, query.Page{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(games))
+	assert.Equal(t, "4", games[0].UUID)
+
+	games, err = index.SearchGames(ctx, query.GameSearch{Text: "pirates"}, query.Page{Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(games))
+
+	// Reindexing replaces the old terms.
+	require.NoError(t, index.IndexGame(ctx, &query.Game{UUID: "4", Title: "Lakeside Stroll", Description: "Arr", City: "Chicago"}))
+	games, err = index.SearchGames(ctx, query.GameSearch{Text: "pirates", City: "Chicago"}, query.Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(games))
+
+	require.NoError(t, index.ClearIndex(ctx))
+	games, err = index.SearchGames(ctx, query.GameSearch{Text: "downtown"}, query.Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(games))
+}
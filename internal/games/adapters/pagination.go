@@ -0,0 +1,78 @@
+package adapters
+
+import (
+	"gopher-cache/internal/games/app/query"
+	"sort"
+	"strings"
+)
+
+// sortValue returns the value of a game that games are sorted by before their UUID.
+type sortValue func(g *query.Game) interface{}
+
+// gameFieldSortValue sorts games by a field. If key is empty games are only sorted by UUID.
+func gameFieldSortValue(key string) sortValue {
+	return func(g *query.Game) interface{} {
+		if key == "" {
+			return nil
+		}
+
+		v, _ := g.FieldValue(key)
+		return v
+	}
+}
+
+func distanceSortValue(g *query.Game) interface{} { return *g.DistanceKm }
+func scoreSortValue(g *query.Game) interface{}    { return *g.Score }
+
+// compareGames compares games by value then UUID. It returns -1, 0 or 1 like compareValues.
+func compareGames(a *query.Game, aValue interface{}, uuid string, value sortValue) int {
+	c, _ := compareValues(value(a), aValue)
+	if c == 0 {
+		c = strings.Compare(a.UUID, uuid)
+	}
+
+	return c
+}
+
+// sortGames sorts games by value and breaks ties by UUID in the same direction, the way Firestore
+// breaks ties by document ID.
+func sortGames(games []*query.Game, value sortValue, descending bool) {
+	sort.Slice(games, func(i, j int) bool {
+		c := compareGames(games[i], value(games[j]), games[j].UUID, value)
+
+		if descending {
+			return c > 0
+		}
+
+		return c < 0
+	})
+}
+
+// pageGames returns the page of games which have already been sorted by sortGames with the same value.
+func pageGames(games []*query.Game, page query.Page, value sortValue) []*query.Game {
+	if after := page.After; after != nil {
+		i := sort.Search(len(games), func(i int) bool {
+			c := compareGames(games[i], after.Value, after.UUID, value)
+
+			if after.Descending {
+				return c < 0
+			}
+
+			return c > 0
+		})
+
+		games = games[i:]
+	}
+
+	if page.Offset >= len(games) {
+		return []*query.Game{}
+	}
+
+	games = games[page.Offset:]
+
+	if page.Limit < len(games) {
+		games = games[:page.Limit]
+	}
+
+	return games
+}
@@ -0,0 +1,747 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/common/emulators"
+	"gopher-cache/internal/games/app/query"
+	"gopher-cache/internal/games/domain/game"
+	"testing"
+	"time"
+)
+
+type projectionRepository interface {
+	query.ProjectionStore
+	query.GameReadModel
+	query.GamesReadModel
+	query.PlayerReadModel
+	query.StateReadModel
+	query.GameAnalyticsReadModel
+	query.ReviewsReadModel
+	query.ModerationQueueReadModel
+	query.ProjectionTransaction
+}
+
+// testProjectionRepositories runs test against every projection repository. The Firestore
+// repository is skipped when running short tests since it requires the emulator.
+func testProjectionRepositories(t *testing.T, test func(t *testing.T, repo projectionRepository)) {
+	t.Run("memory", func(t *testing.T) {
+		test(t, NewMemoryProjectionRepository())
+	})
+
+	t.Run("firestore", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip()
+		}
+
+		client, cleanup := emulators.NewFirestoreClient(context.Background())
+		defer func() {
+			_ = client.Close()
+			cleanup()
+		}()
+
+		repo, err := NewFirestoreProjectionRepository(client)
+		require.NoError(t, err)
+
+		test(t, repo)
+	})
+}
+
+func TestProjectionRepository_ReadGames(t *testing.T) {
+	testProjectionRepositories(t, func(t *testing.T, repo projectionRepository) {
+		ctx := context.Background()
+		projector := query.NewProjector(repo)
+		u := newTestProjectionUser(t)
+
+		for _, location := range [][2]string{{"Austin", "Texas"}, {"Dallas", "Texas"}, {"Chicago", "Illinois"}} {
+			g := newTestProjectionGame(t, u, location[0], location[1])
+			require.NoError(t, projector.Publish(ctx, game.NewGameCreatedEvent(g)))
+		}
+
+		games, err := repo.ReadGames(ctx, query.GameQuery{}, query.Page{Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(games))
+
+		games, err = repo.ReadGames(ctx, query.GameQuery{}, query.Page{Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(games))
+
+		games, err = repo.ReadGames(ctx, query.GameQuery{}, query.Page{Limit: 10, Offset: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(games))
+
+		games, err = repo.ReadGames(ctx, query.GameQuery{Options: []query.GameOption{{
+			Key:   "country",
+			Op:    query.OpEqual,
+			Value: "USA",
+		}}}, query.Page{Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(games))
+
+		games, err = repo.ReadGames(ctx, query.GameQuery{Options: []query.GameOption{{
+			Key:   "state",
+			Op:    query.OpEqual,
+			Value: "Texas",
+		}}}, query.Page{Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(games))
+
+		games, err = repo.ReadGames(ctx, query.GameQuery{Options: []query.GameOption{{
+			Key:   "city",
+			Op:    query.OpEqual,
+			Value: "Austin",
+		}}}, query.Page{Limit: 10})
+		assert.NoError(t, err)
+		require.Equal(t, 1, len(games))
+		assert.Equal(t, "Austin", games[0].City)
+		assert.Equal(t, 3, games[0].Levels)
+
+		games, err = repo.ReadGames(ctx, query.GameQuery{Options: []query.GameOption{{
+			Key:   "city",
+			Op:    query.OpIn,
+			Value: []interface{}{"Austin", "Chicago"},
+		}}}, query.Page{Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(games))
+
+		q, err := query.ParseGameQuery(map[string][]string{"city": {"prefix:Da"}})
+		require.NoError(t, err)
+		games, err = repo.ReadGames(ctx, q, query.Page{Limit: 10})
+		assert.NoError(t, err)
+		require.Equal(t, 1, len(games))
+		assert.Equal(t, "Dallas", games[0].City)
+
+		games, err = repo.ReadGames(ctx, query.GameQuery{Sort: query.GameSort{Key: "city", Descending: true}}, query.Page{Limit: 10})
+		assert.NoError(t, err)
+		require.Equal(t, 3, len(games))
+		assert.Equal(t, []string{"Dallas", "Chicago", "Austin"}, []string{games[0].City, games[1].City, games[2].City})
+	})
+}
+
+func TestProjectionRepository_ReadGame(t *testing.T) {
+	testProjectionRepositories(t, func(t *testing.T, repo projectionRepository) {
+		ctx := context.Background()
+		projector := query.NewProjector(repo)
+		handler := query.NewReadGameHandler(repo)
+
+		userID, err := uuid.NewRandom()
+		require.NoError(t, err)
+		u, err := game.NewNamedUser(userID.String(), "15734497033", "Gopher")
+		require.NoError(t, err)
+
+		g := newTestProjectionGame(t, u, "Austin", "Texas")
+		require.NoError(t, projector.Publish(ctx, game.NewGameCreatedEvent(g)))
+
+		detail, err := handler.Handle(ctx, u, g.UUID())
+		require.NoError(t, err)
+		assert.Equal(t, g.Title(), detail.Title)
+		assert.Equal(t, "Gopher", detail.CreatorName)
+		assert.Equal(t, "urban", detail.Kind)
+		assert.Equal(t, 3, detail.Levels)
+		assert.Equal(t, float64(3*query.EstimatedLevelSeconds), detail.EstimatedDurationSeconds)
+
+		_, err = handler.Handle(ctx, u, "does-not-exist")
+		assert.Equal(t, query.ErrorGameNotFound, err)
+
+		details, err := handler.HandleBatch(ctx, u, []string{g.UUID(), "does-not-exist", g.UUID()})
+		require.NoError(t, err)
+		require.Equal(t, 3, len(details))
+		assert.Equal(t, detail, details[0])
+		assert.Nil(t, details[1])
+		assert.Equal(t, detail, details[2])
+	})
+}
+
+func TestProjectionRepository_ReadGamesWithCursor(t *testing.T) {
+	testProjectionRepositories(t, func(t *testing.T, repo projectionRepository) {
+		ctx := context.Background()
+		projector := query.NewProjector(repo)
+		handler := query.NewReadGamesHandler(repo, query.NewCursorSigner([]byte("secret")))
+		u := newTestProjectionUser(t)
+
+		cities := []string{"Austin", "Austin", "Chicago", "Dallas", "El Paso"}
+		for _, city := range cities {
+			g := newTestProjectionGame(t, u, city, "Texas")
+			require.NoError(t, projector.Publish(ctx, game.NewGameCreatedEvent(g)))
+		}
+
+		for _, q := range []query.GameQuery{
+			{},
+			{Sort: query.GameSort{Key: "city"}},
+			{Sort: query.GameSort{Key: "city", Descending: true}},
+		} {
+			all, err := handler.Handle(ctx, u, q, query.PageParams{Limit: len(cities)})
+			require.NoError(t, err)
+			require.Equal(t, len(cities), len(all.Games))
+
+			// Walking the pages gives every game once in the same order as reading them all at once.
+			var paged []*query.Game
+			params := query.PageParams{Limit: 2}
+			for {
+				page, err := handler.Handle(ctx, u, q, params)
+				require.NoError(t, err)
+
+				paged = append(paged, page.Games...)
+
+				if page.NextCursor == "" {
+					break
+				}
+				params.Cursor = page.NextCursor
+			}
+
+			assert.Equal(t, all.Games, paged)
+		}
+
+		page, err := handler.Handle(ctx, u, query.GameQuery{}, query.PageParams{Limit: 2})
+		require.NoError(t, err)
+
+		// Cursors can only be used with the order they came from.
+		_, err = handler.Handle(ctx, u, query.GameQuery{Sort: query.GameSort{Key: "city"}}, query.PageParams{Limit: 2, Cursor: page.NextCursor})
+		assert.Equal(t, query.ErrorInvalidCursor, err)
+	})
+}
+
+func TestProjectionRepository_ReadGamesNear(t *testing.T) {
+	testProjectionRepositories(t, func(t *testing.T, repo projectionRepository) {
+		ctx := context.Background()
+		projector := query.NewProjector(repo)
+		u := newTestProjectionUser(t)
+
+		// Austin's capitol, a spot about 2km away, Dallas and a game without a location.
+		for _, start := range []struct {
+			city, state string
+			lat, lng    float64
+		}{
+			{"Austin", "Texas", 30.2747, -97.7404},
+			{"Austin", "Texas", 30.2849, -97.7341},
+			{"Dallas", "Texas", 32.7767, -96.7970},
+		} {
+			g := newTestProjectionGame(t, u, start.city, start.state)
+			location, err := game.NewLocation(start.lat, start.lng)
+			require.NoError(t, err)
+			g.SetStartingLocation(location)
+			require.NoError(t, projector.Publish(ctx, game.NewGameCreatedEvent(g)))
+		}
+		require.NoError(t, projector.Publish(ctx, game.NewGameCreatedEvent(newTestProjectionGame(t, u, "Austin", "Texas"))))
+
+		near := query.Near{Latitude: 30.2672, Longitude: -97.7431, RadiusKm: 10}
+
+		games, err := repo.ReadGamesNear(ctx, near, query.GameQuery{}, query.Page{Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(games))
+		assert.Equal(t, 30.2747, games[0].Location.Latitude)
+		assert.True(t, *games[0].DistanceKm < *games[1].DistanceKm)
+
+		games, err = repo.ReadGamesNear(ctx, near, query.GameQuery{}, query.Page{Limit: 10, Offset: 1})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(games))
+		assert.Equal(t, 30.2849, games[0].Location.Latitude)
+
+		games, err = repo.ReadGamesNear(ctx, near, query.GameQuery{}, query.Page{Limit: 1})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(games))
+		assert.Equal(t, 30.2747, games[0].Location.Latitude)
+
+		after := &query.Cursor{Key: "distanceKm", Value: *games[0].DistanceKm, UUID: games[0].UUID}
+		games, err = repo.ReadGamesNear(ctx, near, query.GameQuery{}, query.Page{Limit: 1, After: after})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(games))
+		assert.Equal(t, 30.2849, games[0].Location.Latitude)
+
+		near.RadiusKm = 300
+		games, err = repo.ReadGamesNear(ctx, near, query.GameQuery{}, query.Page{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 3, len(games))
+
+		games, err = repo.ReadGamesNear(ctx, near, query.GameQuery{Options: []query.GameOption{{
+			Key:   "city",
+			Op:    query.OpEqual,
+			Value: "Dallas",
+		}}}, query.Page{Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(games))
+		assert.Equal(t, "Dallas", games[0].City)
+	})
+}
+
+func TestProjectionRepository_PlayerAndState(t *testing.T) {
+	testProjectionRepositories(t, func(t *testing.T, repo projectionRepository) {
+		ctx := context.Background()
+		projector := query.NewProjector(repo)
+		u := newTestProjectionUser(t)
+
+		g := newTestProjectionGame(t, u, "Austin", "Texas")
+		require.NoError(t, projector.Publish(ctx, game.NewGameCreatedEvent(g)))
+
+		p, err := game.NewPlayerFromUser(u)
+		require.NoError(t, err)
+
+		s, _, err := game.Start(g, p)
+		require.NoError(t, err)
+		require.NoError(t, projector.Publish(ctx, game.NewGameStartedEvent(s)))
+
+		queryState, err := repo.ReadState(ctx, s.UUID())
+		require.NoError(t, err)
+		assert.Equal(t, s.CurrentResponse(), queryState.CurrentResponse)
+		assert.Equal(t, p.UUID(), queryState.PlayerUUID)
+		assert.Equal(t, &query.Level{Number: 0, Title: g.Levels()[0].Title(), Description: g.Levels()[0].Description()}, queryState.CurrentLevel)
+
+		_, err = s.Update(g, g.Levels()[0].Answers()[0], p)
+		require.NoError(t, err)
+		require.NoError(t, projector.Publish(ctx, game.NewStateUpdatedEvent(s)))
+
+		queryState, err = repo.ReadState(ctx, s.UUID())
+		require.NoError(t, err)
+		assert.Equal(t, &query.Level{Number: 1, Title: g.Levels()[1].Title(), Description: g.Levels()[1].Description()}, queryState.CurrentLevel)
+
+		for _, l := range g.Levels()[1:] {
+			_, err := s.Update(g, l.Answers()[0], p)
+			require.NoError(t, err)
+		}
+		require.True(t, s.Completed())
+		require.NoError(t, projector.Publish(ctx, game.NewStateUpdatedEvent(s), game.NewGameFinishedEvent(g, s)))
+
+		queryState, err = repo.ReadState(ctx, s.UUID())
+		require.NoError(t, err)
+		assert.True(t, queryState.Completed)
+		assert.Equal(t, game.EndResponse, queryState.CurrentResponse.Kind)
+		assert.Nil(t, queryState.CurrentLevel)
+
+		queryPlayer, err := repo.ReadPlayer(ctx, p.UUID())
+		require.NoError(t, err)
+		assert.Equal(t, p.GamesStarted(), queryPlayer.GamesStarted)
+		assert.Equal(t, p.GamesFinished(), queryPlayer.GamesFinished)
+		assert.Equal(t, p.TotalPoints(), queryPlayer.TotalPoints)
+		require.Equal(t, 1, len(queryPlayer.History))
+		assert.Equal(t, g.Title(), queryPlayer.History[0].GameTitle)
+		assert.True(t, queryPlayer.History[0].Completed)
+
+		queryGame, err := repo.GetGameProjection(ctx, g.UUID())
+		require.NoError(t, err)
+		assert.Equal(t, 1, queryGame.PlayCount)
+		assert.Equal(t, 1, queryGame.CompletionCount)
+
+		// Only the player, or someone who can manage players, can read the player and their states.
+		readPlayer := query.NewReadPlayerHandler(repo)
+		readState := query.NewReadStateHandler(repo)
+
+		_, err = readPlayer.Handle(ctx, u, p.UUID())
+		assert.NoError(t, err)
+
+		_, err = readState.Handle(ctx, u, s.UUID())
+		assert.NoError(t, err)
+
+		other := newTestProjectionUser(t)
+
+		_, err = readPlayer.Handle(ctx, other, p.UUID())
+		assert.Equal(t, query.ErrorNotPlayer, err)
+
+		// The state of another player looks the same as one that doesn't exist.
+		_, err = readState.Handle(ctx, other, s.UUID())
+		assert.Equal(t, query.ErrorStateNotFound, err)
+
+		states, err := readState.HandleBatch(ctx, u, []string{"does-not-exist", s.UUID()})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(states))
+		assert.Nil(t, states[0])
+		assert.Equal(t, s.UUID(), states[1].UUID)
+
+		states, err = readState.HandleBatch(ctx, other, []string{s.UUID()})
+		require.NoError(t, err)
+		assert.Equal(t, []*query.State{nil}, states)
+
+		for _, role := range []game.Role{game.RoleOrganizer, game.RoleAdmin} {
+			manager, err := game.NewUserWithRoles(other.UUID(), other.Number(), "", role)
+			require.NoError(t, err)
+
+			_, err = readPlayer.Handle(ctx, manager, p.UUID())
+			assert.NoError(t, err)
+
+			_, err = readState.Handle(ctx, manager, s.UUID())
+			assert.NoError(t, err)
+		}
+
+		require.NoError(t, projector.Reset(ctx))
+
+		_, err = repo.ReadPlayer(ctx, p.UUID())
+		assert.True(t, errors.Is(err, query.ErrorProjectionNotFound))
+	})
+}
+
+func TestProjectionRepository_SkippedGame(t *testing.T) {
+	testProjectionRepositories(t, func(t *testing.T, repo projectionRepository) {
+		ctx := context.Background()
+		projector := query.NewProjector(repo)
+		u := newTestProjectionUser(t)
+
+		g := newTestProjectionGame(t, u, "Austin", "Texas")
+		require.NoError(t, projector.Publish(ctx, game.NewGameCreatedEvent(g)))
+		require.NotZero(t, g.Value())
+
+		p, err := game.NewPlayerFromUser(u)
+		require.NoError(t, err)
+
+		s, _, err := game.Start(g, p)
+		require.NoError(t, err)
+		require.NoError(t, projector.Publish(ctx, game.NewGameStartedEvent(s)))
+
+		for _, l := range g.Levels()[:len(g.Levels())-1] {
+			_, err := s.Update(g, l.Answers()[0], p)
+			require.NoError(t, err)
+		}
+
+		// Skipping the last level finishes the game without its points.
+		_, err = s.Skip(g, p)
+		require.NoError(t, err)
+		require.True(t, s.Completed())
+		require.NoError(t, projector.Publish(ctx, game.NewStateUpdatedEvent(s), game.NewGameFinishedEvent(g, s)))
+
+		queryPlayer, err := repo.ReadPlayer(ctx, p.UUID())
+		require.NoError(t, err)
+		assert.Equal(t, 1, queryPlayer.GamesFinished)
+		assert.Equal(t, 0, queryPlayer.TotalPoints)
+		assert.Equal(t, p.TotalPoints(), queryPlayer.TotalPoints)
+		require.Equal(t, 1, len(queryPlayer.History))
+		assert.True(t, queryPlayer.History[0].Completed)
+		assert.Equal(t, 0, queryPlayer.History[0].Points)
+	})
+}
+
+func TestProjectionRepository_QuitGame(t *testing.T) {
+	testProjectionRepositories(t, func(t *testing.T, repo projectionRepository) {
+		ctx := context.Background()
+		projector := query.NewProjector(repo)
+		u := newTestProjectionUser(t)
+
+		g := newTestProjectionGame(t, u, "Austin", "Texas")
+		require.NoError(t, projector.Publish(ctx, game.NewGameCreatedEvent(g)))
+
+		p, err := game.NewPlayerFromUser(u)
+		require.NoError(t, err)
+
+		s, _, err := game.Start(g, p)
+		require.NoError(t, err)
+		require.NoError(t, projector.Publish(ctx, game.NewGameStartedEvent(s)))
+
+		_, err = s.Quit(g, p)
+		require.NoError(t, err)
+		require.NoError(t, projector.Publish(ctx, game.NewGameQuitEvent(s)))
+
+		queryState, err := repo.ReadState(ctx, s.UUID())
+		require.NoError(t, err)
+		assert.True(t, queryState.Quit)
+		assert.False(t, queryState.Completed)
+
+		queryPlayer, err := repo.ReadPlayer(ctx, p.UUID())
+		require.NoError(t, err)
+		require.Equal(t, 1, len(queryPlayer.History))
+		assert.True(t, queryPlayer.History[0].Quit)
+		assert.False(t, queryPlayer.History[0].Completed)
+	})
+}
+
+func newTestProjectionUser(t *testing.T) game.User {
+	userID, err := uuid.NewRandom()
+	require.NoError(t, err)
+
+	u, err := game.NewUser(userID.String(), "15734497033")
+	require.NoError(t, err)
+
+	return u
+}
+
+func newTestProjectionGame(t *testing.T, u game.User, city, state string) *game.Game {
+	g, err := game.NewUrbanGame(
+		u,
+		"An Awesome Game",
+		"This is an awesome game",
+		"The end!",
+		city,
+		state,
+		"USA",
+		game.NewLevelAdder(
+			"Level One",
+			"This is Level One",
+			[]string{"Who is the best?", "Level One is the best", "Say I am the best"},
+			[]string{"Level One is the best"}),
+		game.NewLevelAdder(
+			"Level Two",
+			"This is Level Two",
+			[]string{"Who is the best?", "Level Two is the best", "Say I am the best"},
+			[]string{"Level Two is the best"}),
+		game.NewLevelAdder(
+			"Level Three",
+			"This is Level Three",
+			[]string{"Who is the best?", "Level Three is the best", "Say I am the best"},
+			[]string{"Level Three is the best"}),
+	)
+	require.NoError(t, err)
+
+	return g
+}
+
+func TestProjectionRepository_ConcurrentProjectors(t *testing.T) {
+	testProjectionRepositories(t, func(t *testing.T, repo projectionRepository) {
+		ctx := context.Background()
+		u := newTestProjectionUser(t)
+
+		g := newTestProjectionGame(t, u, "Austin", "Texas")
+		require.NoError(t, query.NewProjector(repo).Publish(ctx, game.NewGameCreatedEvent(g)))
+
+		// Projectors in different instances share the store, so none of their updates may be lost.
+		const players = 10
+		errs := make(chan error, players)
+
+		for i := 0; i < players; i++ {
+			p, err := game.NewPlayerFromUser(newTestProjectionUser(t))
+			require.NoError(t, err)
+
+			s, _, err := game.Start(g, p)
+			require.NoError(t, err)
+
+			go func() {
+				errs <- query.NewProjector(repo).Publish(ctx, game.NewGameStartedEvent(s))
+			}()
+		}
+
+		for i := 0; i < players; i++ {
+			require.NoError(t, <-errs)
+		}
+
+		queryGame, err := repo.ReadGame(ctx, g.UUID())
+		require.NoError(t, err)
+		assert.Equal(t, players, queryGame.PlayCount)
+
+		analytics, err := repo.ReadGameAnalytics(ctx, g.UUID())
+		require.NoError(t, err)
+		assert.Equal(t, players, analytics.Levels[0].PlayersReached)
+
+		lag, err := repo.ReadProjectionLag(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, players+1, lag.Events)
+
+		require.NoError(t, repo.ClearProjections(ctx))
+
+		lag, err = repo.ReadProjectionLag(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, query.ProjectionLag{}, *lag)
+	})
+}
+
+func TestProjectionRepository_GameAnalytics(t *testing.T) {
+	testProjectionRepositories(t, func(t *testing.T, repo projectionRepository) {
+		ctx := context.Background()
+		projector := query.NewProjector(repo)
+		handler := query.NewReadGameAnalyticsHandler(repo)
+		creator := newTestProjectionUser(t)
+
+		g := newTestProjectionGame(t, creator, "Austin", "Texas")
+		require.NoError(t, projector.Publish(ctx, game.NewGameCreatedEvent(g)))
+
+		// Two players start, one gets stuck on the first level and the other finishes it.
+		for _, inputs := range [][]string{
+			{"wrong", "wrong", "also wrong"},
+			{"wrong", g.Levels()[0].Answers()[0]},
+		} {
+			p, err := game.NewPlayerFromUser(newTestProjectionUser(t))
+			require.NoError(t, err)
+
+			s, _, err := game.Start(g, p)
+			require.NoError(t, err)
+			require.NoError(t, projector.Publish(ctx, game.NewGameStartedEvent(s)))
+
+			for _, input := range inputs {
+				before := *s
+				_, err := s.Update(g, input, p)
+				require.NoError(t, err)
+
+				a, err := game.NewAttempt(before, s, input)
+				require.NoError(t, err)
+				require.NoError(t, projector.Publish(ctx, game.NewAttemptRecordedEvent(a)))
+			}
+		}
+
+		// A third player skips the first level without answering it.
+		p, err := game.NewPlayerFromUser(newTestProjectionUser(t))
+		require.NoError(t, err)
+
+		s, _, err := game.Start(g, p)
+		require.NoError(t, err)
+		require.NoError(t, projector.Publish(ctx, game.NewGameStartedEvent(s)))
+
+		_, err = s.Skip(g, p)
+		require.NoError(t, err)
+		require.NoError(t, projector.Publish(ctx, game.NewLevelSkippedEvent(s, 0)))
+
+		analytics, err := handler.Handle(ctx, creator, g.UUID())
+		require.NoError(t, err)
+		require.Equal(t, 3, len(analytics.Levels))
+
+		l := analytics.Levels[0]
+		assert.Equal(t, 3, l.PlayersReached)
+		assert.Equal(t, 1, l.PlayersCompleted)
+		assert.Equal(t, 1, l.PlayersSkipped)
+		assert.Equal(t, 1, l.DropOff)
+		assert.Equal(t, 5, l.Attempts)
+		assert.Equal(t, 4, l.CluesShown)
+		assert.Equal(t, []query.WrongAnswer{{Answer: "wrong", Count: 3}, {Answer: "also wrong", Count: 1}}, l.MostCommonWrongAnswers)
+		assert.Equal(t, 2, analytics.Levels[1].PlayersReached)
+		assert.Equal(t, 2, analytics.Levels[1].DropOff)
+
+		_, err = handler.Handle(ctx, newTestProjectionUser(t), g.UUID())
+		assert.Equal(t, query.ErrorNotGameCreator, err)
+
+		all, err := handler.HandleCreator(ctx, creator)
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(all))
+	})
+}
+
+func TestProjectionRepository_Reviews(t *testing.T) {
+	testProjectionRepositories(t, func(t *testing.T, repo projectionRepository) {
+		ctx := context.Background()
+		projector := query.NewProjector(repo)
+		creator := newTestProjectionUser(t)
+
+		liked := newTestProjectionGame(t, creator, "Austin", "Texas")
+		require.NoError(t, projector.Publish(ctx, game.NewGameCreatedEvent(liked)))
+
+		disliked := newTestProjectionGame(t, creator, "Austin", "Texas")
+		require.NoError(t, projector.Publish(ctx, game.NewGameCreatedEvent(disliked)))
+
+		at := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+		for i, e := range []game.GameRated{
+			{GameUUID: liked.UUID(), PlayerUUID: "one", PlayerName: "One", Stars: 3, Review: "Fine", RatingCount: 1, RatingTotal: 3, RatingRevision: 1},
+			{GameUUID: liked.UUID(), PlayerUUID: "two", PlayerName: "Two", Stars: 5, RatingCount: 2, RatingTotal: 9, RatingRevision: 3},
+			{GameUUID: liked.UUID(), PlayerUUID: "one", PlayerName: "One", Stars: 4, Review: "Better", PreviousStars: 3, RatingCount: 1, RatingTotal: 4, RatingRevision: 2},
+			{GameUUID: disliked.UUID(), PlayerUUID: "two", PlayerName: "Two", Stars: 1, RatingCount: 1, RatingTotal: 1, RatingRevision: 1},
+		} {
+			e.At = at.Add(time.Duration(i) * time.Minute)
+			require.NoError(t, projector.Publish(ctx, e))
+		}
+
+		// The second rating of the liked game was saved before the third but projected after it, so the
+		// summary projected last is the latest.
+		g, err := repo.ReadGame(ctx, liked.UUID())
+		require.NoError(t, err)
+		assert.Equal(t, 2, g.RatingCount)
+		assert.Equal(t, 4.5, g.AverageRating)
+
+		reviews, err := repo.ReadGameReviews(ctx, liked.UUID(), query.Page{Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(reviews))
+		assert.Equal(t, "One", reviews[0].PlayerName)
+		assert.Equal(t, "Better", reviews[0].Review)
+		assert.Equal(t, 4, reviews[0].Stars)
+		assert.Equal(t, "Two", reviews[1].PlayerName)
+
+		after := &query.Cursor{Key: "ratedAt", Descending: true, Value: reviews[0].RatedAt, UUID: reviews[0].ID}
+		reviews, err = repo.ReadGameReviews(ctx, liked.UUID(), query.Page{Limit: 10, After: after})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(reviews))
+		assert.Equal(t, "Two", reviews[0].PlayerName)
+
+		games, err := repo.ReadGames(ctx, query.GameQuery{
+			Sort: query.GameSort{Key: "averageRating", Descending: true},
+		}, query.Page{Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(games))
+		assert.Equal(t, liked.UUID(), games[0].UUID)
+		assert.Equal(t, disliked.UUID(), games[1].UUID)
+	})
+}
+
+func TestProjectionRepository_ModerationQueue(t *testing.T) {
+	testProjectionRepositories(t, func(t *testing.T, repo projectionRepository) {
+		ctx := context.Background()
+		projector := query.NewProjector(repo)
+		creator := newTestProjectionUser(t)
+
+		first := newTestProjectionGame(t, creator, "Austin", "Texas")
+		require.NoError(t, projector.Publish(ctx, game.NewGameCreatedEvent(first)))
+
+		second := newTestProjectionGame(t, creator, "Austin", "Texas")
+		require.NoError(t, projector.Publish(ctx, game.NewGameCreatedEvent(second)))
+
+		at := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+		for i, e := range []game.GameReported{
+			{GameUUID: second.UUID(), Reason: game.ReportReasonSpam},
+			{GameUUID: first.UUID(), Reason: game.ReportReasonOffensive, Details: "Rude clue"},
+			{GameUUID: second.UUID(), Reason: game.ReportReasonSpam},
+		} {
+			e.At = at.Add(time.Duration(i) * time.Minute)
+			require.NoError(t, projector.Publish(ctx, e))
+		}
+
+		cases, err := repo.ReadModerationQueue(ctx, query.Page{Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(cases))
+		assert.Equal(t, second.UUID(), cases[0].GameUUID)
+		assert.Equal(t, 2, cases[0].Reports)
+		assert.Equal(t, map[string]int{"spam": 2}, cases[0].Reasons)
+		assert.Equal(t, at.Add(2*time.Minute), cases[0].LastReportedAt)
+		assert.Equal(t, first.UUID(), cases[1].GameUUID)
+		assert.Equal(t, []string{"Rude clue"}, cases[1].Details)
+
+		after := &query.Cursor{Key: "firstReportedAt", Value: cases[0].FirstReportedAt, UUID: cases[0].GameUUID}
+		cases, err = repo.ReadModerationQueue(ctx, query.Page{Limit: 10, After: after})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(cases))
+		assert.Equal(t, first.UUID(), cases[0].GameUUID)
+
+		second.Unpublish()
+		require.NoError(t, projector.Publish(ctx, game.NewGameModeratedEvent(second, nil)))
+
+		cases, err = repo.ReadModerationQueue(ctx, query.Page{Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(cases))
+		assert.Equal(t, first.UUID(), cases[0].GameUUID)
+
+		g, err := repo.ReadGame(ctx, second.UUID())
+		require.NoError(t, err)
+		assert.Equal(t, string(game.StatusUnpublished), g.Status)
+	})
+}
+
+func TestFirestoreProjectionRepository_WatchGameProjections(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, cleanup := emulators.NewFirestoreClient(ctx)
+	defer func() {
+		_ = client.Close()
+		cleanup()
+	}()
+
+	repo, err := NewFirestoreProjectionRepository(client)
+	require.NoError(t, err)
+	require.NoError(t, repo.ClearProjections(ctx))
+
+	u := newTestProjectionUser(t)
+	g := newTestProjectionGame(t, u, "Austin", "Texas")
+
+	// Another instance projects the game after this one started watching.
+	index := NewMemorySearchIndex()
+	synced := make(chan error, 1)
+
+	go func() {
+		synced <- query.SyncGameSearchIndex(ctx, repo, index)
+	}()
+
+	require.NoError(t, query.NewProjector(repo).Publish(ctx, game.NewGameCreatedEvent(g)))
+
+	assert.Eventually(t, func() bool {
+		games, err := index.SearchGames(ctx, query.GameSearch{Text: g.Title()}, query.Page{Limit: 10})
+		return err == nil && len(games) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-synced)
+}
@@ -0,0 +1,196 @@
+package adapters
+
+import (
+	"context"
+	"gopher-cache/internal/games/domain/game"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// DefaultBlockedWords is the profanity list used when a RuleModerator is not given one.
+var DefaultBlockedWords = []string{
+	"arsehole",
+	"asshole",
+	"bastard",
+	"bitch",
+	"bollocks",
+	"cock",
+	"cunt",
+	"dick",
+	"fag",
+	"faggot",
+	"fuck",
+	"fucker",
+	"fucking",
+	"motherfucker",
+	"nigger",
+	"piss",
+	"prick",
+	"pussy",
+	"retard",
+	"shit",
+	"slut",
+	"twat",
+	"wanker",
+	"whore",
+}
+
+var (
+	emailPattern  = regexp.MustCompile(`(?i)[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}`)
+	urlPattern    = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.(?:com|net|org|io|co|info|biz|ly|me|app|xyz)\b(?:/\S*)?`)
+	ssnPattern    = regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`)
+	digitsPattern = regexp.MustCompile(`\+?\(?\d(?:[ ().-]{0,2}\d)+`)
+)
+
+// leetReplacer undoes common letter substitutions used to get around profanity filters.
+var leetReplacer = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s")
+
+// RuleModerator moderates content in process with a profanity list and patterns for links and
+// personal information. It is cheap enough to run on every game but is easily fooled, so it is meant
+// as a first line of defence in front of players reporting games.
+type RuleModerator struct {
+	blocked map[string]bool
+}
+
+// NewRuleModerator creates a moderator which blocks the given words. DefaultBlockedWords is used if no
+// words are given.
+func NewRuleModerator(blockedWords ...string) RuleModerator {
+	if len(blockedWords) == 0 {
+		blockedWords = DefaultBlockedWords
+	}
+
+	blocked := make(map[string]bool, len(blockedWords))
+	for _, word := range blockedWords {
+		blocked[strings.ToLower(word)] = true
+	}
+
+	return RuleModerator{blocked: blocked}
+}
+
+// Moderate flags profanity, links, email addresses, phone numbers, payment card numbers and social
+// security numbers in the content.
+func (m RuleModerator) Moderate(_ context.Context, content []game.Content) ([]game.ModerationFlag, error) {
+	var flags []game.ModerationFlag
+
+	for _, c := range content {
+		for _, rule := range []struct {
+			name string
+			find func(text string) [][]int
+		}{
+			// Personal information is matched first and removed from the text so an email address
+			// isn't also flagged as a link and a card number isn't also flagged as a phone number.
+			{"email-address", findAll(emailPattern)},
+			{"national-id", findAll(ssnPattern)},
+			{"payment-card", findPaymentCards},
+			{"phone-number", findPhoneNumbers},
+			{"url", findAll(urlPattern)},
+			{"profanity", m.findProfanity},
+		} {
+			text := c.Text
+			for _, match := range rule.find(text) {
+				flags = append(flags, game.ModerationFlag{
+					Field: c.Field,
+					Rule:  rule.name,
+					Match: text[match[0]:match[1]],
+				})
+
+				c.Text = c.Text[:match[0]] + strings.Repeat(" ", match[1]-match[0]) + c.Text[match[1]:]
+			}
+		}
+	}
+
+	return flags, nil
+}
+
+func findAll(pattern *regexp.Regexp) func(text string) [][]int {
+	return func(text string) [][]int {
+		return pattern.FindAllStringIndex(text, -1)
+	}
+}
+
+// findProfanity finds the blocked words in text.
+func (m RuleModerator) findProfanity(text string) [][]int {
+	var matches [][]int
+
+	start := -1
+	for i, r := range text + " " {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '@' || r == '$' {
+			if start < 0 {
+				start = i
+			}
+
+			continue
+		}
+
+		if start >= 0 {
+			word := strings.ToLower(text[start:i])
+			if m.blocked[word] || m.blocked[leetReplacer.Replace(word)] {
+				matches = append(matches, []int{start, i})
+			}
+
+			start = -1
+		}
+	}
+
+	return matches
+}
+
+// findPaymentCards finds runs of 13 to 19 digits which pass the Luhn check.
+func findPaymentCards(text string) [][]int {
+	var matches [][]int
+
+	for _, match := range digitsPattern.FindAllStringIndex(text, -1) {
+		digits := onlyDigits(text[match[0]:match[1]])
+		if len(digits) >= 13 && len(digits) <= 19 && luhn(digits) {
+			matches = append(matches, match)
+		}
+	}
+
+	return matches
+}
+
+// findPhoneNumbers finds runs of 7 to 15 digits, the shortest local number to the longest E.164 number.
+func findPhoneNumbers(text string) [][]int {
+	var matches [][]int
+
+	for _, match := range digitsPattern.FindAllStringIndex(text, -1) {
+		digits := onlyDigits(text[match[0]:match[1]])
+		if len(digits) >= 7 && len(digits) <= 15 {
+			matches = append(matches, match)
+		}
+	}
+
+	return matches
+}
+
+func onlyDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+
+		return -1
+	}, s)
+}
+
+// luhn reports whether the digits pass the Luhn checksum used by payment cards.
+func luhn(digits string) bool {
+	sum := 0
+	double := false
+
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
@@ -0,0 +1,80 @@
+package adapters
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/games/domain/game"
+	"testing"
+)
+
+func TestRuleModerator_Moderate(t *testing.T) {
+	m := NewRuleModerator()
+
+	testCases := []struct {
+		Name     string
+		Text     string
+		Expected []game.ModerationFlag
+	}{
+		{
+			Name: "clean",
+			Text: "Find the statue of Stevie Ray Vaughan by the lake, built in 1993.",
+		},
+		{
+			Name:     "profanity",
+			Text:     "What the Fuck is this?",
+			Expected: []game.ModerationFlag{{Field: "clue", Rule: "profanity", Match: "Fuck"}},
+		},
+		{
+			Name:     "leet profanity",
+			Text:     "sh1t happens",
+			Expected: []game.ModerationFlag{{Field: "clue", Rule: "profanity", Match: "sh1t"}},
+		},
+		{
+			Name: "blocked word inside another word",
+			Text: "Scunthorpe is a town in England",
+		},
+		{
+			Name:     "url",
+			Text:     "The answer is at https://example.org/answers",
+			Expected: []game.ModerationFlag{{Field: "clue", Rule: "url", Match: "https://example.org/answers"}},
+		},
+		{
+			Name:     "bare domain",
+			Text:     "Visit cheap-prizes.com now",
+			Expected: []game.ModerationFlag{{Field: "clue", Rule: "url", Match: "cheap-prizes.com"}},
+		},
+		{
+			Name:     "email address",
+			Text:     "Email gopher@example.com for hints",
+			Expected: []game.ModerationFlag{{Field: "clue", Rule: "email-address", Match: "gopher@example.com"}},
+		},
+		{
+			Name:     "phone number",
+			Text:     "Call (512) 555-0100 for a hint",
+			Expected: []game.ModerationFlag{{Field: "clue", Rule: "phone-number", Match: "(512) 555-0100"}},
+		},
+		{
+			Name:     "payment card",
+			Text:     "Pay with 4111 1111 1111 1111",
+			Expected: []game.ModerationFlag{{Field: "clue", Rule: "payment-card", Match: "4111 1111 1111 1111"}},
+		},
+		{
+			Name:     "social security number",
+			Text:     "My SSN is 078-05-1120",
+			Expected: []game.ModerationFlag{{Field: "clue", Rule: "national-id", Match: "078-05-1120"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			flags, err := m.Moderate(context.Background(), []game.Content{{Field: "clue", Text: tc.Text}})
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, flags)
+		})
+	}
+
+	flags, err := NewRuleModerator("gopher").Moderate(context.Background(), []game.Content{{Field: "title", Text: "Gopher shit"}})
+	require.NoError(t, err)
+	assert.Equal(t, []game.ModerationFlag{{Field: "title", Rule: "profanity", Match: "Gopher"}}, flags)
+}
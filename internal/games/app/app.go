@@ -17,11 +17,38 @@ type Commands struct {
 	CreateGame      command.CreateGameHandler
 	CreateGameState command.CreateGameStateHandler
 	UpdateGameState command.UpdateGameStateHandler
+	RateGame        command.RateGameHandler
+	ReportGame      command.ReportGameHandler
+	ModerateGame    command.ModerateGameHandler
+
+	// HandleTextMessage handles what players text, which may be a text command such as HINT or an answer.
+	HandleTextMessage command.TextMessageHandler
+	RequestClue       command.RequestClueHandler
+
+	RequestNumberVerification command.RequestNumberVerificationHandler
+	VerifyNumber              command.VerifyNumberHandler
+	SetPlayerLocale           command.SetPlayerLocaleHandler
+	MigratePlayerNumbers      command.MigratePlayerNumbersHandler
+
+	RebuildProjections command.RebuildProjectionsHandler
+
+	CreateAPIKey command.CreateAPIKeyHandler
+	RevokeAPIKey command.RevokeAPIKeyHandler
 }
 
 // Queries for the games application.
 type Queries struct {
-	GetGames  query.ReadGamesHandler
-	GetPlayer query.ReadPlayerHandler
-	GetState  query.ReadStateHandler
+	GetGame     query.ReadGameHandler
+	GetGames    query.ReadGamesHandler
+	SearchGames query.SearchGamesHandler
+	GetPlayer   query.ReadPlayerHandler
+	GetState    query.ReadStateHandler
+
+	GetProjectionLag query.ReadProjectionLagHandler
+	GetGameAnalytics query.ReadGameAnalyticsHandler
+	GetGameReviews   query.ReadGameReviewsHandler
+
+	GetModerationQueue query.ReadModerationQueueHandler
+
+	GetAPIKeys query.ReadAPIKeysHandler
 }
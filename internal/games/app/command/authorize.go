@@ -0,0 +1,26 @@
+package command
+
+import (
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/games/domain/game"
+)
+
+var ErrorNotPlayer = errors.NewAuthorizationError("only the player may update their game state", "not-player")
+
+// authorize returns an authorization error if none of the user's roles grants the permission p.
+func authorize(u game.User, p game.Permission) error {
+	if !u.Can(p) {
+		return errors.NewAuthorizationError("user doesn't have the "+string(p)+" permission", "missing-permission-"+string(p))
+	}
+
+	return nil
+}
+
+// authorizePlayer returns ErrorNotPlayer unless the user is the player p or can manage players.
+func authorizePlayer(u game.User, p *game.Player) error {
+	if u.UUID() != p.UUID() && !u.Can(game.PermissionManagePlayers) {
+		return ErrorNotPlayer
+	}
+
+	return nil
+}
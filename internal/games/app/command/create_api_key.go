@@ -0,0 +1,101 @@
+package command
+
+import (
+	"context"
+	"gopher-cache/internal/common/auth"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+	"time"
+)
+
+// CreateAPIKey represents the command input for creating an API key for a machine client.
+// All fields are required unless specified otherwise.
+type CreateAPIKey struct {
+	Admin game.User `json:"-"`
+	Name  string    `json:"name"`
+	// Scopes are the permissions given to clients using the key, e.g. play-games or manage-players.
+	Scopes []string `json:"scopes"`
+	// ExpiresAt is optional. Keys without it don't expire.
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// CreatedAPIKey is the key that was created. The key itself is only returned once since only its hash
+// is stored.
+type CreatedAPIKey struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// APIKeyRepository is the interface used for storing API keys.
+type APIKeyRepository interface {
+	// AddAPIKey returns auth.ErrorAPIKeyAlreadyExists if there is a key with the same id.
+	AddAPIKey(ctx context.Context, key auth.APIKey) error
+	// RevokeAPIKey returns auth.ErrorAPIKeyNotFound if there is no key with the id.
+	RevokeAPIKey(ctx context.Context, id string, at time.Time) error
+}
+
+// CreateAPIKeyHandler handles creating API keys.
+type CreateAPIKeyHandler struct {
+	repo APIKeyRepository
+}
+
+// NewCreateAPIKeyHandler creates a new handler.
+func NewCreateAPIKeyHandler(repo APIKeyRepository) CreateAPIKeyHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	return CreateAPIKeyHandler{repo: repo}
+}
+
+// Handle handles the use case of creating an API key. Only users who can manage API keys may create
+// them and keys can't be given the permission to manage keys themselves.
+func (h CreateAPIKeyHandler) Handle(ctx context.Context, cmd CreateAPIKey) (created *CreatedAPIKey, err error) {
+	defer func() {
+		logs.LogCommandExecution("CreateAPIKey", cmd, err)
+	}()
+
+	if err := authorize(cmd.Admin, game.PermissionManageAPIKeys); err != nil {
+		return nil, err
+	}
+
+	if cmd.Name == "" {
+		return nil, errors.NewIncorrectInputError("api key has no name", "invalid-api-key-name")
+	}
+
+	if len(cmd.Scopes) == 0 {
+		return nil, errors.NewIncorrectInputError("api key has no scopes", "invalid-api-key-scopes")
+	}
+
+	for _, s := range cmd.Scopes {
+		p, err := game.NewPermission(s)
+		if err != nil {
+			return nil, errors.NewIncorrectInputError(err.Error(), "invalid-api-key-scopes")
+		}
+
+		if p == game.PermissionManageAPIKeys {
+			return nil, errors.NewIncorrectInputError("api keys can't manage api keys", "invalid-api-key-scopes")
+		}
+	}
+
+	var expiresAt time.Time
+	if cmd.ExpiresAt != nil {
+		if !cmd.ExpiresAt.After(time.Now()) {
+			return nil, errors.NewIncorrectInputError("api key expiry must be in the future", "invalid-api-key-expiry")
+		}
+
+		expiresAt = cmd.ExpiresAt.UTC()
+	}
+
+	key, secret, err := auth.NewAPIKey(cmd.Name, cmd.Scopes, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.repo.AddAPIKey(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &CreatedAPIKey{ID: key.ID, Key: secret}, nil
+}
@@ -0,0 +1,89 @@
+package command
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/common/auth"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/games/adapters"
+	"gopher-cache/internal/games/domain/game"
+	"testing"
+	"time"
+)
+
+func TestCreateAPIKeyHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	repo := adapters.NewMemoryAPIKeyRepository()
+	createHandler := NewCreateAPIKeyHandler(repo)
+	revokeHandler := NewRevokeAPIKeyHandler(repo)
+
+	admin, err := game.NewUserWithRoles("admin", "15734497033", "", game.RoleAdmin)
+	require.NoError(t, err)
+
+	player, err := game.NewUser("player", "15734497034")
+	require.NoError(t, err)
+
+	created, err := createHandler.Handle(ctx, CreateAPIKey{
+		Admin:  admin,
+		Name:   "SMS gateway",
+		Scopes: []string{"play-games", "manage-players"},
+	})
+	require.NoError(t, err)
+
+	// Only the hash of the key is stored and it finds the key.
+	key, err := repo.GetAPIKeyByHash(ctx, auth.HashAPIKey(created.Key))
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, key.ID)
+	assert.NotContains(t, key.Hash, created.Key)
+	assert.Equal(t, []string{"play-games", "manage-players"}, key.Scopes)
+	assert.True(t, key.Active(time.Now()))
+
+	// Only admins may create or revoke keys.
+	_, err = createHandler.Handle(ctx, CreateAPIKey{Admin: player, Name: "Mine", Scopes: []string{"play-games"}})
+	require.Error(t, err)
+	assert.Equal(t, errors.ErrorTypeAuthorization, err.(errors.SlugError).ErrorType())
+
+	err = revokeHandler.Handle(ctx, RevokeAPIKey{Admin: player, ID: created.ID})
+	require.Error(t, err)
+	assert.Equal(t, errors.ErrorTypeAuthorization, err.(errors.SlugError).ErrorType())
+
+	require.NoError(t, revokeHandler.Handle(ctx, RevokeAPIKey{Admin: admin, ID: created.ID}))
+
+	key, err = repo.GetAPIKeyByHash(ctx, auth.HashAPIKey(created.Key))
+	require.NoError(t, err)
+	assert.False(t, key.Active(time.Now()))
+
+	err = revokeHandler.Handle(ctx, RevokeAPIKey{Admin: admin, ID: "missing"})
+	assert.Equal(t, ErrorAPIKeyNotFound, err)
+}
+
+func TestCreateAPIKeyHandler_HandleInvalid(t *testing.T) {
+	handler := NewCreateAPIKeyHandler(adapters.NewMemoryAPIKeyRepository())
+
+	admin, err := game.NewUserWithRoles("admin", "15734497033", "", game.RoleAdmin)
+	require.NoError(t, err)
+
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name string
+		cmd  CreateAPIKey
+	}{
+		{name: "no name", cmd: CreateAPIKey{Scopes: []string{"play-games"}}},
+		{name: "no scopes", cmd: CreateAPIKey{Name: "SMS gateway"}},
+		{name: "unknown scope", cmd: CreateAPIKey{Name: "SMS gateway", Scopes: []string{"play-games", "fly"}}},
+		{name: "manage keys scope", cmd: CreateAPIKey{Name: "SMS gateway", Scopes: []string{"manage-api-keys"}}},
+		{name: "expired", cmd: CreateAPIKey{Name: "SMS gateway", Scopes: []string{"play-games"}, ExpiresAt: &past}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cmd.Admin = admin
+
+			_, err := handler.Handle(context.Background(), tt.cmd)
+			require.Error(t, err)
+			assert.Equal(t, errors.ErrorTypeIncorrectInput, err.(errors.SlugError).ErrorType())
+		})
+	}
+}
@@ -3,11 +3,15 @@ package command
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"gopher-cache/internal/common/errors"
 	"gopher-cache/internal/common/logs"
 	"gopher-cache/internal/games/domain/game"
+	"time"
 )
 
+var ErrorUnknownGameKind = errors.NewIncorrectInputError("unknown game kind", "unknown-game-kind")
+
 // CreateGame represents the command input for creating a game.
 // All fields are required unless specified otherwise.
 type CreateGame struct {
@@ -24,6 +28,32 @@ type CreateGame struct {
 	State string `json:"state"`
 	// Required if the kind is urban
 	Country string `json:"country"`
+	// Location is optional. If it isn't given the game starts at the location of the first level.
+	Location *Location `json:"location"`
+	// Clues is optional. If it isn't given every wrong answer reveals the next clue straight away.
+	Clues *ClueSettings `json:"clues"`
+	// Locale is the locale of the game's text. It is optional and defaults to en.
+	Locale string `json:"locale"`
+	// Translations are optional. Each must have a translation of every level.
+	Translations []GameTranslation `json:"translations"`
+}
+
+// GameTranslation is the text of a game in another locale.
+type GameTranslation struct {
+	Locale      string             `json:"locale"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Ending      string             `json:"ending"`
+	Levels      []LevelTranslation `json:"levels"`
+}
+
+// LevelTranslation is the text of a level in another locale. Clues must be translated one for one.
+type LevelTranslation struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Clues       []string `json:"clues"`
+	// Answers are optional. They are accepted as well as the level's answers.
+	Answers []string `json:"answers"`
 }
 
 type GameLevel struct {
@@ -31,31 +61,79 @@ type GameLevel struct {
 	Description string   `json:"description"`
 	Clues       []string `json:"clues"`
 	Answers     []string `json:"answers"`
+	// Location is optional.
+	Location *Location `json:"location"`
+}
+
+// ClueSettings control how players get the clues of a game's levels.
+type ClueSettings struct {
+	// WrongAnswersRevealClues is optional and defaults to true. If it is false players only get clues
+	// by asking for them.
+	WrongAnswersRevealClues *bool `json:"wrongAnswersRevealClues"`
+	// CooldownSeconds is how long players must wait between clues. It is optional and at most an hour.
+	CooldownSeconds int `json:"cooldownSeconds"`
+}
+
+// Location is a point on the earth in degrees.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// ContentModerator checks the content written by creators for anything players shouldn't see.
+type ContentModerator interface {
+	// Moderate returns a flag for every problem found or none if the content is fine.
+	Moderate(ctx context.Context, content []game.Content) ([]game.ModerationFlag, error)
 }
 
 // CreateGameHandler handles creating games.
 type CreateGameHandler struct {
-	repo game.Repository
+	repo      game.Repository
+	publisher EventPublisher
+	moderator ContentModerator
 }
 
 // NewCreateGameHandler creates a new game handler.
-func NewCreateGameHandler(repo game.Repository) CreateGameHandler {
+func NewCreateGameHandler(repo game.Repository, publisher EventPublisher, moderator ContentModerator) CreateGameHandler {
 	if repo == nil {
 		panic("nil repo")
 	}
 
-	return CreateGameHandler{repo: repo}
+	if publisher == nil {
+		panic("nil publisher")
+	}
+
+	if moderator == nil {
+		panic("nil moderator")
+	}
+
+	return CreateGameHandler{repo: repo, publisher: publisher, moderator: moderator}
 }
 
-// Handle handles the use case of creating games.
+// Handle handles the use case of creating games. Games with content flagged by moderation are held
+// back from players until a moderator has reviewed them.
 func (h CreateGameHandler) Handle(ctx context.Context, cmd CreateGame) (err error) {
 	defer func() {
 		logs.LogCommandExecution("CreateGame", cmd, err)
 	}()
 
+	if err := authorize(cmd.Creator, game.PermissionCreateGames); err != nil {
+		return err
+	}
+
 	var levelAdders []game.LevelAdder
 	for _, l := range cmd.Levels {
-		levelAdders = append(levelAdders, game.NewLevelAdder(l.Title, l.Description, l.Clues, l.Answers))
+		if l.Location == nil {
+			levelAdders = append(levelAdders, game.NewLevelAdder(l.Title, l.Description, l.Clues, l.Answers))
+			continue
+		}
+
+		location, err := game.NewLocation(l.Location.Latitude, l.Location.Longitude)
+		if err != nil {
+			return err
+		}
+
+		levelAdders = append(levelAdders, game.NewLocatedLevelAdder(l.Title, l.Description, l.Clues, l.Answers, location))
 	}
 
 	switch cmd.Kind {
@@ -70,11 +148,100 @@ func (h CreateGameHandler) Handle(ctx context.Context, cmd CreateGame) (err erro
 			cmd.Country,
 			levelAdders...)
 		if err != nil {
+			return incorrectGameInput(err)
+		}
+
+		if cmd.Location != nil {
+			location, err := game.NewLocation(cmd.Location.Latitude, cmd.Location.Longitude)
+			if err != nil {
+				return err
+			}
+
+			g.SetStartingLocation(location)
+		}
+
+		if cmd.Clues != nil {
+			wrongAnswersRevealClues := cmd.Clues.WrongAnswersRevealClues == nil || *cmd.Clues.WrongAnswersRevealClues
+
+			clues, err := game.NewClueSettings(wrongAnswersRevealClues, time.Duration(cmd.Clues.CooldownSeconds)*time.Second)
+			if err != nil {
+				return err
+			}
+
+			g.SetClueSettings(clues)
+		}
+
+		if cmd.Locale != "" {
+			if err := g.SetLocale(cmd.Locale); err != nil {
+				return err
+			}
+		}
+
+		for i, t := range cmd.Translations {
+			translation, err := newTranslation(t)
+			if validationErr, ok := err.(game.ValidationError); ok {
+				return incorrectGameInput(validationErr.Within(fmt.Sprintf("translations[%d]", i)))
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if err := g.AddTranslation(translation); err != nil {
+				return err
+			}
+		}
+
+		flags, err := h.moderator.Moderate(ctx, g.Content())
+		if err != nil {
+			return err
+		}
+
+		var report *game.Report
+		if len(flags) > 0 {
+			g.HoldForReview()
+
+			report, err = game.NewAutomaticReport(g.UUID(), flags)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := h.repo.AddGame(ctx, g); err != nil {
 			return err
 		}
 
-		return h.repo.AddGame(ctx, g)
+		if report == nil {
+			publish(ctx, h.publisher, game.NewGameCreatedEvent(g))
+			return nil
+		}
+
+		if err := h.repo.AddReport(ctx, report); err != nil {
+			return err
+		}
+
+		publish(ctx, h.publisher, game.NewGameCreatedEvent(g), game.NewGameReportedEvent(report))
+
+		return nil
 	default:
-		return errors.New("unknown game kind")
+		return ErrorUnknownGameKind
 	}
 }
+
+func newTranslation(t GameTranslation) (game.Translation, error) {
+	var levels []game.LevelTranslation
+	for i, l := range t.Levels {
+		level, err := game.NewLevelTranslation(l.Title, l.Description, l.Clues, l.Answers)
+		if validationErr, ok := err.(game.ValidationError); ok {
+			return game.Translation{}, validationErr.Within(fmt.Sprintf("levels[%d]", i))
+		}
+
+		if err != nil {
+			return game.Translation{}, err
+		}
+
+		levels = append(levels, level)
+	}
+
+	return game.NewTranslation(t.Locale, t.Title, t.Description, t.Ending, levels...)
+}
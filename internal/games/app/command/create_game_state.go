@@ -12,28 +12,41 @@ import (
 type CreateGameState struct {
 	User     game.User `json:"-"`
 	GameUUID string    `json:"gameUUID"`
+	// AcceptLanguage is optional. It is the Accept-Language of the request, which is used to choose the
+	// locale of the response if the player hasn't chosen one.
+	AcceptLanguage string `json:"-"`
 }
 
 // CreateGameStateHandler handles creating the game state.
 type CreateGameStateHandler struct {
-	repo game.Repository
+	repo      game.Repository
+	publisher EventPublisher
 }
 
 // NewCreateGameStateHandler creates a new handler.
-func NewCreateGameStateHandler(repo game.Repository) CreateGameStateHandler {
+func NewCreateGameStateHandler(repo game.Repository, publisher EventPublisher) CreateGameStateHandler {
 	if repo == nil {
 		panic("nil repo")
 	}
 
-	return CreateGameStateHandler{repo: repo}
+	if publisher == nil {
+		panic("nil publisher")
+	}
+
+	return CreateGameStateHandler{repo: repo, publisher: publisher}
 }
 
-// Handle handles the use case of creating a new game state.
+// Handle handles the use case of creating a new game state. Players must verify their number before they
+// can start games, and players that opted out of messages can't start games until they opt back in.
 func (h CreateGameStateHandler) Handle(ctx context.Context, cmd CreateGameState) (resp *game.Response, err error) {
 	defer func() {
 		logs.LogCommandExecution("CreateGameState", cmd, err)
 	}()
 
+	if err := authorize(cmd.User, game.PermissionPlayGames); err != nil {
+		return nil, err
+	}
+
 	g, err := h.repo.GetGame(ctx, cmd.GameUUID)
 	if err != nil {
 		return nil, err
@@ -56,7 +69,17 @@ func (h CreateGameStateHandler) Handle(ctx context.Context, cmd CreateGameState)
 		}
 	}
 
-	state, resp, err := game.Start(g, p)
+	if err := checkGameMessage(p); err != nil {
+		return nil, err
+	}
+
+	// Starting their first game is how players agree to receive game messages.
+	consent, err := p.GiveConsent(game.ConsentSourceStartGame)
+	if err != nil {
+		return nil, err
+	}
+
+	state, resp, err := game.Start(localize(g, p, cmd.AcceptLanguage), p)
 	if err != nil {
 		return nil, err
 	}
@@ -66,5 +89,14 @@ func (h CreateGameStateHandler) Handle(ctx context.Context, cmd CreateGameState)
 		return nil, err
 	}
 
+	if consent != nil {
+		err = h.repo.AddConsentRecord(ctx, consent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	publish(ctx, h.publisher, game.NewGameStartedEvent(state))
+
 	return resp, nil
 }
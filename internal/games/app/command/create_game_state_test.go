@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"gopher-cache/internal/common/emulators"
 	"gopher-cache/internal/games/adapters"
+	"gopher-cache/internal/games/app/query"
 	"gopher-cache/internal/games/domain/game"
 	"testing"
 )
@@ -25,7 +26,14 @@ func TestCreateGameStateHandler_Handle(t *testing.T) {
 		panic(err)
 	}
 
-	createGameHandler := NewCreateGameHandler(repo)
+	projections, err := adapters.NewFirestoreProjectionRepository(client)
+	if err != nil {
+		panic(err)
+	}
+
+	projector := query.NewProjector(projections)
+
+	createGameHandler := NewCreateGameHandler(repo, projector, adapters.NewRuleModerator())
 
 	userID, err := uuid.NewRandom()
 	require.NoError(t, err)
@@ -69,9 +77,9 @@ func TestCreateGameStateHandler_Handle(t *testing.T) {
 	err = createGameHandler.Handle(ctx, createGame)
 	require.NoError(t, err)
 
-	createGameStateHandler := NewCreateGameStateHandler(repo)
+	createGameStateHandler := NewCreateGameStateHandler(repo, projector)
 
-	games, err := repo.ReadGames(ctx, 10, 0)
+	games, err := projections.ReadGames(ctx, query.GameQuery{}, query.Page{Limit: 10})
 	require.NoError(t, err)
 	require.Equal(t, 1, len(games))
 
@@ -83,6 +91,12 @@ func TestCreateGameStateHandler_Handle(t *testing.T) {
 	player, err := repo.GetPlayerByNumber(ctx, user.Number())
 	assert.Error(t, err)
 
+	// The player is created but can't start the game until their number is verified.
+	_, err = createGameStateHandler.Handle(ctx, createGameState)
+	assert.Equal(t, ErrorNumberNotVerified, err)
+
+	verifyTestNumber(t, repo, user)
+
 	_, err = createGameStateHandler.Handle(ctx, createGameState)
 	assert.NoError(t, err)
 
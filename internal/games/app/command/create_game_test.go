@@ -6,8 +6,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopher-cache/internal/common/emulators"
+	"gopher-cache/internal/common/errors"
 	"gopher-cache/internal/games/adapters"
+	"gopher-cache/internal/games/app/query"
 	"gopher-cache/internal/games/domain/game"
+	"strings"
 	"testing"
 )
 
@@ -25,7 +28,14 @@ func TestCreateGameHandler_Handle(t *testing.T) {
 		panic(err)
 	}
 
-	createGameHandler := NewCreateGameHandler(repo)
+	projections, err := adapters.NewFirestoreProjectionRepository(client)
+	if err != nil {
+		panic(err)
+	}
+
+	projector := query.NewProjector(projections)
+
+	createGameHandler := NewCreateGameHandler(repo, projector, adapters.NewRuleModerator())
 
 	userID, err := uuid.NewRandom()
 	require.NoError(t, err)
@@ -69,8 +79,50 @@ func TestCreateGameHandler_Handle(t *testing.T) {
 	err = createGameHandler.Handle(ctx, createGame)
 	assert.NoError(t, err)
 
-	games, err := repo.ReadGames(ctx, 10, 0)
+	games, err := projections.ReadGames(ctx, query.GameQuery{}, query.Page{Limit: 10})
 	require.NoError(t, err)
 
 	assert.Equal(t, 1, len(games))
 }
+
+func TestCreateGameHandler_HandleInvalidFields(t *testing.T) {
+	ctx := context.Background()
+
+	repo := adapters.NewMemoryGameRepository()
+	projector := query.NewProjector(adapters.NewMemoryProjectionRepository())
+
+	user, err := game.NewUser("creator", "15734497033")
+	require.NoError(t, err)
+
+	err = NewCreateGameHandler(repo, projector, adapters.NewRuleModerator()).Handle(ctx, CreateGame{
+		Creator:     user,
+		Title:       strings.Repeat("長", game.MaxTitleLength+1),
+		Description: "This is an awesome game",
+		Levels: []GameLevel{
+			{
+				Title:       "Level One",
+				Description: "This is Level One",
+				Clues:       []string{"Level One\x00Clue One"},
+			},
+		},
+		Ending:  "The end",
+		Kind:    "urban",
+		City:    "Austin",
+		State:   "Texas",
+		Country: "USA",
+	})
+
+	slugErr, ok := err.(errors.SlugError)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrorTypeIncorrectInput, slugErr.ErrorType())
+	assert.Equal(t, "invalid-game", slugErr.Slug())
+	assert.Equal(t, []errors.FieldError{
+		{Field: "title", Rule: game.RuleMaxLength, Limit: game.MaxTitleLength},
+		{Field: "levels[0].clues[0]", Rule: game.RuleControlCharacters},
+		{Field: "levels[0].answers", Rule: game.RuleRequired},
+	}, slugErr.Fields())
+
+	games, err := repo.AllGames(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, games)
+}
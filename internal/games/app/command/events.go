@@ -0,0 +1,20 @@
+package command
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// EventPublisher publishes domain events after the changes they describe have been persisted.
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...game.Event) error
+}
+
+// publish publishes events without failing the command. The changes have already been persisted
+// at this point so a failure only leaves the projections behind, which a rebuild will fix.
+func publish(ctx context.Context, publisher EventPublisher, events ...game.Event) {
+	if err := publisher.Publish(ctx, events...); err != nil {
+		logrus.WithError(err).Error("Unable to publish events")
+	}
+}
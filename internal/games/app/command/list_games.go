@@ -0,0 +1,64 @@
+package command
+
+import (
+	"context"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// MaxListedGames is how many games are listed in a text so the reply fits in a few messages.
+const MaxListedGames = 5
+
+// ListGames represents the command input for texting a player the games they can start.
+type ListGames struct {
+	// User is who is asking for the games. Only the player or a user who can manage players may.
+	User game.User `json:"-"`
+	// PlayerNumber may be in any format, e.g. "+1 (573) 449-7033" or "15734497033".
+	PlayerNumber string `json:"-"`
+	// AcceptLanguage is optional. It is the Accept-Language of the request, which is used to choose the
+	// locale of the response if the player hasn't chosen one.
+	AcceptLanguage string `json:"-"`
+}
+
+// GameLister lists the titles of the newest games players can start.
+type GameLister interface {
+	ListGameTitles(ctx context.Context, limit int) ([]string, error)
+}
+
+// ListGamesHandler handles listing games to players.
+type ListGamesHandler struct {
+	repo  game.Repository
+	games GameLister
+}
+
+// NewListGamesHandler creates a new handler.
+func NewListGamesHandler(repo game.Repository, games GameLister) ListGamesHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	if games == nil {
+		panic("nil games")
+	}
+
+	return ListGamesHandler{repo: repo, games: games}
+}
+
+// Handle handles the use case of a player asking which games they can play.
+func (h ListGamesHandler) Handle(ctx context.Context, cmd ListGames) (resp *game.Response, err error) {
+	defer func() {
+		logs.LogCommandExecution("ListGames", cmd, err)
+	}()
+
+	p, err := messagedPlayer(ctx, h.repo, cmd.User, cmd.PlayerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	titles, err := h.games.ListGameTitles(ctx, MaxListedGames)
+	if err != nil {
+		return nil, err
+	}
+
+	return game.NewGamesResponse(titles, messageLocale(p, cmd.AcceptLanguage)), nil
+}
@@ -0,0 +1,158 @@
+package command
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/games/domain/game"
+)
+
+var (
+	ErrorNumberOptedOut = errors.NewAuthorizationError(
+		"the player has opted out of messages, text START to resubscribe", "number-opted-out")
+)
+
+// playerByNumber returns the player with the number, which may be in any format. The user must be the player
+// or be able to manage players.
+func playerByNumber(ctx context.Context, repo game.Repository, user game.User, number string) (*game.Player, error) {
+	normalized, err := game.NormalizeNumber(number)
+	if err != nil {
+		return nil, ErrorInvalidNumber
+	}
+
+	p, err := repo.GetPlayerByNumber(ctx, normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizePlayer(user, p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// checkGameMessage returns an error unless a game message may be sent to the player, which is only once the
+// number is verified and while the player hasn't opted out.
+func checkGameMessage(p *game.Player) error {
+	if err := suppressMessage(p, "game"); err != nil {
+		return err
+	}
+
+	if !p.NumberVerified() {
+		return ErrorNumberNotVerified
+	}
+
+	return nil
+}
+
+// messagedPlayer returns the player with the number if a game message may be sent to them.
+func messagedPlayer(ctx context.Context, repo game.Repository, user game.User, number string) (*game.Player, error) {
+	p, err := playerByNumber(ctx, repo, user, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkGameMessage(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// localize returns the game in the locale the player prefers, or the locale that best matches the
+// Accept-Language of the request if the player hasn't chosen one the game is in.
+func localize(g *game.Game, p *game.Player, acceptLanguage string) *game.Game {
+	return g.Localize(game.MatchLocale(g.Locales(), p.Locale(), acceptLanguage))
+}
+
+// messageLocale returns the locale of system messages to the player that aren't about a game.
+func messageLocale(p *game.Player, acceptLanguage string) string {
+	return game.MatchLocale(game.MessageLocales, p.Locale(), acceptLanguage)
+}
+
+// currentGame returns the state and game the player is playing.
+func currentGame(ctx context.Context, repo game.Repository, p *game.Player) (*game.State, *game.Game, error) {
+	if p.CurrentGameStateUUID() == "" {
+		return nil, nil, game.ErrorNoCurrentGame
+	}
+
+	s, err := repo.GetState(ctx, p.CurrentGameStateUUID())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	g, err := repo.GetGame(ctx, s.GameUUID())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s, g, nil
+}
+
+// handleMessagingKeyword handles the input if it is a messaging keyword such as STOP, which must be honoured
+// on every path that receives a player's messages before the input is used for anything else. It reports
+// whether the input was a keyword.
+func handleMessagingKeyword(
+	ctx context.Context,
+	repo game.Repository,
+	p *game.Player,
+	input,
+	acceptLanguage string) (*game.Response, bool, error) {
+	keyword, ok := game.ParseMessagingKeyword(input)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var (
+		record *game.ConsentRecord
+		err    error
+	)
+
+	switch keyword {
+	case game.KeywordStop:
+		record, err = p.OptOut(game.KeywordSource(input))
+	case game.KeywordStart:
+		record, err = p.OptIn(game.KeywordSource(input))
+	}
+	if err != nil {
+		return nil, true, err
+	}
+
+	if record != nil {
+		if err := repo.UpdatePlayer(ctx, p); err != nil {
+			return nil, true, err
+		}
+
+		if err := repo.AddConsentRecord(ctx, record); err != nil {
+			return nil, true, err
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"player": p.UUID(),
+			"number": p.Number(),
+			"action": record.Action(),
+			"source": record.Source(),
+		}).Info("Recorded messaging consent")
+	}
+
+	// The reply to a keyword is the one message that is still sent after a player opts out.
+	return game.NewKeywordResponse(keyword, messageLocale(p, acceptLanguage)), true, nil
+}
+
+// suppressMessage returns ErrorNumberOptedOut if the player has opted out of messages and logs that the
+// message of the kind wasn't sent so suppressed messages can be audited.
+func suppressMessage(p *game.Player, kind string) error {
+	if !p.OptedOut() {
+		return nil
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"player":     p.UUID(),
+		"number":     p.Number(),
+		"kind":       kind,
+		"optedOutAt": p.OptedOutAt(),
+	}).Warn("Suppressed message to opted out number")
+
+	return ErrorNumberOptedOut
+}
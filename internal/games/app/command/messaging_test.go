@@ -0,0 +1,135 @@
+package command
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/games/adapters"
+	"gopher-cache/internal/games/app/query"
+	"gopher-cache/internal/games/domain/game"
+	"testing"
+)
+
+func TestUpdateGameStateHandler_HandleMessagingKeywords(t *testing.T) {
+	ctx := context.Background()
+
+	repo := adapters.NewMemoryGameRepository()
+	projector := query.NewProjector(adapters.NewMemoryProjectionRepository())
+
+	user, err := game.NewUser("player", "15734497033")
+	require.NoError(t, err)
+
+	createGame := CreateGame{
+		Creator:     user,
+		Title:       "An Awesome Game",
+		Description: "This is an awesome game",
+		Levels: []GameLevel{
+			{
+				Title:       "Level One",
+				Description: "This is Level One",
+				Clues:       []string{"Level One Clue One"},
+				Answers:     []string{"Level One is the best"},
+			},
+			{
+				Title:       "Level Two",
+				Description: "This is Level Two",
+				Clues:       []string{"Level Two Clue One"},
+				Answers:     []string{"Level Two is the best"},
+			},
+		},
+		Ending:  "The end",
+		Kind:    "urban",
+		City:    "Austin",
+		State:   "Texas",
+		Country: "USA",
+	}
+
+	err = NewCreateGameHandler(repo, projector, adapters.NewRuleModerator()).Handle(ctx, createGame)
+	require.NoError(t, err)
+
+	games, err := repo.AllGames(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(games))
+
+	createGameStateHandler := NewCreateGameStateHandler(repo, projector)
+	updateGameStateHandler := NewUpdateGameStateHandler(repo, projector)
+
+	verifyTestNumber(t, repo, user)
+
+	// Starting the first game records the player's consent.
+	_, err = createGameStateHandler.Handle(ctx, CreateGameState{User: user, GameUUID: games[0].UUID()})
+	require.NoError(t, err)
+
+	update := func(input string) (*game.Response, error) {
+		return updateGameStateHandler.Handle(ctx, UpdateGameState{User: user, PlayerNumber: user.Number(), Input: input})
+	}
+
+	resp, err := update("help")
+	require.NoError(t, err)
+	assert.Equal(t, game.HelpMessage, resp.Message)
+
+	resp, err = update("STOP")
+	require.NoError(t, err)
+	assert.Equal(t, game.OptOutMessage, resp.Message)
+
+	p, err := repo.GetPlayer(ctx, user.UUID())
+	require.NoError(t, err)
+	assert.True(t, p.OptedOut())
+
+	// No game messages are sent while the player is opted out, but keywords are still answered.
+	_, err = update(createGame.Levels[0].Answers[0])
+	assert.Equal(t, ErrorNumberOptedOut, err)
+
+	_, err = createGameStateHandler.Handle(ctx, CreateGameState{User: user, GameUUID: games[0].UUID()})
+	assert.Equal(t, ErrorNumberOptedOut, err)
+
+	resp, err = update("info")
+	require.NoError(t, err)
+	assert.Equal(t, game.HelpMessage, resp.Message)
+
+	resp, err = update("Start")
+	require.NoError(t, err)
+	assert.Equal(t, game.OptInMessage, resp.Message)
+
+	resp, err = update(createGame.Levels[0].Answers[0])
+	require.NoError(t, err)
+	assert.Equal(t, game.LevelResponse, resp.Kind)
+
+	// Keywords never reach the game so they aren't attempts.
+	attempts, err := repo.AllAttempts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(attempts))
+
+	records, err := repo.GetConsentRecords(ctx, user.UUID())
+	require.NoError(t, err)
+	require.Equal(t, 3, len(records))
+
+	assert.Equal(t, game.ConsentGiven, records[0].Action())
+	assert.Equal(t, game.ConsentSourceStartGame, records[0].Source())
+	assert.Equal(t, game.ConsentWithdrawn, records[1].Action())
+	assert.Equal(t, "sms:STOP", records[1].Source())
+	assert.Equal(t, game.ConsentGiven, records[2].Action())
+	assert.Equal(t, "sms:START", records[2].Source())
+}
+
+func TestRequestNumberVerificationHandler_HandleOptedOut(t *testing.T) {
+	ctx := context.Background()
+
+	repo := adapters.NewMemoryGameRepository()
+	sms := newFakeSMSSender()
+
+	user, err := game.NewUser("player", "15734497033")
+	require.NoError(t, err)
+
+	p, err := game.NewPlayerFromUser(user)
+	require.NoError(t, err)
+
+	_, err = p.OptOut("sms:STOP")
+	require.NoError(t, err)
+
+	require.NoError(t, repo.AddPlayer(ctx, p))
+
+	err = NewRequestNumberVerificationHandler(repo, sms).Handle(ctx, RequestNumberVerification{User: user})
+	assert.Equal(t, ErrorNumberOptedOut, err)
+	assert.Empty(t, sms.messages)
+}
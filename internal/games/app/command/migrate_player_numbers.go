@@ -0,0 +1,130 @@
+package command
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+	"sort"
+	"time"
+)
+
+// MigratePlayerNumbers represents the command input for normalizing the numbers of players created before
+// numbers were normalized and verified.
+type MigratePlayerNumbers struct {
+	User game.User `json:"-"`
+}
+
+// MigratedPlayerNumbers reports what happened to the players that didn't already have a normalized and
+// verified number.
+type MigratedPlayerNumbers struct {
+	Migrated int `json:"migrated"`
+	// Verified is the number of players whose number was verified because they were already playing games.
+	Verified int `json:"verified"`
+	// Invalid are the UUIDs of players whose number isn't a valid phone number.
+	Invalid []string `json:"invalid"`
+	// Duplicates are the UUIDs of players whose normalized number belongs to another player. They are left
+	// unchanged to be merged by hand.
+	Duplicates []string `json:"duplicates"`
+}
+
+// PlayerMigrationRepository is the interface used for migrating players.
+type PlayerMigrationRepository interface {
+	AllPlayers(ctx context.Context) ([]*game.Player, error)
+	UpdatePlayer(ctx context.Context, player *game.Player) error
+}
+
+// MigratePlayerNumbersHandler handles migrating player numbers.
+type MigratePlayerNumbersHandler struct {
+	repo PlayerMigrationRepository
+}
+
+// NewMigratePlayerNumbersHandler creates a new handler.
+func NewMigratePlayerNumbersHandler(repo PlayerMigrationRepository) MigratePlayerNumbersHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	return MigratePlayerNumbersHandler{repo: repo}
+}
+
+// Handle handles the use case of normalizing every player's number to E.164 format and verifying the
+// numbers of players who were playing games before numbers had to be verified. It can be run again safely
+// since players with a normalized and verified number are skipped.
+func (h MigratePlayerNumbersHandler) Handle(ctx context.Context, cmd MigratePlayerNumbers) (result *MigratedPlayerNumbers, err error) {
+	defer func() {
+		logs.LogCommandExecution("MigratePlayerNumbers", cmd, err)
+	}()
+
+	if err := authorize(cmd.User, game.PermissionManagePlayers); err != nil {
+		return nil, err
+	}
+
+	players, err := h.repo.AllPlayers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Players are migrated in a stable order so the same player keeps a duplicated number every run.
+	sort.Slice(players, func(i, j int) bool {
+		return players[i].UUID() < players[j].UUID()
+	})
+
+	numbers := make(map[string]bool, len(players))
+	for _, p := range players {
+		numbers[p.Number()] = true
+	}
+
+	result = &MigratedPlayerNumbers{Invalid: []string{}, Duplicates: []string{}}
+
+	now := time.Now().UTC()
+
+	for _, p := range players {
+		old := p.Number()
+
+		normalized, err := game.NormalizeNumber(old)
+		if err != nil {
+			result.Invalid = append(result.Invalid, p.UUID())
+			continue
+		}
+
+		migrated := normalized != old
+		if migrated && numbers[normalized] {
+			result.Duplicates = append(result.Duplicates, p.UUID())
+			continue
+		}
+
+		if _, err := p.NormalizeNumber(); err != nil {
+			return nil, err
+		}
+
+		verified := p.BackfillNumberVerification(now)
+		if !migrated && !verified {
+			continue
+		}
+
+		if err := h.repo.UpdatePlayer(ctx, p); err != nil {
+			return nil, err
+		}
+
+		numbers[normalized] = true
+
+		if migrated {
+			result.Migrated++
+
+			logrus.WithFields(logrus.Fields{
+				"player": p.UUID(),
+				"from":   old,
+				"to":     normalized,
+			}).Info("Migrated player number")
+		}
+
+		if verified {
+			result.Verified++
+
+			logrus.WithField("player", p.UUID()).Info("Verified number of existing player")
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/games/adapters"
+	"gopher-cache/internal/games/domain/game"
+	"testing"
+	"time"
+)
+
+func TestMigratePlayerNumbersHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	repo := adapters.NewMemoryGameRepository()
+
+	// Players created before numbers were normalized.
+	players := []*game.Player{
+		game.UnmarshalPlayerFromDatabase("a", "+15734497033", 0, 0, 0, "", time.Time{}, game.Verification{}, time.Time{}, time.Time{}, ""),
+		game.UnmarshalPlayerFromDatabase("b", "1 (573) 449-7033", 0, 0, 0, "", time.Time{}, game.Verification{}, time.Time{}, time.Time{}, ""),
+		game.UnmarshalPlayerFromDatabase("c", "573-449-7034", 0, 0, 0, "", time.Time{}, game.Verification{}, time.Time{}, time.Time{}, ""),
+		game.UnmarshalPlayerFromDatabase("d", "not a number", 0, 0, 0, "", time.Time{}, game.Verification{}, time.Time{}, time.Time{}, ""),
+		// Players who were already playing games before numbers had to be verified.
+		game.UnmarshalPlayerFromDatabase("e", "573-449-7037", 2, 1, 10, "", time.Time{}, game.Verification{}, time.Time{}, time.Time{}, ""),
+		game.UnmarshalPlayerFromDatabase("f", "+15734497038", 1, 0, 0, "state", time.Time{}, game.Verification{}, time.Time{}, time.Time{}, ""),
+	}
+	for _, p := range players {
+		require.NoError(t, repo.AddPlayer(ctx, p))
+	}
+
+	organizer, err := game.NewUserWithRoles("organizer", "15734497035", "", game.RoleOrganizer)
+	require.NoError(t, err)
+
+	player, err := game.NewUser("player", "15734497036")
+	require.NoError(t, err)
+
+	handler := NewMigratePlayerNumbersHandler(repo)
+
+	_, err = handler.Handle(ctx, MigratePlayerNumbers{User: player})
+	assert.Error(t, err)
+
+	result, err := handler.Handle(ctx, MigratePlayerNumbers{User: organizer})
+	require.NoError(t, err)
+	assert.Equal(t, &MigratedPlayerNumbers{Migrated: 2, Verified: 2, Invalid: []string{"d"}, Duplicates: []string{"b"}}, result)
+
+	p, err := repo.GetPlayerByNumber(ctx, "+15734497034")
+	require.NoError(t, err)
+	assert.Equal(t, "c", p.UUID())
+	assert.False(t, p.NumberVerified(), "players who haven't played must verify their number")
+
+	for _, number := range []string{"+15734497037", "+15734497038"} {
+		p, err := repo.GetPlayerByNumber(ctx, number)
+		require.NoError(t, err)
+		assert.True(t, p.NumberVerified(), number)
+	}
+
+	// Running it again changes nothing.
+	result, err = handler.Handle(ctx, MigratePlayerNumbers{User: organizer})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Migrated)
+	assert.Equal(t, 0, result.Verified)
+}
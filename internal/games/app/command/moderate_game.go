@@ -0,0 +1,74 @@
+package command
+
+import (
+	"context"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// ModerateGame represents the command input for a moderator's decision on a game.
+type ModerateGame struct {
+	Moderator game.User `json:"-"`
+	GameUUID  string    `json:"-"`
+	// Publish makes the game available to players. Otherwise the game is unpublished.
+	Publish bool `json:"publish"`
+}
+
+// ModerateGameHandler handles moderating games.
+type ModerateGameHandler struct {
+	repo      game.Repository
+	publisher EventPublisher
+}
+
+// NewModerateGameHandler creates a new handler.
+func NewModerateGameHandler(repo game.Repository, publisher EventPublisher) ModerateGameHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	if publisher == nil {
+		panic("nil publisher")
+	}
+
+	return ModerateGameHandler{repo: repo, publisher: publisher}
+}
+
+// Handle handles the use case of publishing or unpublishing a game. Every open report of the game is
+// resolved by the decision, which takes the game out of the review queue.
+func (h ModerateGameHandler) Handle(ctx context.Context, cmd ModerateGame) (err error) {
+	defer func() {
+		logs.LogCommandExecution("ModerateGame", cmd, err)
+	}()
+
+	if err := authorize(cmd.Moderator, game.PermissionModerateGames); err != nil {
+		return err
+	}
+
+	g, err := h.repo.GetGame(ctx, cmd.GameUUID)
+	if err != nil {
+		return err
+	}
+
+	reports, err := h.repo.GetOpenReports(ctx, g.UUID())
+	if err != nil {
+		return err
+	}
+
+	if cmd.Publish {
+		g.Publish()
+	} else {
+		g.Unpublish()
+	}
+
+	for _, r := range reports {
+		r.Resolve()
+	}
+
+	if err := h.repo.UpdateGameAndReports(ctx, g, reports); err != nil {
+		return err
+	}
+
+	publish(ctx, h.publisher, game.NewGameModeratedEvent(g, reports))
+
+	return nil
+}
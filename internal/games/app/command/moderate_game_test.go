@@ -0,0 +1,163 @@
+package command
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/games/adapters"
+	"gopher-cache/internal/games/app/query"
+	"gopher-cache/internal/games/domain/game"
+	"testing"
+)
+
+// projectionsWatcher sends every game projection once, the same as an instance starting to follow them.
+type projectionsWatcher struct {
+	projections *adapters.MemoryProjectionRepository
+}
+
+func (w projectionsWatcher) WatchGameProjections(ctx context.Context, changed func(ctx context.Context, changes query.GameProjectionChanges) error) error {
+	games, err := w.projections.ReadGames(ctx, query.GameQuery{}, query.Page{Limit: 100})
+	if err != nil {
+		return err
+	}
+
+	return changed(ctx, query.GameProjectionChanges{All: true, Saved: games})
+}
+
+func TestModerateGameHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	repo := adapters.NewMemoryGameRepository()
+	projections := adapters.NewMemoryProjectionRepository()
+	index := adapters.NewMemorySearchIndex()
+	projector := query.NewProjector(projections)
+	cursors := query.NewCursorSigner([]byte("test"))
+
+	readGames := query.NewReadGamesHandler(projections, cursors)
+	searchGames := query.NewSearchGamesHandler(index, cursors)
+	readQueue := query.NewReadModerationQueueHandler(projections, cursors)
+
+	userID, err := uuid.NewRandom()
+	require.NoError(t, err)
+
+	user, err := game.NewNamedUser(userID.String(), "15734497033", "Gopher")
+	require.NoError(t, err)
+
+	adminID, err := uuid.NewRandom()
+	require.NoError(t, err)
+
+	admin, err := game.NewUserWithRoles(adminID.String(), "15734497034", "Admin", game.RoleAdmin)
+	require.NoError(t, err)
+
+	createGame := CreateGame{
+		Creator:     user,
+		Title:       "Pirate Treasure",
+		Description: "Find the treasure, visit www.cheap-prizes.com for hints",
+		Levels: []GameLevel{
+			{
+				Title:       "Level One",
+				Description: "This is Level One",
+				Clues:       []string{"Call 512-555-0100 if you are stuck"},
+				Answers:     []string{"Level One is the best"},
+			},
+		},
+		Ending:  "The end",
+		Kind:    "urban",
+		City:    "Austin",
+		State:   "Texas",
+		Country: "USA",
+	}
+
+	err = NewCreateGameHandler(repo, projector, adapters.NewRuleModerator()).Handle(ctx, createGame)
+	require.NoError(t, err)
+
+	all, err := projections.ReadGames(ctx, query.GameQuery{}, query.Page{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(all))
+	gameUUID := all[0].UUID
+
+	// The game was flagged so it is held back from players.
+	assertListed := func(listed bool) {
+		t.Helper()
+
+		require.NoError(t, query.SyncGameSearchIndex(ctx, projectionsWatcher{projections}, index))
+
+		games, err := readGames.Handle(ctx, user, query.GameQuery{}, query.PageParams{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, listed, len(games.Games) == 1)
+
+		found, err := searchGames.Handle(ctx, user, query.GameSearch{Text: "pirate"}, query.PageParams{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, listed, len(found.Games) == 1)
+	}
+
+	assertListed(false)
+
+	g, err := repo.GetGame(ctx, gameUUID)
+	require.NoError(t, err)
+	assert.Equal(t, game.StatusPendingReview, g.Status())
+
+	_, err = NewCreateGameStateHandler(repo, projector).Handle(ctx, CreateGameState{User: user, GameUUID: gameUUID})
+	assert.Error(t, err)
+
+	queue, err := readQueue.Handle(ctx, admin, query.PageParams{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(queue.Cases))
+	assert.Equal(t, gameUUID, queue.Cases[0].GameUUID)
+	assert.Equal(t, map[string]int{"automatic": 1}, queue.Cases[0].Reasons)
+	assert.Equal(t, []query.ModerationFlag{
+		{Field: "description", Rule: "url", Match: "www.cheap-prizes.com"},
+		{Field: "levels[0].clues[0]", Rule: "phone-number", Match: "512-555-0100"},
+	}, queue.Cases[0].Flags)
+
+	// Players and creators can't read the queue or moderate.
+	_, err = readQueue.Handle(ctx, user, query.PageParams{Limit: 10})
+	assert.Equal(t, errors.ErrorTypeAuthorization, err.(errors.SlugError).ErrorType())
+
+	moderate := NewModerateGameHandler(repo, projector)
+
+	err = moderate.Handle(ctx, ModerateGame{Moderator: user, GameUUID: gameUUID, Publish: true})
+	assert.Equal(t, errors.ErrorTypeAuthorization, err.(errors.SlugError).ErrorType())
+
+	err = moderate.Handle(ctx, ModerateGame{Moderator: admin, GameUUID: gameUUID, Publish: true})
+	require.NoError(t, err)
+
+	assertListed(true)
+
+	queue, err = readQueue.Handle(ctx, admin, query.PageParams{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(queue.Cases))
+
+	// Players can report the published game which puts it back in the queue.
+	report := NewReportGameHandler(repo, projector)
+
+	err = report.Handle(ctx, ReportGame{Reporter: user, GameUUID: gameUUID, Reason: "unknown"})
+	assert.Error(t, err)
+
+	err = report.Handle(ctx, ReportGame{Reporter: user, GameUUID: gameUUID, Reason: "spam"})
+	require.NoError(t, err)
+
+	err = report.Handle(ctx, ReportGame{Reporter: user, GameUUID: gameUUID, Reason: "other", Details: "Wants my number"})
+	require.NoError(t, err)
+
+	queue, err = readQueue.Handle(ctx, admin, query.PageParams{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(queue.Cases))
+	assert.Equal(t, 2, queue.Cases[0].Reports)
+	assert.Equal(t, map[string]int{"spam": 1, "other": 1}, queue.Cases[0].Reasons)
+	assert.Equal(t, []string{"Wants my number"}, queue.Cases[0].Details)
+
+	err = moderate.Handle(ctx, ModerateGame{Moderator: admin, GameUUID: gameUUID, Publish: false})
+	require.NoError(t, err)
+
+	assertListed(false)
+
+	open, err := repo.GetOpenReports(ctx, gameUUID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(open))
+
+	_, err = query.NewReadGameHandler(projections).Handle(ctx, user, gameUUID)
+	assert.Equal(t, query.ErrorGameNotFound, err)
+}
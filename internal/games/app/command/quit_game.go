@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// QuitGame represents the command input for quitting a player's current game.
+type QuitGame struct {
+	// User is who is quitting the game. Only the player or a user who can manage players may.
+	User game.User `json:"-"`
+	// PlayerNumber may be in any format, e.g. "+1 (573) 449-7033" or "15734497033".
+	PlayerNumber string `json:"-"`
+	// AcceptLanguage is optional. It is the Accept-Language of the request, which is used to choose the
+	// locale of the response if the player hasn't chosen one.
+	AcceptLanguage string `json:"-"`
+}
+
+// QuitGameHandler handles quitting games.
+type QuitGameHandler struct {
+	repo      game.Repository
+	publisher EventPublisher
+}
+
+// NewQuitGameHandler creates a new handler.
+func NewQuitGameHandler(repo game.Repository, publisher EventPublisher) QuitGameHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	if publisher == nil {
+		panic("nil publisher")
+	}
+
+	return QuitGameHandler{repo: repo, publisher: publisher}
+}
+
+// Handle handles the use case of a player stopping their current game. Texts are answers to no game until
+// the player starts another one.
+func (h QuitGameHandler) Handle(ctx context.Context, cmd QuitGame) (resp *game.Response, err error) {
+	defer func() {
+		logs.LogCommandExecution("QuitGame", cmd, err)
+	}()
+
+	p, err := messagedPlayer(ctx, h.repo, cmd.User, cmd.PlayerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	s, g, err := currentGame(ctx, h.repo, p)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err = s.Quit(localize(g, p, cmd.AcceptLanguage), p)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repo.UpdatePlayer(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	publish(ctx, h.publisher, game.NewGameQuitEvent(s))
+
+	return resp, nil
+}
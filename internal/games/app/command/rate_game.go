@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// RateGame represents the command input for rating a game. Rating a game again replaces the previous rating.
+// All fields are required unless specified otherwise.
+type RateGame struct {
+	Rater    game.User `json:"-"`
+	GameUUID string    `json:"-"`
+	// Stars must be between 1 and 5.
+	Stars int `json:"stars"`
+	// Review is optional.
+	Review string `json:"review"`
+}
+
+// RateGameHandler handles rating games.
+type RateGameHandler struct {
+	repo      game.Repository
+	publisher EventPublisher
+}
+
+// NewRateGameHandler creates a new handler.
+func NewRateGameHandler(repo game.Repository, publisher EventPublisher) RateGameHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	if publisher == nil {
+		panic("nil publisher")
+	}
+
+	return RateGameHandler{repo: repo, publisher: publisher}
+}
+
+// Handle handles the use case of rating a game. Only players who have completed the game may rate it.
+func (h RateGameHandler) Handle(ctx context.Context, cmd RateGame) (err error) {
+	defer func() {
+		logs.LogCommandExecution("RateGame", cmd, err)
+	}()
+
+	if err := authorize(cmd.Rater, game.PermissionPlayGames); err != nil {
+		return err
+	}
+
+	states, err := h.repo.GetPlayerGameStates(ctx, cmd.Rater.UUID(), cmd.GameUUID)
+	if err != nil {
+		return err
+	}
+
+	var completed *game.State
+	for _, s := range states {
+		if s.Completed() {
+			completed = s
+			break
+		}
+	}
+
+	if completed == nil {
+		return game.ErrorGameNotCompleted
+	}
+
+	rating, err := game.NewRating(cmd.Rater, completed, cmd.Stars, cmd.Review)
+	if err != nil {
+		return err
+	}
+
+	var event game.GameRated
+
+	// The rating replaces any previous rating and is added to the game's summary in one transaction so
+	// ratings saved at the same time can't be counted twice or lost.
+	err = h.repo.SaveRating(ctx, rating, func(previous *game.Rating, summary *game.RatingSummary) error {
+		summary.Rate(rating, previous)
+		event = game.NewGameRatedEvent(rating, previous, *summary)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	publish(ctx, h.publisher, event)
+
+	return nil
+}
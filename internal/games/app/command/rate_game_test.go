@@ -0,0 +1,104 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/games/adapters"
+	"gopher-cache/internal/games/app/query"
+	"gopher-cache/internal/games/domain/game"
+	"testing"
+)
+
+func TestRateGameHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	repo := adapters.NewMemoryGameRepository()
+	projections := adapters.NewMemoryProjectionRepository()
+	projector := query.NewProjector(projections)
+
+	userID, err := uuid.NewRandom()
+	require.NoError(t, err)
+
+	user, err := game.NewNamedUser(userID.String(), "15734497033", "Gopher")
+	require.NoError(t, err)
+
+	createGame := CreateGame{
+		Creator:     user,
+		Title:       "An Awesome Game",
+		Description: "This is an awesome game",
+		Levels: []GameLevel{
+			{
+				Title:       "Level One",
+				Description: "This is Level One",
+				Clues:       []string{"Level One Clue One"},
+				Answers:     []string{"Level One is the best"},
+			},
+		},
+		Ending:  "The end",
+		Kind:    "urban",
+		City:    "Austin",
+		State:   "Texas",
+		Country: "USA",
+	}
+
+	err = NewCreateGameHandler(repo, projector, adapters.NewRuleModerator()).Handle(ctx, createGame)
+	require.NoError(t, err)
+
+	games, err := projections.ReadGames(ctx, query.GameQuery{}, query.Page{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(games))
+	gameUUID := games[0].UUID
+
+	handler := NewRateGameHandler(repo, projector)
+
+	// The game can't be rated before it is played.
+	err = handler.Handle(ctx, RateGame{Rater: user, GameUUID: gameUUID, Stars: 5})
+	assert.True(t, errors.Is(err, game.ErrorGameNotCompleted))
+
+	verifyTestNumber(t, repo, user)
+
+	_, err = NewCreateGameStateHandler(repo, projector).Handle(ctx, CreateGameState{
+		User:     user,
+		GameUUID: gameUUID,
+	})
+	require.NoError(t, err)
+
+	// Or before it is completed.
+	err = handler.Handle(ctx, RateGame{Rater: user, GameUUID: gameUUID, Stars: 5})
+	assert.True(t, errors.Is(err, game.ErrorGameNotCompleted))
+
+	_, err = NewUpdateGameStateHandler(repo, projector).Handle(ctx, UpdateGameState{
+		User:         user,
+		PlayerNumber: user.Number(),
+		Input:        createGame.Levels[0].Answers[0],
+	})
+	require.NoError(t, err)
+
+	err = handler.Handle(ctx, RateGame{Rater: user, GameUUID: gameUUID, Stars: 6})
+	assert.Error(t, err)
+
+	err = handler.Handle(ctx, RateGame{Rater: user, GameUUID: gameUUID, Stars: 2, Review: "Too short"})
+	require.NoError(t, err)
+
+	// Rating again replaces the previous rating.
+	err = handler.Handle(ctx, RateGame{Rater: user, GameUUID: gameUUID, Stars: 4, Review: "Loved it"})
+	require.NoError(t, err)
+
+	rating, err := repo.GetRating(ctx, gameUUID, user.UUID())
+	require.NoError(t, err)
+	assert.Equal(t, 4, rating.Stars())
+
+	g, err := projections.ReadGame(ctx, gameUUID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, g.RatingCount)
+	assert.Equal(t, 4.0, g.AverageRating)
+
+	reviews, err := projections.ReadGameReviews(ctx, gameUUID, query.Page{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(reviews))
+	assert.Equal(t, "Gopher", reviews[0].PlayerName)
+	assert.Equal(t, "Loved it", reviews[0].Review)
+}
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"context"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// ReadGameStatus represents the command input for telling a player how far they have got in their game.
+type ReadGameStatus struct {
+	// User is who is asking for the status. Only the player or a user who can manage players may.
+	User game.User `json:"-"`
+	// PlayerNumber may be in any format, e.g. "+1 (573) 449-7033" or "15734497033".
+	PlayerNumber string `json:"-"`
+	// AcceptLanguage is optional. It is the Accept-Language of the request, which is used to choose the
+	// locale of the response if the player hasn't chosen one.
+	AcceptLanguage string `json:"-"`
+}
+
+// ReadGameStatusHandler handles reading the status of games.
+type ReadGameStatusHandler struct {
+	repo game.Repository
+}
+
+// NewReadGameStatusHandler creates a new handler.
+func NewReadGameStatusHandler(repo game.Repository) ReadGameStatusHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	return ReadGameStatusHandler{repo: repo}
+}
+
+// Handle handles the use case of a player asking which level they are on. It reads the write model since
+// the reply must reflect the player's last text, which may not have been projected yet.
+func (h ReadGameStatusHandler) Handle(ctx context.Context, cmd ReadGameStatus) (resp *game.Response, err error) {
+	defer func() {
+		logs.LogCommandExecution("ReadGameStatus", cmd, err)
+	}()
+
+	p, err := messagedPlayer(ctx, h.repo, cmd.User, cmd.PlayerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	s, g, err := currentGame(ctx, h.repo, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Status(localize(g, p, cmd.AcceptLanguage)), nil
+}
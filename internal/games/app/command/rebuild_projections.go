@@ -0,0 +1,200 @@
+package command
+
+import (
+	"context"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+	"sort"
+)
+
+// RebuildProjections represents the command input for regenerating every projection from scratch.
+type RebuildProjections struct {
+	User game.User
+}
+
+// ProjectionSource provides the persisted domain types that projections are rebuilt from.
+type ProjectionSource interface {
+	AllGames(ctx context.Context) ([]*game.Game, error)
+	AllStates(ctx context.Context) ([]*game.State, error)
+	AllAttempts(ctx context.Context) ([]*game.Attempt, error)
+	AllRatings(ctx context.Context) ([]*game.Rating, error)
+	AllOpenReports(ctx context.Context) ([]*game.Report, error)
+}
+
+// ProjectionRebuilder is an EventPublisher that can also discard everything it has projected.
+type ProjectionRebuilder interface {
+	EventPublisher
+	Reset(ctx context.Context) error
+}
+
+// RebuildProjectionsHandler handles rebuilding projections.
+type RebuildProjectionsHandler struct {
+	source    ProjectionSource
+	rebuilder ProjectionRebuilder
+}
+
+// NewRebuildProjectionsHandler creates a new handler.
+func NewRebuildProjectionsHandler(source ProjectionSource, rebuilder ProjectionRebuilder) RebuildProjectionsHandler {
+	if source == nil {
+		panic("nil source")
+	}
+
+	if rebuilder == nil {
+		panic("nil rebuilder")
+	}
+
+	return RebuildProjectionsHandler{source: source, rebuilder: rebuilder}
+}
+
+// Handle handles the use case of rebuilding projections. The events that produced the current
+// games and states are replayed in the order they originally occurred.
+func (h RebuildProjectionsHandler) Handle(ctx context.Context, cmd RebuildProjections) (err error) {
+	defer func() {
+		logs.LogCommandExecution("RebuildProjections", cmd, err)
+	}()
+
+	if err := authorize(cmd.User, game.PermissionManageProjections); err != nil {
+		return err
+	}
+
+	games, err := h.source.AllGames(ctx)
+	if err != nil {
+		return err
+	}
+
+	states, err := h.source.AllStates(ctx)
+	if err != nil {
+		return err
+	}
+
+	attempts, err := h.source.AllAttempts(ctx)
+	if err != nil {
+		return err
+	}
+
+	ratings, err := h.source.AllRatings(ctx)
+	if err != nil {
+		return err
+	}
+
+	reports, err := h.source.AllOpenReports(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := h.rebuilder.Reset(ctx); err != nil {
+		return err
+	}
+
+	sort.Slice(games, func(i, j int) bool {
+		return games[i].CreatedAt().Before(games[j].CreatedAt())
+	})
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].StartedAt().Before(states[j].StartedAt())
+	})
+
+	sort.Slice(attempts, func(i, j int) bool {
+		return attempts[i].SubmittedAt().Before(attempts[j].SubmittedAt())
+	})
+
+	sort.Slice(ratings, func(i, j int) bool {
+		return ratings[i].RatedAt().Before(ratings[j].RatedAt())
+	})
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].ReportedAt().Before(reports[j].ReportedAt())
+	})
+
+	gamesByUUID := make(map[string]*game.Game, len(games))
+
+	var events []game.Event
+	for _, g := range games {
+		gamesByUUID[g.UUID()] = g
+		events = append(events, game.NewGameCreatedEvent(g))
+	}
+
+	for _, s := range states {
+		g, ok := gamesByUUID[s.GameUUID()]
+		if !ok {
+			// A state without a game can't be projected, so skip it rather than failing the rebuild.
+			continue
+		}
+
+		events = append(events, game.NewGameStartedEvent(s))
+
+		updated := game.NewStateUpdatedEvent(s)
+		updated.At = s.StartedAt()
+		if s.Completed() {
+			updated.At = s.FinishedAt()
+		}
+		events = append(events, updated)
+
+		if s.Completed() {
+			events = append(events, game.NewGameFinishedEvent(g, s))
+		}
+	}
+
+	// answered holds the levels of each state that were answered correctly.
+	answered := make(map[string]map[int]bool)
+	for _, a := range attempts {
+		if _, ok := gamesByUUID[a.GameUUID()]; !ok {
+			continue
+		}
+
+		events = append(events, game.NewAttemptRecordedEvent(a))
+
+		if a.Correct() {
+			if answered[a.StateUUID()] == nil {
+				answered[a.StateUUID()] = make(map[int]bool)
+			}
+			answered[a.StateUUID()][a.Level()] = true
+		}
+	}
+
+	// Skips aren't kept, but every level a player got past without answering it was skipped.
+	for _, s := range states {
+		if _, ok := gamesByUUID[s.GameUUID()]; !ok {
+			continue
+		}
+
+		for level := 0; level < s.Level(); level++ {
+			if answered[s.UUID()][level] {
+				continue
+			}
+
+			skipped := game.NewLevelSkippedEvent(s, level)
+			skipped.At = s.StartedAt()
+			if s.Completed() {
+				skipped.At = s.FinishedAt()
+			}
+			events = append(events, skipped)
+		}
+	}
+
+	// Only the latest rating of each player is kept so each is replayed as if it was the first.
+	summaries := make(map[string]*game.RatingSummary)
+	for _, r := range ratings {
+		if _, ok := gamesByUUID[r.GameUUID()]; !ok {
+			continue
+		}
+
+		summary, ok := summaries[r.GameUUID()]
+		if !ok {
+			summary = &game.RatingSummary{}
+			summaries[r.GameUUID()] = summary
+		}
+
+		summary.Rate(r, nil)
+		events = append(events, game.NewGameRatedEvent(r, nil, *summary))
+	}
+
+	// Resolved reports have already been dealt with and are only kept for the record.
+	for _, r := range reports {
+		if _, ok := gamesByUUID[r.GameUUID()]; ok {
+			events = append(events, game.NewGameReportedEvent(r))
+		}
+	}
+
+	return h.rebuilder.Publish(ctx, events...)
+}
@@ -0,0 +1,146 @@
+package command
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/games/adapters"
+	"gopher-cache/internal/games/app/query"
+	"gopher-cache/internal/games/domain/game"
+	"testing"
+)
+
+func TestRebuildProjectionsHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	repo := adapters.NewMemoryGameRepository()
+	projections := adapters.NewMemoryProjectionRepository()
+	projector := query.NewProjector(projections)
+
+	userID, err := uuid.NewRandom()
+	require.NoError(t, err)
+
+	user, err := game.NewUser(userID.String(), "15734497033")
+	require.NoError(t, err)
+
+	createGame := CreateGame{
+		Creator:     user,
+		Title:       "An Awesome Game",
+		Description: "This is an awesome game",
+		Levels: []GameLevel{
+			{
+				Title:       "Level One",
+				Description: "This is Level One",
+				Clues:       []string{"Level One Clue One"},
+				Answers:     []string{"Level One is the best"},
+			},
+			{
+				Title:       "Level Two",
+				Description: "This is Level Two",
+				Clues:       []string{"Level Two Clue One"},
+				Answers:     []string{"Level Two is the best"},
+			},
+		},
+		Ending:  "The end",
+		Kind:    "urban",
+		City:    "Austin",
+		State:   "Texas",
+		Country: "USA",
+	}
+
+	err = NewCreateGameHandler(repo, projector, adapters.NewRuleModerator()).Handle(ctx, createGame)
+	require.NoError(t, err)
+
+	games, err := projections.ReadGames(ctx, query.GameQuery{}, query.Page{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(games))
+
+	verifyTestNumber(t, repo, user)
+
+	_, err = NewCreateGameStateHandler(repo, projector).Handle(ctx, CreateGameState{
+		User:     user,
+		GameUUID: games[0].UUID,
+	})
+	require.NoError(t, err)
+
+	// The first level is answered and the last is skipped.
+	_, err = NewUpdateGameStateHandler(repo, projector).Handle(ctx, UpdateGameState{
+		User:         user,
+		PlayerNumber: user.Number(),
+		Input:        createGame.Levels[0].Answers[0],
+	})
+	require.NoError(t, err)
+
+	_, err = NewSkipLevelHandler(repo, projector).Handle(ctx, SkipLevel{User: user, PlayerNumber: user.Number()})
+	require.NoError(t, err)
+
+	err = NewRateGameHandler(repo, projector).Handle(ctx, RateGame{Rater: user, GameUUID: games[0].UUID, Stars: 4})
+	require.NoError(t, err)
+
+	expectedGame, err := projections.GetGameProjection(ctx, games[0].UUID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, expectedGame.PlayCount)
+	assert.Equal(t, 1, expectedGame.CompletionCount)
+	assert.Equal(t, 1, expectedGame.RatingCount)
+
+	expectedPlayer, err := projections.ReadPlayer(ctx, user.UUID())
+	require.NoError(t, err)
+	assert.Equal(t, 1, expectedPlayer.GamesFinished)
+
+	p, err := repo.GetPlayer(ctx, user.UUID())
+	require.NoError(t, err)
+
+	expectedState, err := projections.ReadState(ctx, p.CurrentGameStateUUID())
+	require.NoError(t, err)
+
+	expectedAnalytics, err := projections.ReadGameAnalytics(ctx, games[0].UUID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, expectedAnalytics.Levels[1].PlayersReached)
+	assert.Equal(t, 1, expectedAnalytics.Levels[1].PlayersSkipped)
+
+	// Throw away the projections and make sure they come back the same.
+	require.NoError(t, projector.Reset(ctx))
+
+	rebuild := NewRebuildProjectionsHandler(repo, projector)
+
+	// Only admins may rebuild projections.
+	err = rebuild.Handle(ctx, RebuildProjections{User: user})
+	assert.Equal(t, errors.ErrorTypeAuthorization, err.(errors.SlugError).ErrorType())
+
+	admin, err := game.NewUserWithRoles(userID.String(), "15734497033", "Admin", game.RoleAdmin)
+	require.NoError(t, err)
+
+	err = rebuild.Handle(ctx, RebuildProjections{User: admin})
+	require.NoError(t, err)
+
+	gotGame, err := projections.GetGameProjection(ctx, games[0].UUID)
+	require.NoError(t, err)
+	assert.Equal(t, expectedGame, gotGame)
+
+	gotPlayer, err := projections.ReadPlayer(ctx, user.UUID())
+	require.NoError(t, err)
+	assert.Equal(t, expectedPlayer.GamesStarted, gotPlayer.GamesStarted)
+	assert.Equal(t, expectedPlayer.GamesFinished, gotPlayer.GamesFinished)
+	assert.Equal(t, expectedPlayer.TotalPoints, gotPlayer.TotalPoints)
+	assert.Equal(t, len(expectedPlayer.History), len(gotPlayer.History))
+
+	gotState, err := projections.ReadState(ctx, p.CurrentGameStateUUID())
+	require.NoError(t, err)
+	assert.Equal(t, expectedState.CurrentResponse, gotState.CurrentResponse)
+	assert.Equal(t, expectedState.Completed, gotState.Completed)
+
+	gotAnalytics, err := projections.ReadGameAnalytics(ctx, games[0].UUID)
+	require.NoError(t, err)
+	assert.Equal(t, expectedAnalytics, gotAnalytics)
+
+	reviews, err := projections.ReadGameReviews(ctx, games[0].UUID, query.Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(reviews))
+
+	// One of each event for the game and state plus the attempt, the skip and the rating.
+	lag, err := projections.ReadProjectionLag(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 7, lag.Events)
+}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"context"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// RepeatResponse represents the command input for sending a player the last response of their game again.
+type RepeatResponse struct {
+	// User is who is asking for the response. Only the player or a user who can manage players may.
+	User game.User `json:"-"`
+	// PlayerNumber may be in any format, e.g. "+1 (573) 449-7033" or "15734497033".
+	PlayerNumber string `json:"-"`
+}
+
+// RepeatResponseHandler handles repeating responses.
+type RepeatResponseHandler struct {
+	repo game.Repository
+}
+
+// NewRepeatResponseHandler creates a new handler.
+func NewRepeatResponseHandler(repo game.Repository) RepeatResponseHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	return RepeatResponseHandler{repo: repo}
+}
+
+// Handle handles the use case of a player asking for the last response again, e.g. after losing the
+// message. The game state isn't changed.
+func (h RepeatResponseHandler) Handle(ctx context.Context, cmd RepeatResponse) (resp *game.Response, err error) {
+	defer func() {
+		logs.LogCommandExecution("RepeatResponse", cmd, err)
+	}()
+
+	p, err := messagedPlayer(ctx, h.repo, cmd.User, cmd.PlayerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	s, _, err := currentGame(ctx, h.repo, p)
+	if err != nil {
+		return nil, err
+	}
+
+	current := s.CurrentResponse()
+
+	return &current, nil
+}
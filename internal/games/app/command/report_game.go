@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// ReportGame represents the command input for a player reporting abuse in a game.
+// All fields are required unless specified otherwise.
+type ReportGame struct {
+	Reporter game.User `json:"-"`
+	GameUUID string    `json:"-"`
+	// Reason is one of spam, offensive, personal-information or other.
+	Reason string `json:"reason"`
+	// Details is optional unless the reason is other.
+	Details string `json:"details"`
+}
+
+// ReportGameHandler handles reporting games.
+type ReportGameHandler struct {
+	repo      game.Repository
+	publisher EventPublisher
+}
+
+// NewReportGameHandler creates a new handler.
+func NewReportGameHandler(repo game.Repository, publisher EventPublisher) ReportGameHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	if publisher == nil {
+		panic("nil publisher")
+	}
+
+	return ReportGameHandler{repo: repo, publisher: publisher}
+}
+
+// Handle handles the use case of reporting a game. The report is queued for a moderator to review.
+func (h ReportGameHandler) Handle(ctx context.Context, cmd ReportGame) (err error) {
+	defer func() {
+		logs.LogCommandExecution("ReportGame", cmd, err)
+	}()
+
+	if err := authorize(cmd.Reporter, game.PermissionPlayGames); err != nil {
+		return err
+	}
+
+	g, err := h.repo.GetGame(ctx, cmd.GameUUID)
+	if err != nil {
+		return err
+	}
+
+	report, err := game.NewReport(cmd.Reporter, g.UUID(), game.ReportReason(cmd.Reason), cmd.Details)
+	if err != nil {
+		return err
+	}
+
+	if err := h.repo.AddReport(ctx, report); err != nil {
+		return err
+	}
+
+	publish(ctx, h.publisher, game.NewGameReportedEvent(report))
+
+	return nil
+}
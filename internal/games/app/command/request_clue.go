@@ -0,0 +1,83 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+	"time"
+)
+
+// RequestClue represents the command input for revealing the next clue of a player's current level.
+type RequestClue struct {
+	// User is who is requesting the clue. Only the player or a user who can manage players may.
+	User game.User `json:"-"`
+	// PlayerNumber may be in any format, e.g. "+1 (573) 449-7033" or "15734497033".
+	PlayerNumber string `json:"-"`
+	// AcceptLanguage is optional. It is the Accept-Language of the request, which is used to choose the
+	// locale of the response if the player hasn't chosen one.
+	AcceptLanguage string `json:"-"`
+}
+
+// RequestClueHandler handles requesting clues.
+type RequestClueHandler struct {
+	repo      game.Repository
+	publisher EventPublisher
+}
+
+// NewRequestClueHandler creates a new handler.
+func NewRequestClueHandler(repo game.Repository, publisher EventPublisher) RequestClueHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	if publisher == nil {
+		panic("nil publisher")
+	}
+
+	return RequestClueHandler{repo: repo, publisher: publisher}
+}
+
+// Handle handles the use case of a player asking for the next clue. Unlike a wrong answer it isn't recorded
+// as an attempt. A conflict error with the slug clue-cooldown is returned if the game makes the player wait
+// for the next clue.
+func (h RequestClueHandler) Handle(ctx context.Context, cmd RequestClue) (resp *game.Response, err error) {
+	defer func() {
+		logs.LogCommandExecution("RequestClue", cmd, err)
+	}()
+
+	p, err := messagedPlayer(ctx, h.repo, cmd.User, cmd.PlayerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	s, g, err := currentGame(ctx, h.repo, p)
+	if err != nil {
+		return nil, err
+	}
+
+	// A finished game only has its ending to give.
+	if s.Completed() {
+		return s.RequestClue(localize(g, p, cmd.AcceptLanguage))
+	}
+
+	resp, err = s.RequestClue(localize(g, p, cmd.AcceptLanguage))
+	if stderrors.Is(err, game.ErrorClueCooldown) {
+		wait := time.Until(s.NextClueAt(g)).Round(time.Second)
+		return nil, errors.NewConflictError(fmt.Sprintf("the next clue is available in %s", wait), "clue-cooldown")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repo.UpdateState(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	publish(ctx, h.publisher, game.NewStateUpdatedEvent(s))
+
+	return resp, nil
+}
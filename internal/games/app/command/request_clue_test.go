@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/games/adapters"
+	"gopher-cache/internal/games/app/query"
+	"gopher-cache/internal/games/domain/game"
+	"testing"
+)
+
+func TestRequestClueHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	repo := adapters.NewMemoryGameRepository()
+	projector := query.NewProjector(adapters.NewMemoryProjectionRepository())
+
+	user, err := game.NewUser("player", "15734497033")
+	require.NoError(t, err)
+
+	wrongAnswersRevealClues := false
+
+	err = NewCreateGameHandler(repo, projector, adapters.NewRuleModerator()).Handle(ctx, CreateGame{
+		Creator:     user,
+		Title:       "An Awesome Game",
+		Description: "This is an awesome game",
+		Levels: []GameLevel{
+			{
+				Title:       "Level One",
+				Description: "This is Level One",
+				Clues:       []string{"Level One Clue One", "Level One Clue Two"},
+				Answers:     []string{"Level One is the best"},
+			},
+		},
+		Ending:  "The end",
+		Kind:    "urban",
+		City:    "Austin",
+		State:   "Texas",
+		Country: "USA",
+		Clues: &ClueSettings{
+			WrongAnswersRevealClues: &wrongAnswersRevealClues,
+			CooldownSeconds:         60,
+		},
+	})
+	require.NoError(t, err)
+
+	games, err := repo.AllGames(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(games))
+
+	verifyTestNumber(t, repo, user)
+
+	_, err = NewCreateGameStateHandler(repo, projector).Handle(ctx, CreateGameState{User: user, GameUUID: games[0].UUID()})
+	require.NoError(t, err)
+
+	resp, err := NewUpdateGameStateHandler(repo, projector).Handle(ctx, UpdateGameState{
+		User:         user,
+		PlayerNumber: user.Number(),
+		Input:        "wrong answer",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, game.IncorrectResponse, resp.Kind)
+
+	handler := NewRequestClueHandler(repo, projector)
+	cmd := RequestClue{User: user, PlayerNumber: user.Number()}
+
+	resp, err = handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "Level One Clue One", resp.Clue)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.Error(t, err)
+	assert.Equal(t, "clue-cooldown", err.(errors.SlugError).Slug())
+	assert.Equal(t, errors.ErrorTypeConflict, err.(errors.SlugError).ErrorType())
+
+	other, err := game.NewUser("other", "15734497034")
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, RequestClue{User: other, PlayerNumber: user.Number()})
+	assert.Equal(t, ErrorNotPlayer, err)
+
+	// Only the wrong answer is an attempt.
+	attempts, err := repo.AllAttempts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(attempts))
+}
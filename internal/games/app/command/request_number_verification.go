@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+	"time"
+)
+
+// RequestNumberVerification represents the command input for sending a verification code to the user's
+// number.
+type RequestNumberVerification struct {
+	User game.User `json:"-"`
+	// AcceptLanguage is optional. It is the Accept-Language of the request, which is used to choose the
+	// locale of the response if the player hasn't chosen one.
+	AcceptLanguage string `json:"-"`
+}
+
+// SMSSender is the interface used for sending text messages.
+type SMSSender interface {
+	SendSMS(ctx context.Context, number, message string) error
+}
+
+// RequestNumberVerificationHandler handles sending verification codes.
+type RequestNumberVerificationHandler struct {
+	repo game.Repository
+	sms  SMSSender
+}
+
+// NewRequestNumberVerificationHandler creates a new handler.
+func NewRequestNumberVerificationHandler(repo game.Repository, sms SMSSender) RequestNumberVerificationHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	if sms == nil {
+		panic("nil sms")
+	}
+
+	return RequestNumberVerificationHandler{repo: repo, sms: sms}
+}
+
+// Handle handles the use case of sending a one-time code to the user's number, which VerifyNumber checks.
+// A player is created for the user if they don't have one yet.
+func (h RequestNumberVerificationHandler) Handle(ctx context.Context, cmd RequestNumberVerification) (err error) {
+	defer func() {
+		logs.LogCommandExecution("RequestNumberVerification", cmd, err)
+	}()
+
+	if err := authorize(cmd.User, game.PermissionPlayGames); err != nil {
+		return err
+	}
+
+	p, err := h.repo.GetPlayer(ctx, cmd.User.UUID())
+	if errors.Is(err, game.ErrorPlayerNotFound) {
+		p, err = game.NewPlayerFromUser(cmd.User)
+		if err != nil {
+			return err
+		}
+
+		err = h.repo.AddPlayer(ctx, p)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := suppressMessage(p, "verification-code"); err != nil {
+		return err
+	}
+
+	code, err := game.NewVerificationCode()
+	if err != nil {
+		return err
+	}
+
+	if err := p.StartNumberVerification(code, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	// The code is saved before it is sent so a code is never received that can't be verified.
+	err = h.repo.UpdatePlayer(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	return h.sms.SendSMS(ctx, p.Number(), game.VerificationCodeMessage(code, messageLocale(p, cmd.AcceptLanguage)))
+}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"context"
+	"gopher-cache/internal/common/auth"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+	"time"
+)
+
+// RevokeAPIKey represents the command input for revoking an API key.
+type RevokeAPIKey struct {
+	Admin game.User `json:"-"`
+	ID    string    `json:"-"`
+}
+
+var ErrorAPIKeyNotFound = errors.NewNotFoundError("api key not found", "api-key-not-found")
+
+// RevokeAPIKeyHandler handles revoking API keys.
+type RevokeAPIKeyHandler struct {
+	repo APIKeyRepository
+}
+
+// NewRevokeAPIKeyHandler creates a new handler.
+func NewRevokeAPIKeyHandler(repo APIKeyRepository) RevokeAPIKeyHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	return RevokeAPIKeyHandler{repo: repo}
+}
+
+// Handle handles the use case of revoking an API key. Requests made with the key are rejected from
+// then on. ErrorAPIKeyNotFound is returned if there is no such key.
+func (h RevokeAPIKeyHandler) Handle(ctx context.Context, cmd RevokeAPIKey) (err error) {
+	defer func() {
+		logs.LogCommandExecution("RevokeAPIKey", cmd, err)
+	}()
+
+	if err := authorize(cmd.Admin, game.PermissionManageAPIKeys); err != nil {
+		return err
+	}
+
+	err = h.repo.RevokeAPIKey(ctx, cmd.ID, time.Now().UTC())
+	if err == auth.ErrorAPIKeyNotFound {
+		return ErrorAPIKeyNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,62 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// SetPlayerLocale represents the command input for choosing the locale games and messages are sent to the
+// user in.
+type SetPlayerLocale struct {
+	User game.User `json:"-"`
+	// Locale is a BCP 47 tag such as es or es-MX. An empty locale removes the player's choice so the
+	// Accept-Language of requests is used instead.
+	Locale string `json:"locale"`
+}
+
+// SetPlayerLocaleHandler handles setting players' locales.
+type SetPlayerLocaleHandler struct {
+	repo game.Repository
+}
+
+// NewSetPlayerLocaleHandler creates a new handler.
+func NewSetPlayerLocaleHandler(repo game.Repository) SetPlayerLocaleHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	return SetPlayerLocaleHandler{repo: repo}
+}
+
+// Handle handles the use case of a player choosing their locale. A player is created for the user if they
+// don't have one yet.
+func (h SetPlayerLocaleHandler) Handle(ctx context.Context, cmd SetPlayerLocale) (err error) {
+	defer func() {
+		logs.LogCommandExecution("SetPlayerLocale", cmd, err)
+	}()
+
+	if err := authorize(cmd.User, game.PermissionPlayGames); err != nil {
+		return err
+	}
+
+	p, err := h.repo.GetPlayer(ctx, cmd.User.UUID())
+	if errors.Is(err, game.ErrorPlayerNotFound) {
+		p, err = game.NewPlayerFromUser(cmd.User)
+		if err != nil {
+			return err
+		}
+
+		err = h.repo.AddPlayer(ctx, p)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := p.SetLocale(cmd.Locale); err != nil {
+		return err
+	}
+
+	return h.repo.UpdatePlayer(ctx, p)
+}
@@ -0,0 +1,118 @@
+package command
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/games/adapters"
+	"gopher-cache/internal/games/app/query"
+	"gopher-cache/internal/games/domain/game"
+	"testing"
+)
+
+func TestSetPlayerLocaleHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	repo := adapters.NewMemoryGameRepository()
+	projector := query.NewProjector(adapters.NewMemoryProjectionRepository())
+
+	user, err := game.NewUser("player", "15734497033")
+	require.NoError(t, err)
+
+	err = NewCreateGameHandler(repo, projector, adapters.NewRuleModerator()).Handle(ctx, CreateGame{
+		Creator:     user,
+		Title:       "An Awesome Game",
+		Description: "This is an awesome game",
+		Levels: []GameLevel{
+			{
+				Title:       "Level One",
+				Description: "This is Level One",
+				Clues:       []string{"Level One Clue One"},
+				Answers:     []string{"Level One is the best"},
+			},
+		},
+		Ending:  "The end",
+		Kind:    "urban",
+		City:    "Austin",
+		State:   "Texas",
+		Country: "USA",
+		Translations: []GameTranslation{
+			{
+				Locale:      "es",
+				Title:       "Un juego increíble",
+				Description: "Este es un juego increíble",
+				Ending:      "El fin",
+				Levels: []LevelTranslation{
+					{
+						Title:       "Nivel uno",
+						Description: "Este es el nivel uno",
+						Clues:       []string{"Pista uno del nivel uno"},
+						Answers:     []string{"El nivel uno es el mejor"},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	games, err := repo.AllGames(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(games))
+	assert.Equal(t, []string{"en", "es"}, games[0].Locales())
+
+	verifyTestNumber(t, repo, user)
+
+	// Without a preference the Accept-Language is used.
+	resp, err := NewCreateGameStateHandler(repo, projector).Handle(ctx, CreateGameState{
+		User:           user,
+		GameUUID:       games[0].UUID(),
+		AcceptLanguage: "es-MX,es;q=0.9",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Nivel uno", resp.LevelTitle)
+
+	handler := NewSetPlayerLocaleHandler(repo)
+
+	err = handler.Handle(ctx, SetPlayerLocale{User: user, Locale: "not a locale"})
+	assert.Equal(t, game.ErrorInvalidLocale, err)
+
+	err = handler.Handle(ctx, SetPlayerLocale{User: user, Locale: "EN"})
+	require.NoError(t, err)
+
+	p, err := repo.GetPlayer(ctx, user.UUID())
+	require.NoError(t, err)
+	assert.Equal(t, "en", p.Locale())
+
+	update := NewUpdateGameStateHandler(repo, projector)
+
+	// The player's preference wins over the Accept-Language.
+	resp, err = update.Handle(ctx, UpdateGameState{
+		User:           user,
+		PlayerNumber:   user.Number(),
+		Input:          "wrong answer",
+		AcceptLanguage: "es",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Level One Clue One", resp.Clue)
+
+	err = handler.Handle(ctx, SetPlayerLocale{User: user})
+	require.NoError(t, err)
+
+	resp, err = update.Handle(ctx, UpdateGameState{
+		User:           user,
+		PlayerNumber:   user.Number(),
+		Input:          "help",
+		AcceptLanguage: "fr",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, resp.Message, "Envoyez votre réponse")
+
+	resp, err = update.Handle(ctx, UpdateGameState{
+		User:           user,
+		PlayerNumber:   user.Number(),
+		Input:          "El nivel uno es el mejor",
+		AcceptLanguage: "es",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "El fin", resp.EndMessage)
+}
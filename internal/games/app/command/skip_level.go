@@ -0,0 +1,82 @@
+package command
+
+import (
+	"context"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// SkipLevel represents the command input for skipping a player's current level.
+type SkipLevel struct {
+	// User is who is skipping the level. Only the player or a user who can manage players may.
+	User game.User `json:"-"`
+	// PlayerNumber may be in any format, e.g. "+1 (573) 449-7033" or "15734497033".
+	PlayerNumber string `json:"-"`
+	// AcceptLanguage is optional. It is the Accept-Language of the request, which is used to choose the
+	// locale of the response if the player hasn't chosen one.
+	AcceptLanguage string `json:"-"`
+}
+
+// SkipLevelHandler handles skipping levels.
+type SkipLevelHandler struct {
+	repo      game.Repository
+	publisher EventPublisher
+}
+
+// NewSkipLevelHandler creates a new handler.
+func NewSkipLevelHandler(repo game.Repository, publisher EventPublisher) SkipLevelHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	if publisher == nil {
+		panic("nil publisher")
+	}
+
+	return SkipLevelHandler{repo: repo, publisher: publisher}
+}
+
+// Handle handles the use case of a player moving on to the next level without answering the current one.
+// A game finished by skipping its last level doesn't give the player its points.
+func (h SkipLevelHandler) Handle(ctx context.Context, cmd SkipLevel) (resp *game.Response, err error) {
+	defer func() {
+		logs.LogCommandExecution("SkipLevel", cmd, err)
+	}()
+
+	p, err := messagedPlayer(ctx, h.repo, cmd.User, cmd.PlayerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	s, g, err := currentGame(ctx, h.repo, p)
+	if err != nil {
+		return nil, err
+	}
+
+	// A finished game only has its ending to give.
+	if s.Completed() {
+		return s.Skip(localize(g, p, cmd.AcceptLanguage), p)
+	}
+
+	level := s.Level()
+
+	resp, err = s.Skip(localize(g, p, cmd.AcceptLanguage), p)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repo.UpdateStateAndPlayer(ctx, s, p)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []game.Event{game.NewStateUpdatedEvent(s), game.NewLevelSkippedEvent(s, level)}
+
+	if s.Completed() {
+		events = append(events, game.NewGameFinishedEvent(g, s))
+	}
+
+	publish(ctx, h.publisher, events...)
+
+	return resp, nil
+}
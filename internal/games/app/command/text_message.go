@@ -0,0 +1,68 @@
+package command
+
+import (
+	"context"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// TextMessageHandler handles everything a player texts. Text commands such as HINT are dispatched to their
+// own handler and everything else, including messaging keywords such as STOP, is handled as an answer by
+// UpdateGameStateHandler.
+type TextMessageHandler struct {
+	aliases game.TextCommandAliases
+
+	updateGameState UpdateGameStateHandler
+	requestClue     RequestClueHandler
+	repeatResponse  RepeatResponseHandler
+	readGameStatus  ReadGameStatusHandler
+	skipLevel       SkipLevelHandler
+	quitGame        QuitGameHandler
+	listGames       ListGamesHandler
+}
+
+// NewTextMessageHandler creates a new handler that recognizes text commands by their aliases.
+func NewTextMessageHandler(
+	repo game.Repository,
+	publisher EventPublisher,
+	games GameLister,
+	aliases game.TextCommandAliases) TextMessageHandler {
+	return TextMessageHandler{
+		aliases:         aliases,
+		updateGameState: NewUpdateGameStateHandler(repo, publisher),
+		requestClue:     NewRequestClueHandler(repo, publisher),
+		repeatResponse:  NewRepeatResponseHandler(repo),
+		readGameStatus:  NewReadGameStatusHandler(repo),
+		skipLevel:       NewSkipLevelHandler(repo, publisher),
+		quitGame:        NewQuitGameHandler(repo, publisher),
+		listGames:       NewListGamesHandler(repo, games),
+	}
+}
+
+// Handle handles the use case of a player texting the game. Aliases can't be messaging keywords so those
+// always reach UpdateGameStateHandler to be honoured.
+func (h TextMessageHandler) Handle(ctx context.Context, cmd UpdateGameState) (*game.Response, error) {
+	command, ok := h.aliases.Parse(cmd.Input)
+	if !ok {
+		return h.updateGameState.Handle(ctx, cmd)
+	}
+
+	user, number, acceptLanguage := cmd.User, cmd.PlayerNumber, cmd.AcceptLanguage
+
+	switch command {
+	case game.TextCommandHint:
+		return h.requestClue.Handle(ctx, RequestClue{User: user, PlayerNumber: number, AcceptLanguage: acceptLanguage})
+	case game.TextCommandRepeat:
+		return h.repeatResponse.Handle(ctx, RepeatResponse{User: user, PlayerNumber: number})
+	case game.TextCommandStatus:
+		return h.readGameStatus.Handle(ctx, ReadGameStatus{User: user, PlayerNumber: number, AcceptLanguage: acceptLanguage})
+	case game.TextCommandSkip:
+		return h.skipLevel.Handle(ctx, SkipLevel{User: user, PlayerNumber: number, AcceptLanguage: acceptLanguage})
+	case game.TextCommandQuit:
+		return h.quitGame.Handle(ctx, QuitGame{User: user, PlayerNumber: number, AcceptLanguage: acceptLanguage})
+	case game.TextCommandGames:
+		return h.listGames.Handle(ctx, ListGames{User: user, PlayerNumber: number, AcceptLanguage: acceptLanguage})
+	}
+
+	// The aliases only contain known commands so this is only reached if a command isn't dispatched above.
+	return h.updateGameState.Handle(ctx, cmd)
+}
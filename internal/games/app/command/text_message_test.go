@@ -0,0 +1,157 @@
+package command
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/games/adapters"
+	"gopher-cache/internal/games/app/query"
+	"gopher-cache/internal/games/domain/game"
+	"testing"
+)
+
+func TestTextMessageHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	repo := adapters.NewMemoryGameRepository()
+	projectionRepo := adapters.NewMemoryProjectionRepository()
+	projector := query.NewProjector(projectionRepo)
+	games := query.NewReadGamesHandler(projectionRepo, query.NewCursorSigner([]byte("key")))
+
+	aliases, err := game.NewTextCommandAliases(game.DefaultTextCommandAliases)
+	require.NoError(t, err)
+
+	handler := NewTextMessageHandler(repo, projector, games, aliases)
+
+	user, err := game.NewUser("player", "15734497033")
+	require.NoError(t, err)
+
+	createGame := CreateGame{
+		Creator:     user,
+		Title:       "An Awesome Game",
+		Description: "This is an awesome game",
+		Levels: []GameLevel{
+			{
+				Title:       "Level One",
+				Description: "This is Level One",
+				Clues:       []string{"Level One Clue One", "Level One Clue Two"},
+				Answers:     []string{"Level One is the best"},
+			},
+			{
+				Title:       "Level Two",
+				Description: "This is Level Two",
+				Clues:       []string{"Level Two Clue One"},
+				Answers:     []string{"Level Two is the best"},
+			},
+		},
+		Ending:  "The end",
+		Kind:    "urban",
+		City:    "Austin",
+		State:   "Texas",
+		Country: "USA",
+	}
+
+	err = NewCreateGameHandler(repo, projector, adapters.NewRuleModerator()).Handle(ctx, createGame)
+	require.NoError(t, err)
+
+	all, err := repo.AllGames(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(all))
+
+	text := func(input string) (*game.Response, error) {
+		return handler.Handle(ctx, UpdateGameState{User: user, PlayerNumber: user.Number(), Input: input})
+	}
+
+	verifyTestNumber(t, repo, user)
+
+	// The player hasn't started a game yet.
+	_, err = text("hint")
+	assert.Equal(t, game.ErrorNoCurrentGame, err)
+
+	resp, err := text("games")
+	require.NoError(t, err)
+	assert.Equal(t, "Gopher Cache games: An Awesome Game.", resp.Message)
+
+	_, err = NewCreateGameStateHandler(repo, projector).Handle(ctx, CreateGameState{User: user, GameUUID: all[0].UUID()})
+	require.NoError(t, err)
+
+	resp, err = text("HINT")
+	require.NoError(t, err)
+	assert.Equal(t, "Level One Clue One", resp.Clue)
+
+	resp, err = text("pista")
+	require.NoError(t, err)
+	assert.Equal(t, "Level One Clue Two", resp.Clue)
+
+	resp, err = text("repeat")
+	require.NoError(t, err)
+	assert.Equal(t, "Level One Clue Two", resp.Clue)
+
+	resp, err = text("status")
+	require.NoError(t, err)
+	assert.Equal(t, "Gopher Cache: You are on level 1 of 2 of An Awesome Game with 2 of 2 clues revealed.", resp.Message)
+
+	// Hints aren't attempts.
+	attempts, err := repo.AllAttempts(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, attempts)
+
+	resp, err = text("skip")
+	require.NoError(t, err)
+	assert.Equal(t, game.LevelResponse, resp.Kind)
+	assert.Equal(t, "Level Two", resp.LevelTitle)
+
+	// Messaging keywords are still honoured.
+	resp, err = text("help")
+	require.NoError(t, err)
+	assert.Equal(t, game.HelpMessage, resp.Message)
+
+	resp, err = text("Level Two is the best")
+	require.NoError(t, err)
+	assert.Equal(t, game.EndResponse, resp.Kind)
+
+	p, err := repo.GetPlayer(ctx, user.UUID())
+	require.NoError(t, err)
+	assert.Equal(t, 1, p.GamesFinished())
+	assert.Equal(t, 0, p.TotalPoints())
+
+	resp, err = text("leave")
+	require.NoError(t, err)
+	assert.Equal(t, game.MessageResponse, resp.Kind)
+
+	_, err = text("status")
+	assert.Equal(t, game.ErrorNoCurrentGame, err)
+
+	_, err = text("Level One is the best")
+	assert.Equal(t, game.ErrorNoCurrentGame, err)
+}
+
+func TestTextMessageHandler_HandleOptedOut(t *testing.T) {
+	ctx := context.Background()
+
+	repo := adapters.NewMemoryGameRepository()
+	projectionRepo := adapters.NewMemoryProjectionRepository()
+	games := query.NewReadGamesHandler(projectionRepo, query.NewCursorSigner([]byte("key")))
+
+	aliases, err := game.NewTextCommandAliases(game.DefaultTextCommandAliases)
+	require.NoError(t, err)
+
+	handler := NewTextMessageHandler(repo, query.NewProjector(projectionRepo), games, aliases)
+
+	user, err := game.NewUser("player", "15734497033")
+	require.NoError(t, err)
+
+	text := func(input string) (*game.Response, error) {
+		return handler.Handle(ctx, UpdateGameState{User: user, PlayerNumber: user.Number(), Input: input})
+	}
+
+	verifyTestNumber(t, repo, user)
+
+	_, err = text("QUIT")
+	require.NoError(t, err)
+
+	for _, input := range []string{"hint", "repeat", "status", "skip", "leave", "games"} {
+		_, err = text(input)
+		assert.Equal(t, ErrorNumberOptedOut, err, input)
+	}
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"gopher-cache/internal/common/errors"
 	"gopher-cache/internal/common/logs"
 	"gopher-cache/internal/games/domain/game"
 )
@@ -9,54 +10,100 @@ import (
 // UpdateGameState represents the command input for updating a game state.
 // All fields are required unless specified otherwise.
 type UpdateGameState struct {
+	// User is who is submitting the input. Only the player or a user who can manage players may.
+	User game.User `json:"-"`
+	// PlayerNumber may be in any format, e.g. "+1 (573) 449-7033" or "15734497033".
 	PlayerNumber string `json:"-"`
 	Input        string `json:"input"`
+	// AcceptLanguage is optional. It is the Accept-Language of the request, which is used to choose the
+	// locale of the response if the player hasn't chosen one.
+	AcceptLanguage string `json:"-"`
 }
 
+var (
+	ErrorInvalidNumber     = errors.NewIncorrectInputError("invalid phone number", "invalid-number")
+	ErrorNumberNotVerified = errors.NewAuthorizationError(
+		"the player's number must be verified before they can receive game messages", "number-not-verified")
+)
+
 // UpdateGameStateHandler handles updating the game state.
 type UpdateGameStateHandler struct {
-	repo game.Repository
+	repo      game.Repository
+	publisher EventPublisher
 }
 
 // NewUpdateGameStateHandler creates a new handler.
-func NewUpdateGameStateHandler(repo game.Repository) UpdateGameStateHandler {
+func NewUpdateGameStateHandler(repo game.Repository, publisher EventPublisher) UpdateGameStateHandler {
 	if repo == nil {
 		panic("nil repo")
 	}
 
-	return UpdateGameStateHandler{repo: repo}
+	if publisher == nil {
+		panic("nil publisher")
+	}
+
+	return UpdateGameStateHandler{repo: repo, publisher: publisher}
 }
 
-// Handle handles the use case of updating an existing game state.
+// Handle handles the use case of updating an existing game state. Messaging keywords such as STOP are
+// handled instead of being used as an answer.
 func (h UpdateGameStateHandler) Handle(ctx context.Context, cmd UpdateGameState) (resp *game.Response, err error) {
 	defer func() {
 		logs.LogCommandExecution("UpdateGameState", cmd, err)
 	}()
 
-	p, err := h.repo.GetPlayerByNumber(ctx, cmd.PlayerNumber)
+	p, err := playerByNumber(ctx, h.repo, cmd.User, cmd.PlayerNumber)
 	if err != nil {
 		return nil, err
 	}
 
-	s, err := h.repo.GetState(ctx, p.CurrentGameStateUUID())
-	if err != nil {
-		return nil, err
+	if resp, ok, err := handleMessagingKeyword(ctx, h.repo, p, cmd.Input, cmd.AcceptLanguage); ok {
+		return resp, err
 	}
 
-	g, err := h.repo.GetGame(ctx, s.GameUUID())
-	if err != nil {
+	if err := checkGameMessage(p); err != nil {
 		return nil, err
 	}
 
-	resp, err = s.Update(g, cmd.Input, p)
+	s, g, err := currentGame(ctx, h.repo, p)
 	if err != nil {
 		return nil, err
 	}
 
-	err = h.repo.UpdateStateAndPlayer(ctx, s, p)
+	before := *s
+
+	resp, err = s.Update(localize(g, p, cmd.AcceptLanguage), cmd.Input, p)
 	if err != nil {
 		return nil, err
 	}
 
+	events := []game.Event{game.NewStateUpdatedEvent(s)}
+
+	// Inputs after a game has been completed aren't attempts at any level.
+	if before.Completed() {
+		err = h.repo.UpdateStateAndPlayer(ctx, s, p)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		attempt, err := game.NewAttempt(before, s, cmd.Input)
+		if err != nil {
+			return nil, err
+		}
+
+		err = h.repo.UpdateStateAndPlayerWithAttempt(ctx, s, p, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, game.NewAttemptRecordedEvent(attempt))
+	}
+
+	if s.Completed() && !before.Completed() {
+		events = append(events, game.NewGameFinishedEvent(g, s))
+	}
+
+	publish(ctx, h.publisher, events...)
+
 	return resp, err
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"gopher-cache/internal/common/emulators"
 	"gopher-cache/internal/games/adapters"
+	"gopher-cache/internal/games/app/query"
 	"gopher-cache/internal/games/domain/game"
 	"testing"
 )
@@ -25,7 +26,14 @@ func TestUpdateGameStateHandler_Handle(t *testing.T) {
 		panic(err)
 	}
 
-	createGameHandler := NewCreateGameHandler(repo)
+	projections, err := adapters.NewFirestoreProjectionRepository(client)
+	if err != nil {
+		panic(err)
+	}
+
+	projector := query.NewProjector(projections)
+
+	createGameHandler := NewCreateGameHandler(repo, projector, adapters.NewRuleModerator())
 
 	userID, err := uuid.NewRandom()
 	require.NoError(t, err)
@@ -69,9 +77,9 @@ func TestUpdateGameStateHandler_Handle(t *testing.T) {
 	err = createGameHandler.Handle(ctx, createGame)
 	require.NoError(t, err)
 
-	createGameStateHandler := NewCreateGameStateHandler(repo)
+	createGameStateHandler := NewCreateGameStateHandler(repo, projector)
 
-	games, err := repo.ReadGames(ctx, 10, 0)
+	games, err := projections.ReadGames(ctx, query.GameQuery{}, query.Page{Limit: 10})
 	require.NoError(t, err)
 	require.Equal(t, 1, len(games))
 
@@ -80,18 +88,22 @@ func TestUpdateGameStateHandler_Handle(t *testing.T) {
 		GameUUID: games[0].UUID,
 	}
 
+	verifyTestNumber(t, repo, user)
+
 	_, err = createGameStateHandler.Handle(ctx, createGameState)
 	require.NoError(t, err)
 
-	updateGameStateHandler := NewUpdateGameStateHandler(repo)
+	updateGameStateHandler := NewUpdateGameStateHandler(repo, projector)
 
 	_, err = updateGameStateHandler.Handle(ctx, UpdateGameState{
+		User:         user,
 		PlayerNumber: user.Number(),
 		Input:        createGame.Levels[0].Answers[0],
 	})
 	assert.NoError(t, err)
 
 	_, err = updateGameStateHandler.Handle(ctx, UpdateGameState{
+		User:         user,
 		PlayerNumber: user.Number(),
 		Input:        createGame.Levels[1].Answers[0],
 	})
@@ -101,6 +113,7 @@ func TestUpdateGameStateHandler_Handle(t *testing.T) {
 	require.NoError(t, err)
 
 	_, err = updateGameStateHandler.Handle(ctx, UpdateGameState{
+		User:         user,
 		PlayerNumber: user.Number(),
 		Input:        createGame.Levels[2].Answers[0],
 	})
@@ -114,6 +127,7 @@ func TestUpdateGameStateHandler_Handle(t *testing.T) {
 
 	// Make sure the player cannot run up a score by repeating the last answer.
 	_, err = updateGameStateHandler.Handle(ctx, UpdateGameState{
+		User:         user,
 		PlayerNumber: user.Number(),
 		Input:        createGame.Levels[2].Answers[0],
 	})
@@ -124,3 +138,111 @@ func TestUpdateGameStateHandler_Handle(t *testing.T) {
 
 	assert.Equal(t, playerAfter, playerAfter2)
 }
+
+func TestUpdateGameStateHandler_HandleOtherPlayer(t *testing.T) {
+	ctx := context.Background()
+
+	repo := adapters.NewMemoryGameRepository()
+	projector := query.NewProjector(adapters.NewMemoryProjectionRepository())
+
+	newUser := func(number string, roles ...game.Role) game.User {
+		id, err := uuid.NewRandom()
+		require.NoError(t, err)
+
+		if len(roles) == 0 {
+			u, err := game.NewUser(id.String(), number)
+			require.NoError(t, err)
+			return u
+		}
+
+		u, err := game.NewUserWithRoles(id.String(), number, "", roles...)
+		require.NoError(t, err)
+		return u
+	}
+
+	player := newUser("15734497033")
+	other := newUser("15734497034")
+	organizer := newUser("15734497035", game.RoleOrganizer)
+
+	createGame := CreateGame{
+		Creator:     player,
+		Title:       "An Awesome Game",
+		Description: "This is an awesome game",
+		Levels: []GameLevel{
+			{
+				Title:       "Level One",
+				Description: "This is Level One",
+				Clues:       []string{"Level One Clue One"},
+				Answers:     []string{"Level One is the best"},
+			},
+			{
+				Title:       "Level Two",
+				Description: "This is Level Two",
+				Clues:       []string{"Level Two Clue One"},
+				Answers:     []string{"Level Two is the best"},
+			},
+		},
+		Ending:  "The end",
+		Kind:    "urban",
+		City:    "Austin",
+		State:   "Texas",
+		Country: "USA",
+	}
+
+	err := NewCreateGameHandler(repo, projector, adapters.NewRuleModerator()).Handle(ctx, createGame)
+	require.NoError(t, err)
+
+	games, err := repo.AllGames(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(games))
+
+	createGameState := NewCreateGameStateHandler(repo, projector)
+
+	// The player can't start a game until their number is verified.
+	_, err = createGameState.Handle(ctx, CreateGameState{User: player, GameUUID: games[0].UUID()})
+	assert.Equal(t, ErrorNumberNotVerified, err)
+
+	verifyTestNumber(t, repo, player)
+
+	_, err = createGameState.Handle(ctx, CreateGameState{User: player, GameUUID: games[0].UUID()})
+	require.NoError(t, err)
+
+	p, err := repo.GetPlayerByNumber(ctx, player.Number())
+	require.NoError(t, err)
+
+	before, err := repo.GetState(ctx, p.CurrentGameStateUUID())
+	require.NoError(t, err)
+
+	handler := NewUpdateGameStateHandler(repo, projector)
+
+	// Another player can't submit answers for the player.
+	_, err = handler.Handle(ctx, UpdateGameState{
+		User:         other,
+		PlayerNumber: player.Number(),
+		Input:        createGame.Levels[0].Answers[0],
+	})
+	assert.Equal(t, ErrorNotPlayer, err)
+
+	s, err := repo.GetState(ctx, p.CurrentGameStateUUID())
+	require.NoError(t, err)
+	assert.Equal(t, before.Level(), s.Level())
+
+	// But an organizer can, with the number in any format.
+	_, err = handler.Handle(ctx, UpdateGameState{
+		User:         organizer,
+		PlayerNumber: "+1 (573) 449-7033",
+		Input:        createGame.Levels[0].Answers[0],
+	})
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, UpdateGameState{
+		User:         player,
+		PlayerNumber: player.Number(),
+		Input:        createGame.Levels[1].Answers[0],
+	})
+	require.NoError(t, err)
+
+	s, err = repo.GetState(ctx, p.CurrentGameStateUUID())
+	require.NoError(t, err)
+	assert.True(t, s.Completed())
+}
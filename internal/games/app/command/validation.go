@@ -0,0 +1,22 @@
+package command
+
+import (
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// incorrectGameInput returns err as an incorrect input error listing the fields that broke the rules of a
+// game if it is a game.ValidationError. Any other error is returned unchanged.
+func incorrectGameInput(err error) error {
+	validationErr, ok := err.(game.ValidationError)
+	if !ok {
+		return err
+	}
+
+	fields := make([]errors.FieldError, len(validationErr.Fields()))
+	for i, f := range validationErr.Fields() {
+		fields[i] = errors.FieldError{Field: f.Field(), Rule: f.Rule(), Limit: f.Limit()}
+	}
+
+	return errors.NewIncorrectInputError(validationErr.Error(), "invalid-game", fields...)
+}
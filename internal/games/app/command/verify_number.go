@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/domain/game"
+	"time"
+)
+
+// VerifyNumber represents the command input for verifying the user's number with the code sent to it.
+type VerifyNumber struct {
+	User game.User `json:"-"`
+	Code string    `json:"code"`
+}
+
+// VerifyNumberHandler handles verifying numbers.
+type VerifyNumberHandler struct {
+	repo game.Repository
+}
+
+// NewVerifyNumberHandler creates a new handler.
+func NewVerifyNumberHandler(repo game.Repository) VerifyNumberHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	return VerifyNumberHandler{repo: repo}
+}
+
+// Handle handles the use case of verifying the user's number, after which the player can receive game
+// messages.
+func (h VerifyNumberHandler) Handle(ctx context.Context, cmd VerifyNumber) (err error) {
+	defer func() {
+		// The code is left out so it isn't logged.
+		logs.LogCommandExecution("VerifyNumber", VerifyNumber{User: cmd.User}, err)
+	}()
+
+	if err := authorize(cmd.User, game.PermissionPlayGames); err != nil {
+		return err
+	}
+
+	p, err := h.repo.GetPlayer(ctx, cmd.User.UUID())
+	if errors.Is(err, game.ErrorPlayerNotFound) {
+		return game.ErrorNoVerificationCode
+	}
+	if err != nil {
+		return err
+	}
+
+	verifyErr := p.VerifyNumber(cmd.Code, time.Now().UTC())
+	if verifyErr != nil && !errors.Is(verifyErr, game.ErrorIncorrectVerificationCode) {
+		return verifyErr
+	}
+
+	// Incorrect codes are saved too so they count towards the limit.
+	err = h.repo.UpdatePlayer(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	return verifyErr
+}
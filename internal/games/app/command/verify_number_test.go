@@ -0,0 +1,113 @@
+package command
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/games/adapters"
+	"gopher-cache/internal/games/domain/game"
+	"regexp"
+	"sync"
+	"testing"
+)
+
+// fakeSMSSender keeps the messages sent to each number.
+type fakeSMSSender struct {
+	lock     sync.Mutex
+	messages map[string][]string
+}
+
+func newFakeSMSSender() *fakeSMSSender {
+	return &fakeSMSSender{messages: make(map[string][]string)}
+}
+
+func (s *fakeSMSSender) SendSMS(_ context.Context, number, message string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.messages[number] = append(s.messages[number], message)
+
+	return nil
+}
+
+var verificationCodePattern = regexp.MustCompile(`\d{6}`)
+
+// lastCode returns the verification code in the last message sent to number.
+func (s *fakeSMSSender) lastCode(t *testing.T, number string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	messages := s.messages[number]
+	require.NotEmpty(t, messages)
+
+	code := verificationCodePattern.FindString(messages[len(messages)-1])
+	require.NotEmpty(t, code)
+
+	return code
+}
+
+// verifyTestNumber verifies the number of the user's player, creating the player if needed.
+func verifyTestNumber(t *testing.T, repo game.Repository, user game.User) {
+	ctx := context.Background()
+	sms := newFakeSMSSender()
+
+	err := NewRequestNumberVerificationHandler(repo, sms).Handle(ctx, RequestNumberVerification{User: user})
+	require.NoError(t, err)
+
+	err = NewVerifyNumberHandler(repo).Handle(ctx, VerifyNumber{User: user, Code: sms.lastCode(t, user.Number())})
+	require.NoError(t, err)
+}
+
+func TestVerifyNumberHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	repo := adapters.NewMemoryGameRepository()
+	sms := newFakeSMSSender()
+
+	requestHandler := NewRequestNumberVerificationHandler(repo, sms)
+	verifyHandler := NewVerifyNumberHandler(repo)
+
+	user, err := game.NewUser("uuid", "+1 (573) 449-7033")
+	require.NoError(t, err)
+	assert.Equal(t, "+15734497033", user.Number())
+
+	err = verifyHandler.Handle(ctx, VerifyNumber{User: user, Code: "123456"})
+	assert.Equal(t, game.ErrorNoVerificationCode, err)
+
+	// The player is created with the code.
+	err = requestHandler.Handle(ctx, RequestNumberVerification{User: user})
+	require.NoError(t, err)
+
+	p, err := repo.GetPlayer(ctx, user.UUID())
+	require.NoError(t, err)
+	assert.False(t, p.NumberVerified())
+
+	code := sms.lastCode(t, user.Number())
+
+	// Another code can't be sent straight away.
+	err = requestHandler.Handle(ctx, RequestNumberVerification{User: user})
+	assert.Equal(t, game.ErrorVerificationCodeTooSoon, err)
+
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+
+	err = verifyHandler.Handle(ctx, VerifyNumber{User: user, Code: wrong})
+	assert.Equal(t, game.ErrorIncorrectVerificationCode, err)
+
+	// Incorrect codes are counted.
+	p, err = repo.GetPlayer(ctx, user.UUID())
+	require.NoError(t, err)
+	assert.Equal(t, 1, p.Verification().Attempts())
+
+	err = verifyHandler.Handle(ctx, VerifyNumber{User: user, Code: code})
+	require.NoError(t, err)
+
+	p, err = repo.GetPlayer(ctx, user.UUID())
+	require.NoError(t, err)
+	assert.True(t, p.NumberVerified())
+
+	err = requestHandler.Handle(ctx, RequestNumberVerification{User: user})
+	assert.Equal(t, game.ErrorNumberAlreadyVerified, err)
+}
@@ -0,0 +1,40 @@
+package query
+
+import (
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/games/domain/game"
+)
+
+var (
+	ErrorNotPlayer = errors.NewAuthorizationError("only the player may read their player and game states", "not-player")
+	// ErrorCannotReadGames is returned to clients whose API key doesn't have the read-games scope.
+	ErrorCannotReadGames = errors.NewAuthorizationError("user doesn't have the read-games permission", "missing-permission-read-games")
+)
+
+// authorize returns an authorization error if none of the user's roles grants the permission p.
+func authorize(u game.User, p game.Permission) error {
+	if !u.Can(p) {
+		return errors.NewAuthorizationError("user doesn't have the "+string(p)+" permission", "missing-permission-"+string(p))
+	}
+
+	return nil
+}
+
+// authorizeReader returns ErrorCannotReadGames unless the user may read games, their reviews and analytics.
+// Users get the permission from every role but clients using an API key need the read-games scope.
+func authorizeReader(u game.User) error {
+	if !u.Can(game.PermissionReadGames) {
+		return ErrorCannotReadGames
+	}
+
+	return nil
+}
+
+// authorizePlayer returns ErrorNotPlayer unless the user is the player with playerUUID or can manage players.
+func authorizePlayer(u game.User, playerUUID string) error {
+	if u.UUID() != playerUUID && !u.Can(game.PermissionManagePlayers) {
+		return ErrorNotPlayer
+	}
+
+	return nil
+}
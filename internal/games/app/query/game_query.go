@@ -0,0 +1,337 @@
+package query
+
+import (
+	"fmt"
+	"gopher-cache/internal/common/errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Operators a GameOption can use to match games.
+const (
+	OpEqual              = "=="
+	OpIn                 = "in"
+	OpGreaterThan        = ">"
+	OpGreaterThanOrEqual = ">="
+	OpLessThan           = "<"
+	OpLessThanOrEqual    = "<="
+)
+
+// MaxInValues is the most values an OpIn option can match against.
+const MaxInValues = 10
+
+// prefixEnd is appended to a prefix to get the upper bound of every string starting with it.
+const prefixEnd = "\uf8ff"
+
+type fieldType int
+
+const (
+	stringField fieldType = iota
+	intField
+	floatField
+	timeField
+)
+
+// gameFields are the only fields games can be filtered and sorted on, keyed by the name used by clients
+// which is also the name used by the projections.
+var gameFields = map[string]fieldType{
+	"title":                    stringField,
+	"kind":                     stringField,
+	"city":                     stringField,
+	"state":                    stringField,
+	"country":                  stringField,
+	"value":                    intField,
+	"playCount":                intField,
+	"completionCount":          intField,
+	"averageCompletionSeconds": floatField,
+	"ratingCount":              intField,
+	"averageRating":            floatField,
+	"createdAt":                timeField,
+}
+
+// statusField is the moderation status of a game. It isn't in gameFields since only published games are
+// ever listed for clients.
+const statusField = "status"
+
+// FieldValue returns the value of a field games can be filtered and sorted on. It reports false if
+// games can't be queried by the field.
+func (g Game) FieldValue(key string) (interface{}, bool) {
+	switch key {
+	case "title":
+		return g.Title, true
+	case "kind":
+		return g.Kind, true
+	case "city":
+		return g.City, true
+	case "state":
+		return g.State, true
+	case "country":
+		return g.Country, true
+	case "value":
+		return g.Value, true
+	case "playCount":
+		return g.PlayCount, true
+	case "completionCount":
+		return g.CompletionCount, true
+	case "averageCompletionSeconds":
+		return g.AverageCompletionSeconds, true
+	case "ratingCount":
+		return g.RatingCount, true
+	case "averageRating":
+		return g.AverageRating, true
+	case "createdAt":
+		return g.CreatedAt, true
+	case statusField:
+		return g.Status, true
+	default:
+		return nil, false
+	}
+}
+
+// GameQuery filters and sorts games.
+type GameQuery struct {
+	Options []GameOption
+	// Sort is optional. If it isn't set games are sorted by the field of any range option and then UUID.
+	Sort GameSort
+}
+
+// GameOption is used for matching games in a query. Value must have the type of the field Key,
+// or be a slice of them for OpIn. Times are time.Time.
+type GameOption struct {
+	Key   string
+	Op    string
+	Value interface{}
+}
+
+// GameSort orders games by the field Key. The zero value orders games by UUID.
+type GameSort struct {
+	Key        string
+	Descending bool
+}
+
+// IsRange reports whether the option matches a range of values rather than exact values.
+func (o GameOption) IsRange() bool {
+	switch o.Op {
+	case OpGreaterThan, OpGreaterThanOrEqual, OpLessThan, OpLessThanOrEqual:
+		return true
+	default:
+		return false
+	}
+}
+
+// Order returns the sort the games of the query are returned in.
+func (q GameQuery) Order() GameSort {
+	if q.Sort.Key != "" {
+		return q.Sort
+	}
+
+	for _, option := range q.Options {
+		if option.IsRange() {
+			return GameSort{Key: option.Key}
+		}
+	}
+
+	return GameSort{}
+}
+
+// Validate checks the query only uses allowed fields, operators and values. Range options may only
+// be on one field and if the query is sorted it must be sorted by that field first.
+func (q GameQuery) Validate() error {
+	rangeKey := ""
+
+	for _, option := range q.Options {
+		t, ok := gameFields[option.Key]
+		if !ok {
+			return errors.NewIncorrectInputError(fmt.Sprintf("games can't be filtered by %q", option.Key), "invalid-filter-field")
+		}
+
+		switch option.Op {
+		case OpEqual:
+			if !hasFieldType(option.Value, t) {
+				return invalidValueError(option.Key)
+			}
+		case OpIn:
+			values, ok := option.Value.([]interface{})
+			if !ok || len(values) == 0 || len(values) > MaxInValues {
+				return errors.NewIncorrectInputError(fmt.Sprintf("in needs between 1 and %d values", MaxInValues), "invalid-filter-value")
+			}
+
+			for _, v := range values {
+				if !hasFieldType(v, t) {
+					return invalidValueError(option.Key)
+				}
+			}
+		case OpGreaterThan, OpGreaterThanOrEqual, OpLessThan, OpLessThanOrEqual:
+			if !hasFieldType(option.Value, t) {
+				return invalidValueError(option.Key)
+			}
+
+			if rangeKey != "" && rangeKey != option.Key {
+				return errors.NewIncorrectInputError("range filters can only be used on one field", "invalid-filter-range")
+			}
+
+			rangeKey = option.Key
+		default:
+			return errors.NewIncorrectInputError(fmt.Sprintf("unknown filter operator %q", option.Op), "invalid-filter-operator")
+		}
+	}
+
+	if q.Sort.Key != "" {
+		if _, ok := gameFields[q.Sort.Key]; !ok {
+			return errors.NewIncorrectInputError(fmt.Sprintf("games can't be sorted by %q", q.Sort.Key), "invalid-sort-field")
+		}
+
+		if rangeKey != "" && rangeKey != q.Sort.Key {
+			return errors.NewIncorrectInputError("games must be sorted by the field with a range filter", "invalid-sort-field")
+		}
+	}
+
+	return nil
+}
+
+func invalidValueError(key string) error {
+	return errors.NewIncorrectInputError(fmt.Sprintf("invalid value for %q", key), "invalid-filter-value")
+}
+
+func hasFieldType(v interface{}, t fieldType) bool {
+	switch v.(type) {
+	case string:
+		return t == stringField
+	case int:
+		return t == intField
+	case float64:
+		return t == floatField
+	case time.Time:
+		return t == timeField
+	default:
+		return false
+	}
+}
+
+// ParseGameQuery parses a GameQuery from client parameters, such as URL query values. The sort parameter
+// names the field to sort by, prefixed with - to sort in descending order. Every other parameter is a
+// filter on the field of the same name. Each filter value is either a plain value to match exactly or
+// an operator and value separated by a colon:
+//
+//	eq:Austin            equal to
+//	in:Austin,Dallas     equal to any of the comma separated values
+//	gt:10 gte:10         greater than, greater than or equal to
+//	lt:10 lte:10         less than, less than or equal to
+//	prefix:Pirate        strings starting with
+//
+// Times are in RFC 3339 format. The query is validated before it is returned.
+func ParseGameQuery(params map[string][]string) (GameQuery, error) {
+	q := GameQuery{}
+
+	// Sort the keys so the options, and any error, are the same every time.
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if key == "sort" {
+			s := params[key][0]
+			q.Sort = GameSort{Key: strings.TrimPrefix(s, "-"), Descending: strings.HasPrefix(s, "-")}
+			continue
+		}
+
+		t, ok := gameFields[key]
+		if !ok {
+			return GameQuery{}, errors.NewIncorrectInputError(fmt.Sprintf("games can't be filtered by %q", key), "invalid-filter-field")
+		}
+
+		for _, param := range params[key] {
+			options, err := parseGameOptions(key, t, param)
+			if err != nil {
+				return GameQuery{}, err
+			}
+
+			q.Options = append(q.Options, options...)
+		}
+	}
+
+	return q, q.Validate()
+}
+
+var paramOps = map[string]string{
+	"eq":  OpEqual,
+	"in":  OpIn,
+	"gt":  OpGreaterThan,
+	"gte": OpGreaterThanOrEqual,
+	"lt":  OpLessThan,
+	"lte": OpLessThanOrEqual,
+}
+
+func parseGameOptions(key string, t fieldType, param string) ([]GameOption, error) {
+	op, value := "eq", param
+
+	// Values may contain colons, like times, so only split off known operators.
+	if i := strings.Index(param, ":"); i >= 0 {
+		if _, ok := paramOps[param[:i]]; ok || param[:i] == "prefix" {
+			op, value = param[:i], param[i+1:]
+		}
+	}
+
+	if op == "prefix" {
+		if t != stringField || value == "" {
+			return nil, invalidValueError(key)
+		}
+
+		return []GameOption{
+			{Key: key, Op: OpGreaterThanOrEqual, Value: value},
+			{Key: key, Op: OpLessThan, Value: value + prefixEnd},
+		}, nil
+	}
+
+	if op == "in" {
+		var values []interface{}
+
+		for _, s := range strings.Split(value, ",") {
+			v, err := parseGameValue(key, t, s)
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, v)
+		}
+
+		return []GameOption{{Key: key, Op: OpIn, Value: values}}, nil
+	}
+
+	v, err := parseGameValue(key, t, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return []GameOption{{Key: key, Op: paramOps[op], Value: v}}, nil
+}
+
+func parseGameValue(key string, t fieldType, s string) (interface{}, error) {
+	var (
+		v   interface{}
+		err error
+	)
+
+	switch t {
+	case intField:
+		v, err = strconv.Atoi(s)
+	case floatField:
+		v, err = strconv.ParseFloat(s, 64)
+	case timeField:
+		var tm time.Time
+		tm, err = time.Parse(time.RFC3339, s)
+		v = tm.UTC()
+	default:
+		v = s
+	}
+
+	if err != nil {
+		return nil, invalidValueError(key)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,58 @@
+package query
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/common/errors"
+	"testing"
+	"time"
+)
+
+func TestParseGameQuery(t *testing.T) {
+	q, err := ParseGameQuery(map[string][]string{
+		"city":      {"Austin"},
+		"kind":      {"in:urban,rural"},
+		"value":     {"gte:10", "lt:50"},
+		"createdAt": {"eq:2021-02-01T10:00:00Z"},
+		"sort":      {"-value"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, GameQuery{
+		Options: []GameOption{
+			{Key: "city", Op: OpEqual, Value: "Austin"},
+			{Key: "createdAt", Op: OpEqual, Value: time.Date(2021, 2, 1, 10, 0, 0, 0, time.UTC)},
+			{Key: "kind", Op: OpIn, Value: []interface{}{"urban", "rural"}},
+			{Key: "value", Op: OpGreaterThanOrEqual, Value: 10},
+			{Key: "value", Op: OpLessThan, Value: 50},
+		},
+		Sort: GameSort{Key: "value", Descending: true},
+	}, q)
+
+	q, err = ParseGameQuery(map[string][]string{"title": {"prefix:Pir"}})
+	require.NoError(t, err)
+	assert.Equal(t, []GameOption{
+		{Key: "title", Op: OpGreaterThanOrEqual, Value: "Pir"},
+		{Key: "title", Op: OpLessThan, Value: "Pir" + prefixEnd},
+	}, q.Options)
+	assert.Equal(t, GameSort{Key: "title"}, q.Order())
+
+	// A colon that isn't after an operator is part of the value.
+	q, err = ParseGameQuery(map[string][]string{"title": {"Pirates: The Hunt"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Pirates: The Hunt", q.Options[0].Value)
+
+	for slug, params := range map[string]map[string][]string{
+		"invalid-filter-field": {"creatorUUID": {"abc"}},
+		"invalid-filter-value": {"value": {"gt:ten"}},
+		"invalid-filter-range": {"value": {"gt:10"}, "playCount": {"lt:5"}},
+		"invalid-sort-field":   {"value": {"gt:10"}, "sort": {"city"}},
+	} {
+		_, err := ParseGameQuery(params)
+		require.Error(t, err, slug)
+
+		slugError, ok := err.(errors.SlugError)
+		require.True(t, ok, slug)
+		assert.Equal(t, slug, slugError.Slug())
+		assert.Equal(t, errors.ErrorTypeIncorrectInput, slugError.ErrorType())
+	}
+}
@@ -0,0 +1,292 @@
+package query
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"gopher-cache/internal/common/errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxPageLimit is the most results a page of a list query can have.
+const MaxPageLimit = 100
+
+// Sort keys of list queries that aren't fields of a game.
+const (
+	distanceSortKey   = "distanceKm"
+	scoreSortKey      = "score"
+	ratedAtSortKey    = "ratedAt"
+	reportedAtSortKey = "firstReportedAt"
+)
+
+// PageParams are the pagination params given by a client for a list query.
+type PageParams struct {
+	Limit int
+	// Cursor is the NextCursor of the previous page. It is empty for the first page.
+	Cursor string
+	// Offset skips results and can't be combined with Cursor.
+	//
+	// Deprecated: Offset is slower and skips or repeats results when they change between pages. Use Cursor.
+	Offset int
+}
+
+// Page is the page of a list query read models return.
+type Page struct {
+	Limit int
+	// After is the position of the last result of the previous page. It is nil for the first page.
+	After *Cursor
+	// Deprecated: use After.
+	Offset int
+}
+
+// Cursor marks the position of a result in a list ordered by Key, then UUID, in the same direction.
+// Value is the value of Key for the result and has the type of the field. Key is empty if the list is
+// only ordered by UUID.
+type Cursor struct {
+	Key        string
+	Descending bool
+	Value      interface{}
+	UUID       string
+}
+
+// GamesPage is a page of games returned by a list query.
+type GamesPage struct {
+	Games []*Game `json:"games"`
+	// NextCursor gets the next page. It is empty if this is the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// CursorSigner encodes cursors as opaque tokens signed so clients can't forge them.
+type CursorSigner struct {
+	key []byte
+}
+
+// NewCursorSigner creates a new signer. Tokens signed with one key can't be decoded with another.
+func NewCursorSigner(key []byte) CursorSigner {
+	if len(key) == 0 {
+		panic("empty key")
+	}
+
+	return CursorSigner{key: key}
+}
+
+// cursorToken is what is signed. Values are kept as strings so their type isn't lost in JSON.
+type cursorToken struct {
+	Key        string `json:"k,omitempty"`
+	Descending bool   `json:"d,omitempty"`
+	Value      string `json:"v,omitempty"`
+	UUID       string `json:"u"`
+}
+
+var ErrorInvalidCursor = errors.NewIncorrectInputError("invalid cursor", "invalid-cursor")
+
+// Encode returns the token for c.
+func (s CursorSigner) Encode(c Cursor) (string, error) {
+	value, err := formatCursorValue(c.Value)
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(cursorToken{
+		Key:        c.Key,
+		Descending: c.Descending,
+		Value:      value,
+		UUID:       c.UUID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(s.sign(payload)), nil
+}
+
+// Decode returns the cursor for a token. It returns ErrorInvalidCursor if the token wasn't created by Encode
+// with the same key.
+func (s CursorSigner) Decode(token string) (Cursor, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return Cursor{}, ErrorInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return Cursor{}, ErrorInvalidCursor
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, s.sign(payload)) {
+		return Cursor{}, ErrorInvalidCursor
+	}
+
+	t := cursorToken{}
+	if err := json.Unmarshal(payload, &t); err != nil {
+		return Cursor{}, ErrorInvalidCursor
+	}
+
+	c := Cursor{
+		Key:        t.Key,
+		Descending: t.Descending,
+		UUID:       t.UUID,
+	}
+
+	if c.Key != "" {
+		c.Value, err = parseCursorValue(c.Key, t.Value)
+		if err != nil {
+			return Cursor{}, ErrorInvalidCursor
+		}
+	}
+
+	return c, nil
+}
+
+func (s CursorSigner) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
+
+func formatCursorValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	default:
+		return "", fmt.Errorf("unsupported cursor value %T", v)
+	}
+}
+
+func parseCursorValue(key, s string) (interface{}, error) {
+	if key == distanceSortKey || key == scoreSortKey {
+		return strconv.ParseFloat(s, 64)
+	}
+
+	t, ok := gameFields[key]
+	if key == ratedAtSortKey || key == reportedAtSortKey {
+		t, ok = timeField, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown cursor key %q", key)
+	}
+
+	if t == timeField {
+		tm, err := time.Parse(time.RFC3339Nano, s)
+		return tm.UTC(), err
+	}
+
+	return parseGameValue(key, t, s)
+}
+
+// page checks the params and returns the page for a list sorted by order.
+func (s CursorSigner) page(params PageParams, order GameSort) (Page, error) {
+	if params.Limit <= 0 || params.Limit > MaxPageLimit {
+		return Page{}, errors.NewIncorrectInputError(fmt.Sprintf("limit must be between 1 and %d", MaxPageLimit), "invalid-limit")
+	}
+
+	if params.Offset < 0 {
+		return Page{}, errors.NewIncorrectInputError("offset can't be negative", "invalid-offset")
+	}
+
+	p := Page{Limit: params.Limit, Offset: params.Offset}
+
+	if params.Cursor == "" {
+		return p, nil
+	}
+
+	if params.Offset != 0 {
+		return Page{}, errors.NewIncorrectInputError("cursor and offset can't be combined", "invalid-offset")
+	}
+
+	c, err := s.Decode(params.Cursor)
+	if err != nil {
+		return Page{}, err
+	}
+
+	// A cursor is only valid for lists in the same order as the one it came from.
+	if c.Key != order.Key || c.Descending != order.Descending {
+		return Page{}, ErrorInvalidCursor
+	}
+
+	p.After = &c
+
+	return p, nil
+}
+
+// reviewsPage creates the page for reviews read in order. There is only a next cursor if the page is full.
+func (s CursorSigner) reviewsPage(reviews []*Review, p Page, order GameSort) (*ReviewsPage, error) {
+	result := &ReviewsPage{Reviews: reviews}
+
+	if len(reviews) == 0 || len(reviews) < p.Limit {
+		return result, nil
+	}
+
+	last := reviews[len(reviews)-1]
+
+	var err error
+	result.NextCursor, err = s.Encode(Cursor{
+		Key:        order.Key,
+		Descending: order.Descending,
+		Value:      last.RatedAt,
+		UUID:       last.ID,
+	})
+
+	return result, err
+}
+
+// moderationQueuePage creates the page for cases read in order. There is only a next cursor if the page is full.
+func (s CursorSigner) moderationQueuePage(cases []*ModerationCase, p Page, order GameSort) (*ModerationQueuePage, error) {
+	result := &ModerationQueuePage{Cases: cases}
+
+	if len(cases) == 0 || len(cases) < p.Limit {
+		return result, nil
+	}
+
+	last := cases[len(cases)-1]
+
+	var err error
+	result.NextCursor, err = s.Encode(Cursor{
+		Key:        order.Key,
+		Descending: order.Descending,
+		Value:      last.FirstReportedAt,
+		UUID:       last.GameUUID,
+	})
+
+	return result, err
+}
+
+// gamesPage creates the page for games read in order. There is only a next cursor if the page is full.
+func (s CursorSigner) gamesPage(games []*Game, p Page, order GameSort, value func(g *Game) interface{}) (*GamesPage, error) {
+	result := &GamesPage{Games: games}
+
+	if len(games) == 0 || len(games) < p.Limit {
+		return result, nil
+	}
+
+	last := games[len(games)-1]
+
+	c := Cursor{
+		Key:        order.Key,
+		Descending: order.Descending,
+		UUID:       last.UUID,
+	}
+
+	if order.Key != "" {
+		c.Value = value(last)
+	}
+
+	var err error
+	result.NextCursor, err = s.Encode(c)
+
+	return result, err
+}
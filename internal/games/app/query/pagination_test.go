@@ -0,0 +1,59 @@
+package query
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCursorSigner(t *testing.T) {
+	signer := NewCursorSigner([]byte("secret"))
+
+	for _, c := range []Cursor{
+		{UUID: "a"},
+		{Key: "city", Value: "Austin", UUID: "b"},
+		{Key: "value", Descending: true, Value: 42, UUID: "c"},
+		{Key: "createdAt", Value: time.Date(2021, 2, 1, 10, 0, 0, 123000, time.UTC), UUID: "d"},
+		{Key: distanceSortKey, Value: 1.2345, UUID: "e"},
+	} {
+		token, err := signer.Encode(c)
+		require.NoError(t, err)
+
+		decoded, err := signer.Decode(token)
+		require.NoError(t, err)
+		assert.Equal(t, c, decoded)
+	}
+
+	token, err := signer.Encode(Cursor{Key: "city", Value: "Austin", UUID: "b"})
+	require.NoError(t, err)
+
+	_, err = NewCursorSigner([]byte("other")).Decode(token)
+	assert.Equal(t, ErrorInvalidCursor, err)
+
+	_, err = signer.Decode("x" + token)
+	assert.Equal(t, ErrorInvalidCursor, err)
+
+	_, err = signer.Decode("garbage")
+	assert.Equal(t, ErrorInvalidCursor, err)
+}
+
+func TestCursorSigner_page(t *testing.T) {
+	signer := NewCursorSigner([]byte("secret"))
+
+	_, err := signer.page(PageParams{Limit: 0}, GameSort{})
+	assert.Error(t, err)
+
+	_, err = signer.page(PageParams{Limit: MaxPageLimit + 1}, GameSort{})
+	assert.Error(t, err)
+
+	token, err := signer.Encode(Cursor{UUID: "a"})
+	require.NoError(t, err)
+
+	_, err = signer.page(PageParams{Limit: 10, Cursor: token, Offset: 1}, GameSort{})
+	assert.Error(t, err)
+
+	p, err := signer.page(PageParams{Limit: 10, Cursor: token}, GameSort{})
+	require.NoError(t, err)
+	assert.Equal(t, &Cursor{UUID: "a"}, p.After)
+}
@@ -0,0 +1,489 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"gopher-cache/internal/games/domain/game"
+	"time"
+)
+
+var (
+	ErrorProjectionNotFound = errors.New("projection not found")
+)
+
+// ProjectionTransaction is the interface used to read and write projections while projecting an event.
+type ProjectionTransaction interface {
+	// GetGameProjection returns ErrorProjectionNotFound if the projection does not exist.
+	GetGameProjection(ctx context.Context, uuid string) (*Game, error)
+	SaveGameProjection(ctx context.Context, game *Game) error
+
+	// GetPlayerProjection returns ErrorProjectionNotFound if the projection does not exist.
+	GetPlayerProjection(ctx context.Context, uuid string) (*Player, error)
+	SavePlayerProjection(ctx context.Context, player *Player) error
+
+	// GetStateProjection returns ErrorProjectionNotFound if the projection does not exist.
+	GetStateProjection(ctx context.Context, uuid string) (*State, error)
+	SaveStateProjection(ctx context.Context, state *State) error
+
+	// GetGameAnalyticsProjection returns ErrorProjectionNotFound if the projection does not exist.
+	GetGameAnalyticsProjection(ctx context.Context, gameUUID string) (*GameAnalytics, error)
+	SaveGameAnalyticsProjection(ctx context.Context, analytics *GameAnalytics) error
+
+	SaveReviewProjection(ctx context.Context, review *Review) error
+
+	// GetModerationCaseProjection returns ErrorProjectionNotFound if the projection does not exist.
+	GetModerationCaseProjection(ctx context.Context, gameUUID string) (*ModerationCase, error)
+	SaveModerationCaseProjection(ctx context.Context, c *ModerationCase) error
+	// DeleteModerationCaseProjection does nothing if the projection does not exist.
+	DeleteModerationCaseProjection(ctx context.Context, gameUUID string) error
+}
+
+// ProjectionStore is the interface used to persist the denormalized projections backing the read models.
+// It may be shared by projectors in any number of instances.
+type ProjectionStore interface {
+	// UpdateProjections runs updateFn in a transaction. If another transaction changes a projection read by
+	// updateFn before its writes are saved, the writes are discarded and updateFn is run again, so it must not
+	// have other side effects. Reads don't see the writes made earlier in the same transaction.
+	UpdateProjections(ctx context.Context, updateFn func(ctx context.Context, tx ProjectionTransaction) error) error
+
+	// RecordProjectionLag adds the lag of one event to the metrics returned by ReadProjectionLag.
+	RecordProjectionLag(ctx context.Context, lag time.Duration) error
+	ReadProjectionLag(ctx context.Context) (*ProjectionLag, error)
+
+	// ClearProjections removes every projection and the projection lag from the store.
+	ClearProjections(ctx context.Context) error
+}
+
+// Projector keeps the projections in a ProjectionStore up to date by applying domain events to them.
+type Projector struct {
+	store ProjectionStore
+}
+
+// NewProjector creates a new projector.
+func NewProjector(store ProjectionStore) Projector {
+	if store == nil {
+		panic("nil store")
+	}
+
+	return Projector{store: store}
+}
+
+// Publish projects the events in the order given. Each event is projected in its own transaction so
+// projectors in other instances can publish at the same time.
+func (p Projector) Publish(ctx context.Context, events ...game.Event) error {
+	for _, event := range events {
+		err := p.store.UpdateProjections(ctx, func(ctx context.Context, tx ProjectionTransaction) error {
+			return p.project(ctx, tx, event)
+		})
+		if err != nil {
+			return fmt.Errorf("projecting %s: %w", event.EventName(), err)
+		}
+
+		lag := time.Since(event.OccurredAt())
+		if err := p.store.RecordProjectionLag(ctx, lag); err != nil {
+			return fmt.Errorf("recording lag of %s: %w", event.EventName(), err)
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"event": event.EventName(),
+			"lag":   lag.String(),
+		}).Debug("Event projected")
+	}
+
+	return nil
+}
+
+// Reset removes every projection so they can be rebuilt from scratch.
+func (p Projector) Reset(ctx context.Context) error {
+	return p.store.ClearProjections(ctx)
+}
+
+func (p Projector) project(ctx context.Context, tx ProjectionTransaction, event game.Event) error {
+	switch e := event.(type) {
+	case game.GameCreated:
+		return p.projectGameCreated(ctx, tx, e)
+	case game.GameStarted:
+		return p.projectGameStarted(ctx, tx, e)
+	case game.StateUpdated:
+		return p.projectStateUpdated(ctx, tx, e)
+	case game.GameFinished:
+		return p.projectGameFinished(ctx, tx, e)
+	case game.AttemptRecorded:
+		return p.projectAttemptRecorded(ctx, tx, e)
+	case game.GameQuit:
+		return p.projectGameQuit(ctx, tx, e)
+	case game.LevelSkipped:
+		return p.projectLevelSkipped(ctx, tx, e)
+	case game.GameRated:
+		return p.projectGameRated(ctx, tx, e)
+	case game.GameReported:
+		return p.projectGameReported(ctx, tx, e)
+	case game.GameModerated:
+		return p.projectGameModerated(ctx, tx, e)
+	default:
+		// Events that don't affect any projection are ignored.
+		return nil
+	}
+}
+
+func (p Projector) projectGameCreated(ctx context.Context, tx ProjectionTransaction, e game.GameCreated) error {
+	analytics := &GameAnalytics{
+		GameUUID:    e.GameUUID,
+		CreatorUUID: e.CreatorUUID,
+		Title:       e.Title,
+		Levels:      make([]LevelAnalytics, e.Levels),
+	}
+
+	for i := range analytics.Levels {
+		analytics.Levels[i] = LevelAnalytics{Level: i, WrongAnswers: map[string]int{}}
+	}
+
+	if err := tx.SaveGameAnalyticsProjection(ctx, analytics); err != nil {
+		return err
+	}
+
+	g := &Game{
+		UUID:        e.GameUUID,
+		CreatorUUID: e.CreatorUUID,
+		CreatorName: e.CreatorName,
+		Title:       e.Title,
+		Description: e.Description,
+		Kind:        e.Kind,
+		City:        e.City,
+		State:       e.State,
+		Country:     e.Country,
+		Levels:      e.Levels,
+		Value:       e.Value,
+		CreatedAt:   e.At,
+		Status:      string(e.Status),
+	}
+
+	if e.Location != nil {
+		g.Location = &Location{
+			Latitude:  e.Location.Latitude(),
+			Longitude: e.Location.Longitude(),
+		}
+	}
+
+	return tx.SaveGameProjection(ctx, g)
+}
+
+func (p Projector) projectGameStarted(ctx context.Context, tx ProjectionTransaction, e game.GameStarted) error {
+	g, err := tx.GetGameProjection(ctx, e.GameUUID)
+	if err != nil {
+		return err
+	}
+
+	g.PlayCount++
+
+	if err := tx.SaveGameProjection(ctx, g); err != nil {
+		return err
+	}
+
+	analytics, err := tx.GetGameAnalyticsProjection(ctx, e.GameUUID)
+	if err != nil {
+		return err
+	}
+
+	if len(analytics.Levels) > 0 {
+		analytics.Levels[0].PlayersReached++
+	}
+
+	if err := tx.SaveGameAnalyticsProjection(ctx, analytics); err != nil {
+		return err
+	}
+
+	player, err := getOrNewPlayerProjection(ctx, tx, e.PlayerUUID)
+	if err != nil {
+		return err
+	}
+
+	player.GamesStarted++
+	player.History = append(player.History, PlayedGame{
+		StateUUID: e.StateUUID,
+		GameUUID:  e.GameUUID,
+		GameTitle: g.Title,
+		StartedAt: e.At,
+	})
+
+	if err := tx.SavePlayerProjection(ctx, player); err != nil {
+		return err
+	}
+
+	return tx.SaveStateProjection(ctx, &State{
+		UUID:            e.StateUUID,
+		PlayerUUID:      e.PlayerUUID,
+		GameUUID:        e.GameUUID,
+		GameLevels:      e.GameLevels,
+		CurrentResponse: e.Response,
+		CurrentLevel:    levelFromResponse(0, e.Response),
+		UpdatedAt:       e.At,
+	})
+}
+
+func (p Projector) projectStateUpdated(ctx context.Context, tx ProjectionTransaction, e game.StateUpdated) error {
+	s, err := tx.GetStateProjection(ctx, e.StateUUID)
+	if err != nil {
+		return err
+	}
+
+	s.Level = e.Level
+	s.Completed = e.Completed
+	s.CurrentResponse = e.Response
+	s.UpdatedAt = e.At
+
+	// Only level responses have the title and description of the level, so other responses such as clues
+	// keep the level the player is on.
+	if l := levelFromResponse(e.Level, e.Response); l != nil {
+		s.CurrentLevel = l
+	}
+	if s.Completed {
+		s.CurrentLevel = nil
+	}
+
+	return tx.SaveStateProjection(ctx, s)
+}
+
+// levelFromResponse returns the level of a level response or nil for other responses.
+func levelFromResponse(number int, r game.Response) *Level {
+	if r.Kind != game.LevelResponse {
+		return nil
+	}
+
+	return &Level{Number: number, Title: r.LevelTitle, Description: r.LevelDescription}
+}
+
+func (p Projector) projectGameFinished(ctx context.Context, tx ProjectionTransaction, e game.GameFinished) error {
+	g, err := tx.GetGameProjection(ctx, e.GameUUID)
+	if err != nil {
+		return err
+	}
+
+	total := g.AverageCompletionSeconds * float64(g.CompletionCount)
+	g.CompletionCount++
+	g.AverageCompletionSeconds = (total + e.Duration.Seconds()) / float64(g.CompletionCount)
+
+	if err := tx.SaveGameProjection(ctx, g); err != nil {
+		return err
+	}
+
+	player, err := getOrNewPlayerProjection(ctx, tx, e.PlayerUUID)
+	if err != nil {
+		return err
+	}
+
+	player.GamesFinished++
+	player.TotalPoints += e.Points
+
+	for i := range player.History {
+		if player.History[i].StateUUID == e.StateUUID {
+			player.History[i].Completed = true
+			player.History[i].Points = e.Points
+			player.History[i].FinishedAt = e.At
+		}
+	}
+
+	return tx.SavePlayerProjection(ctx, player)
+}
+
+func (p Projector) projectGameQuit(ctx context.Context, tx ProjectionTransaction, e game.GameQuit) error {
+	player, err := getOrNewPlayerProjection(ctx, tx, e.PlayerUUID)
+	if err != nil {
+		return err
+	}
+
+	for i := range player.History {
+		if player.History[i].StateUUID == e.StateUUID {
+			player.History[i].Quit = true
+		}
+	}
+
+	if err := tx.SavePlayerProjection(ctx, player); err != nil {
+		return err
+	}
+
+	s, err := tx.GetStateProjection(ctx, e.StateUUID)
+	if err != nil {
+		return err
+	}
+
+	s.Quit = true
+	s.UpdatedAt = e.At
+
+	return tx.SaveStateProjection(ctx, s)
+}
+
+// maxWrongAnswers limits the distinct wrong answers kept per level so a projection can't grow without bound.
+const maxWrongAnswers = 100
+
+func (p Projector) projectAttemptRecorded(ctx context.Context, tx ProjectionTransaction, e game.AttemptRecorded) error {
+	analytics, err := tx.GetGameAnalyticsProjection(ctx, e.GameUUID)
+	if err != nil {
+		return err
+	}
+
+	if e.Level < 0 || e.Level >= len(analytics.Levels) {
+		return errors.New("attempt level out of range")
+	}
+
+	l := &analytics.Levels[e.Level]
+	l.Attempts++
+
+	if e.ClueShown >= 0 {
+		l.CluesShown++
+	}
+
+	if e.Correct {
+		l.PlayersCompleted++
+		if e.Level+1 < len(analytics.Levels) {
+			analytics.Levels[e.Level+1].PlayersReached++
+		}
+	} else {
+		if l.WrongAnswers == nil {
+			l.WrongAnswers = map[string]int{}
+		}
+
+		if _, ok := l.WrongAnswers[e.Input]; ok || len(l.WrongAnswers) < maxWrongAnswers {
+			l.WrongAnswers[e.Input]++
+		}
+	}
+
+	return tx.SaveGameAnalyticsProjection(ctx, analytics)
+}
+
+func (p Projector) projectLevelSkipped(ctx context.Context, tx ProjectionTransaction, e game.LevelSkipped) error {
+	analytics, err := tx.GetGameAnalyticsProjection(ctx, e.GameUUID)
+	if err != nil {
+		return err
+	}
+
+	if e.Level < 0 || e.Level >= len(analytics.Levels) {
+		return errors.New("skipped level out of range")
+	}
+
+	// Players who skip a level reach the next one without completing it.
+	analytics.Levels[e.Level].PlayersSkipped++
+	if e.Level+1 < len(analytics.Levels) {
+		analytics.Levels[e.Level+1].PlayersReached++
+	}
+
+	return tx.SaveGameAnalyticsProjection(ctx, analytics)
+}
+
+func (p Projector) projectGameRated(ctx context.Context, tx ProjectionTransaction, e game.GameRated) error {
+	g, err := tx.GetGameProjection(ctx, e.GameUUID)
+	if err != nil {
+		return err
+	}
+
+	// Ratings saved at the same time may be projected in either order, so only a later summary replaces
+	// the one projected.
+	if e.RatingRevision > g.RatingRevision {
+		g.RatingCount = e.RatingCount
+		g.RatingTotal = e.RatingTotal
+		g.RatingRevision = e.RatingRevision
+		g.AverageRating = float64(g.RatingTotal) / float64(g.RatingCount)
+
+		if err := tx.SaveGameProjection(ctx, g); err != nil {
+			return err
+		}
+	}
+
+	return tx.SaveReviewProjection(ctx, &Review{
+		ID:         e.GameUUID + "_" + e.PlayerUUID,
+		GameUUID:   e.GameUUID,
+		PlayerUUID: e.PlayerUUID,
+		PlayerName: e.PlayerName,
+		Stars:      e.Stars,
+		Review:     e.Review,
+		RatedAt:    e.At,
+	})
+}
+
+// maxModerationDetails limits the details kept per case so a game reported many times can't grow it
+// without bound.
+const maxModerationDetails = 20
+
+func (p Projector) projectGameReported(ctx context.Context, tx ProjectionTransaction, e game.GameReported) error {
+	c, err := tx.GetModerationCaseProjection(ctx, e.GameUUID)
+	if errors.Is(err, ErrorProjectionNotFound) {
+		g, err := tx.GetGameProjection(ctx, e.GameUUID)
+		if err != nil {
+			return err
+		}
+
+		c = &ModerationCase{
+			GameUUID:        g.UUID,
+			CreatorUUID:     g.CreatorUUID,
+			Title:           g.Title,
+			Status:          g.Status,
+			Reasons:         map[string]int{},
+			FirstReportedAt: e.At,
+		}
+	} else if err != nil {
+		return err
+	}
+
+	c.Reports++
+	c.Reasons[string(e.Reason)]++
+	c.LastReportedAt = e.At
+
+	if e.Details != "" {
+		c.Details = append(c.Details, e.Details)
+		if len(c.Details) > maxModerationDetails {
+			c.Details = c.Details[len(c.Details)-maxModerationDetails:]
+		}
+	}
+
+	for _, flag := range e.Flags {
+		c.Flags = append(c.Flags, ModerationFlag{Field: flag.Field, Rule: flag.Rule, Match: flag.Match})
+	}
+
+	return tx.SaveModerationCaseProjection(ctx, c)
+}
+
+func (p Projector) projectGameModerated(ctx context.Context, tx ProjectionTransaction, e game.GameModerated) error {
+	g, err := tx.GetGameProjection(ctx, e.GameUUID)
+	if err != nil {
+		return err
+	}
+
+	g.Status = string(e.Status)
+
+	if err := tx.SaveGameProjection(ctx, g); err != nil {
+		return err
+	}
+
+	// The decision resolves every open report so the game leaves the queue until it is reported again.
+	return tx.DeleteModerationCaseProjection(ctx, e.GameUUID)
+}
+
+func getOrNewPlayerProjection(ctx context.Context, tx ProjectionTransaction, uuid string) (*Player, error) {
+	player, err := tx.GetPlayerProjection(ctx, uuid)
+	if errors.Is(err, ErrorProjectionNotFound) {
+		return &Player{UUID: uuid}, nil
+	}
+
+	return player, err
+}
+
+// ProjectionLag holds metrics on the time between an event occurring and it being projected.
+type ProjectionLag struct {
+	Events  int           `json:"events"`
+	Last    time.Duration `json:"last"`
+	Max     time.Duration `json:"max"`
+	Average time.Duration `json:"average"`
+}
+
+// Record adds the lag of one event to the metrics.
+func (l *ProjectionLag) Record(lag time.Duration) {
+	l.Average = (l.Average*time.Duration(l.Events) + lag) / time.Duration(l.Events+1)
+	l.Events++
+	l.Last = lag
+
+	if lag > l.Max {
+		l.Max = lag
+	}
+}
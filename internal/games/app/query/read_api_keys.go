@@ -0,0 +1,66 @@
+package query
+
+import (
+	"context"
+	"gopher-cache/internal/common/auth"
+	"gopher-cache/internal/games/domain/game"
+	"time"
+)
+
+// ReadAPIKeysHandler handles reading API keys.
+type ReadAPIKeysHandler struct {
+	readModel APIKeysReadModel
+}
+
+// NewReadAPIKeysHandler creates a new handler.
+func NewReadAPIKeysHandler(readModel APIKeysReadModel) ReadAPIKeysHandler {
+	if readModel == nil {
+		panic("nil readModel")
+	}
+
+	return ReadAPIKeysHandler{readModel: readModel}
+}
+
+// APIKeysReadModel is the interface used for reading API keys.
+type APIKeysReadModel interface {
+	// ReadAPIKeys returns every key, including revoked and expired keys, newest first.
+	ReadAPIKeys(ctx context.Context) ([]auth.APIKey, error)
+}
+
+// Handle handles the use case for reading API keys. Only users who can manage API keys may read them.
+func (h ReadAPIKeysHandler) Handle(ctx context.Context, user game.User) ([]*APIKey, error) {
+	if err := authorize(user, game.PermissionManageAPIKeys); err != nil {
+		return nil, err
+	}
+
+	keys, err := h.readModel.ReadAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	result := make([]*APIKey, len(keys))
+	for i, k := range keys {
+		result[i] = &APIKey{
+			ID:        k.ID,
+			Name:      k.Name,
+			Prefix:    k.Prefix,
+			Scopes:    k.Scopes,
+			CreatedAt: k.CreatedAt,
+			ExpiresAt: optionalTime(k.ExpiresAt),
+			RevokedAt: optionalTime(k.RevokedAt),
+			Active:    k.Active(now),
+		}
+	}
+
+	return result, nil
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
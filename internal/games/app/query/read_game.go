@@ -0,0 +1,95 @@
+package query
+
+import (
+	"context"
+	stderrors "errors"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// EstimatedLevelSeconds is how long a level is expected to take before any player has finished the game.
+const EstimatedLevelSeconds = 15 * 60
+
+var (
+	ErrorGameNotFound = errors.NewNotFoundError("game not found", "game-not-found")
+)
+
+// ReadGameHandler handles reading the details of a game.
+type ReadGameHandler struct {
+	readModel GameReadModel
+}
+
+// NewReadGameHandler creates a new handler.
+func NewReadGameHandler(readModel GameReadModel) ReadGameHandler {
+	if readModel == nil {
+		panic("nil readModel")
+	}
+
+	return ReadGameHandler{readModel: readModel}
+}
+
+// GameReadModel is the interface used for reading a Game for a client query.
+type GameReadModel interface {
+	// ReadGame returns ErrorProjectionNotFound if the game does not exist.
+	ReadGame(ctx context.Context, uuid string) (*Game, error)
+	// ReadGamesByUUID returns the games with the uuids in the same order. Games that don't exist are nil.
+	ReadGamesByUUID(ctx context.Context, uuids []string) ([]*Game, error)
+}
+
+// Handle handles the use case for reading the details of a game. It returns ErrorGameNotFound if
+// the game does not exist or isn't published.
+func (h ReadGameHandler) Handle(ctx context.Context, user game.User, uuid string) (*GameDetail, error) {
+	if err := authorizeReader(user); err != nil {
+		return nil, err
+	}
+
+	g, err := h.readModel.ReadGame(ctx, uuid)
+	if stderrors.Is(err, ErrorProjectionNotFound) {
+		return nil, ErrorGameNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if g.Status != string(game.StatusPublished) {
+		return nil, ErrorGameNotFound
+	}
+
+	return newGameDetail(g), nil
+}
+
+// HandleBatch handles the use case for reading the details of several games at once, e.g. the games of a
+// player's history. The details are in the same order as the uuids. They are nil for games that don't
+// exist or aren't published.
+func (h ReadGameHandler) HandleBatch(ctx context.Context, user game.User, uuids []string) ([]*GameDetail, error) {
+	if err := authorizeReader(user); err != nil {
+		return nil, err
+	}
+
+	games, err := h.readModel.ReadGamesByUUID(ctx, uuids)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]*GameDetail, len(games))
+	for i, g := range games {
+		if g != nil && g.Status == string(game.StatusPublished) {
+			details[i] = newGameDetail(g)
+		}
+	}
+
+	return details, nil
+}
+
+func newGameDetail(g *Game) *GameDetail {
+	detail := &GameDetail{
+		Game:                     *g,
+		EstimatedDurationSeconds: g.AverageCompletionSeconds,
+	}
+
+	if g.CompletionCount == 0 {
+		detail.EstimatedDurationSeconds = float64(g.Levels * EstimatedLevelSeconds)
+	}
+
+	return detail
+}
@@ -0,0 +1,111 @@
+package query
+
+import (
+	"context"
+	stderrors "errors"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/games/domain/game"
+	"sort"
+)
+
+// MostCommonWrongAnswers is the number of wrong answers reported for each level.
+const MostCommonWrongAnswers = 5
+
+var (
+	ErrorNotGameCreator = errors.NewAuthorizationError("only the creator of a game may view its analytics", "not-game-creator")
+)
+
+// ReadGameAnalyticsHandler handles reading the analytics of games for their creators.
+type ReadGameAnalyticsHandler struct {
+	readModel GameAnalyticsReadModel
+}
+
+// NewReadGameAnalyticsHandler creates a new handler.
+func NewReadGameAnalyticsHandler(readModel GameAnalyticsReadModel) ReadGameAnalyticsHandler {
+	if readModel == nil {
+		panic("nil readModel")
+	}
+
+	return ReadGameAnalyticsHandler{readModel: readModel}
+}
+
+// GameAnalyticsReadModel is the interface used for reading GameAnalytics for a client query.
+type GameAnalyticsReadModel interface {
+	// ReadGameAnalytics returns ErrorProjectionNotFound if the game does not exist.
+	ReadGameAnalytics(ctx context.Context, gameUUID string) (*GameAnalytics, error)
+	// ReadCreatorGameAnalytics will return an empty non-nil slice if the creator has no games.
+	ReadCreatorGameAnalytics(ctx context.Context, creatorUUID string) ([]*GameAnalytics, error)
+}
+
+// Handle handles the use case for reading the analytics of a single game. ErrorNotGameCreator is
+// returned if the game wasn't created by the user and ErrorGameNotFound if it does not exist.
+func (h ReadGameAnalyticsHandler) Handle(ctx context.Context, user game.User, gameUUID string) (*GameAnalytics, error) {
+	if err := authorizeReader(user); err != nil {
+		return nil, err
+	}
+
+	a, err := h.readModel.ReadGameAnalytics(ctx, gameUUID)
+	if stderrors.Is(err, ErrorProjectionNotFound) {
+		return nil, ErrorGameNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if a.CreatorUUID != user.UUID() {
+		return nil, ErrorNotGameCreator
+	}
+
+	summarize(a)
+
+	return a, nil
+}
+
+// HandleCreator handles the use case for reading the analytics of every game created by the user.
+func (h ReadGameAnalyticsHandler) HandleCreator(ctx context.Context, user game.User) ([]*GameAnalytics, error) {
+	if err := authorizeReader(user); err != nil {
+		return nil, err
+	}
+
+	analytics, err := h.readModel.ReadCreatorGameAnalytics(ctx, user.UUID())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range analytics {
+		summarize(a)
+	}
+
+	return analytics, nil
+}
+
+// summarize fills in the fields of the analytics derived from the counts kept by the projection.
+func summarize(a *GameAnalytics) {
+	for i := range a.Levels {
+		l := &a.Levels[i]
+
+		l.DropOff = l.PlayersReached - l.PlayersCompleted - l.PlayersSkipped
+
+		if l.PlayersReached > 0 {
+			l.AverageAttempts = float64(l.Attempts) / float64(l.PlayersReached)
+			l.AverageCluesShown = float64(l.CluesShown) / float64(l.PlayersReached)
+		}
+
+		l.MostCommonWrongAnswers = []WrongAnswer{}
+		for answer, count := range l.WrongAnswers {
+			l.MostCommonWrongAnswers = append(l.MostCommonWrongAnswers, WrongAnswer{Answer: answer, Count: count})
+		}
+
+		sort.Slice(l.MostCommonWrongAnswers, func(i, j int) bool {
+			if l.MostCommonWrongAnswers[i].Count == l.MostCommonWrongAnswers[j].Count {
+				return l.MostCommonWrongAnswers[i].Answer < l.MostCommonWrongAnswers[j].Answer
+			}
+
+			return l.MostCommonWrongAnswers[i].Count > l.MostCommonWrongAnswers[j].Count
+		})
+
+		if len(l.MostCommonWrongAnswers) > MostCommonWrongAnswers {
+			l.MostCommonWrongAnswers = l.MostCommonWrongAnswers[:MostCommonWrongAnswers]
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package query
+
+import (
+	"context"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// ReadGameReviewsHandler handles reading the reviews of a game.
+type ReadGameReviewsHandler struct {
+	readModel ReviewsReadModel
+	cursors   CursorSigner
+}
+
+// NewReadGameReviewsHandler creates a new handler.
+func NewReadGameReviewsHandler(readModel ReviewsReadModel, cursors CursorSigner) ReadGameReviewsHandler {
+	if readModel == nil {
+		panic("nil readModel")
+	}
+
+	return ReadGameReviewsHandler{readModel: readModel, cursors: cursors}
+}
+
+// ReviewsReadModel is the interface used for reading Reviews for a client query.
+type ReviewsReadModel interface {
+	// ReadGameReviews returns the page of reviews of a game, newest first with ties broken by ID in
+	// the same direction. It will return an empty non-nil slice if no reviews are found.
+	ReadGameReviews(ctx context.Context, gameUUID string, page Page) ([]*Review, error)
+}
+
+// Handle handles the use case for reading the reviews of a game.
+func (h ReadGameReviewsHandler) Handle(ctx context.Context, user game.User, gameUUID string, params PageParams) (*ReviewsPage, error) {
+	if err := authorizeReader(user); err != nil {
+		return nil, err
+	}
+
+	order := GameSort{Key: ratedAtSortKey, Descending: true}
+
+	page, err := h.cursors.page(params, order)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := h.readModel.ReadGameReviews(ctx, gameUUID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.cursors.reviewsPage(reviews, page, order)
+}
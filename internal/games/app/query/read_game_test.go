@@ -0,0 +1,46 @@
+package query
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/games/domain/game"
+	"testing"
+)
+
+// publishedGames reads the games with any UUID as published games.
+type publishedGames struct{}
+
+func (publishedGames) ReadGame(_ context.Context, uuid string) (*Game, error) {
+	return &Game{UUID: uuid, Status: string(game.StatusPublished)}, nil
+}
+
+func (r publishedGames) ReadGamesByUUID(ctx context.Context, uuids []string) ([]*Game, error) {
+	games := make([]*Game, len(uuids))
+	for i, uuid := range uuids {
+		games[i], _ = r.ReadGame(ctx, uuid)
+	}
+
+	return games, nil
+}
+
+func TestReadGameHandler_ReadScope(t *testing.T) {
+	ctx := context.Background()
+	handler := NewReadGameHandler(publishedGames{})
+
+	reader, err := game.NewClientUser("api-key:1", "Reader", game.PermissionReadGames)
+	require.NoError(t, err)
+	creator, err := game.NewClientUser("api-key:2", "Creator", game.PermissionCreateGames)
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, reader, "game-1")
+	assert.NoError(t, err)
+	_, err = handler.HandleBatch(ctx, reader, []string{"game-1"})
+	assert.NoError(t, err)
+
+	// Games read in a batch, e.g. the games of a player's history, need the permission too.
+	_, err = handler.Handle(ctx, creator, "game-1")
+	assert.Equal(t, ErrorCannotReadGames, err)
+	_, err = handler.HandleBatch(ctx, creator, []string{"game-1"})
+	assert.Equal(t, ErrorCannotReadGames, err)
+}
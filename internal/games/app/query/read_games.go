@@ -3,36 +3,150 @@ package query
 
 import (
 	"context"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/common/geo"
+	"gopher-cache/internal/games/domain/game"
 )
 
+// MaxNearRadiusKm is the largest radius games can be searched for around a location.
+const MaxNearRadiusKm = 500
+
 // ReadGamesHandler handles the reading of games.
 type ReadGamesHandler struct {
 	readModel GamesReadModel
+	cursors   CursorSigner
 }
 
 // NewReadGamesHandler creates a new handler.
-func NewReadGamesHandler(readModel GamesReadModel) ReadGamesHandler {
+func NewReadGamesHandler(readModel GamesReadModel, cursors CursorSigner) ReadGamesHandler {
 	if readModel == nil {
 		panic("nil readModel")
 	}
 
-	return ReadGamesHandler{readModel: readModel}
+	return ReadGamesHandler{readModel: readModel, cursors: cursors}
 }
 
 // GamesReadModel is the interface used for reading Games for a client query.
 type GamesReadModel interface {
-	// ReadGames will return an empty non-nil slice if no games are found.
-	ReadGames(ctx context.Context, limit, offset int, options ...GameOption) ([]*Game, error)
+	// ReadGames returns the page of games matching q in the order given by q.Order, then UUID in the
+	// same direction. It will return an empty non-nil slice if no games are found. q has already been
+	// validated.
+	ReadGames(ctx context.Context, q GameQuery, page Page) ([]*Game, error)
+	// ReadGamesNear returns the page of games matching q with a location within near.RadiusKm sorted by
+	// distance, then UUID, with Game.DistanceKm set. It will return an empty non-nil slice if no games are
+	// found. q has already been validated and only has equality options.
+	ReadGamesNear(ctx context.Context, near Near, q GameQuery, page Page) ([]*Game, error)
 }
 
 // Handle is the use case for reading games.
-func (h ReadGamesHandler) Handle(ctx context.Context, limit, offset int, options ...GameOption) ([]*Game, error) {
-	return h.readModel.ReadGames(ctx, limit, offset, options...)
+func (h ReadGamesHandler) Handle(ctx context.Context, user game.User, q GameQuery, params PageParams) (*GamesPage, error) {
+	if err := authorizeReader(user); err != nil {
+		return nil, err
+	}
+
+	return h.readGames(ctx, q, params)
+}
+
+func (h ReadGamesHandler) readGames(ctx context.Context, q GameQuery, params PageParams) (*GamesPage, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	order := q.Order()
+
+	page, err := h.cursors.page(params, order)
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := h.readModel.ReadGames(ctx, published(q), page)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.cursors.gamesPage(games, page, order, func(g *Game) interface{} {
+		v, _ := g.FieldValue(order.Key)
+		return v
+	})
+}
+
+// HandleNear is the use case for reading games near a location sorted by distance.
+func (h ReadGamesHandler) HandleNear(ctx context.Context, user game.User, near Near, q GameQuery, params PageParams) (*GamesPage, error) {
+	if err := authorizeReader(user); err != nil {
+		return nil, err
+	}
+
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Games near a location are always sorted by distance and the geohash index they are found
+	// with can't be combined with other range filters.
+	if q.Sort.Key != "" {
+		return nil, errors.NewIncorrectInputError("games near a location are sorted by distance", "invalid-sort-field")
+	}
+
+	for _, option := range q.Options {
+		if option.IsRange() {
+			return nil, errors.NewIncorrectInputError("range filters can't be used with a location", "invalid-filter-range")
+		}
+	}
+
+	if !geo.ValidCoordinates(near.Latitude, near.Longitude) {
+		return nil, errors.NewIncorrectInputError("invalid coordinates", "invalid-coordinates")
+	}
+
+	if near.RadiusKm <= 0 || near.RadiusKm > MaxNearRadiusKm {
+		return nil, errors.NewIncorrectInputError("radius must be greater than 0 and at most 500km", "invalid-radius")
+	}
+
+	order := GameSort{Key: distanceSortKey}
+
+	page, err := h.cursors.page(params, order)
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := h.readModel.ReadGamesNear(ctx, near, published(q), page)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.cursors.gamesPage(games, page, order, func(g *Game) interface{} {
+		return *g.DistanceKm
+	})
+}
+
+// published restricts q to the games players can see. It is added after q has been validated since
+// clients can't filter on the status themselves.
+func published(q GameQuery) GameQuery {
+	options := make([]GameOption, 0, len(q.Options)+1)
+	options = append(options, q.Options...)
+	q.Options = append(options, GameOption{Key: statusField, Op: OpEqual, Value: string(game.StatusPublished)})
+
+	return q
+}
+
+// Near is used for matching games within a radius of a location.
+type Near struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
 }
 
-// GameOption is used for matching games in a query.
-type GameOption struct {
-	Key   string
-	Op    string
-	Value interface{}
+// ListGameTitles returns the titles of the newest published games, e.g. for players texting for a game to
+// play who can't be shown the full list. It isn't a query of a client, so the read-games permission isn't
+// needed.
+func (h ReadGamesHandler) ListGameTitles(ctx context.Context, limit int) ([]string, error) {
+	page, err := h.readGames(ctx, GameQuery{Sort: GameSort{Key: "createdAt", Descending: true}}, PageParams{Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make([]string, len(page.Games))
+	for i, g := range page.Games {
+		titles[i] = g.Title
+	}
+
+	return titles, nil
 }
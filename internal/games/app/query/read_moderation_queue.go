@@ -0,0 +1,50 @@
+package query
+
+import (
+	"context"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// ReadModerationQueueHandler handles reading the games waiting for a moderator.
+type ReadModerationQueueHandler struct {
+	readModel ModerationQueueReadModel
+	cursors   CursorSigner
+}
+
+// NewReadModerationQueueHandler creates a new handler.
+func NewReadModerationQueueHandler(readModel ModerationQueueReadModel, cursors CursorSigner) ReadModerationQueueHandler {
+	if readModel == nil {
+		panic("nil readModel")
+	}
+
+	return ReadModerationQueueHandler{readModel: readModel, cursors: cursors}
+}
+
+// ModerationQueueReadModel is the interface used for reading the moderation review queue.
+type ModerationQueueReadModel interface {
+	// ReadModerationQueue returns the page of cases ordered by when the game was first reported, oldest
+	// first, with ties broken by game UUID. It will return an empty non-nil slice if the queue is empty.
+	ReadModerationQueue(ctx context.Context, page Page) ([]*ModerationCase, error)
+}
+
+// Handle handles the use case for reading the moderation review queue. Only users who can moderate games
+// may read it.
+func (h ReadModerationQueueHandler) Handle(ctx context.Context, user game.User, params PageParams) (*ModerationQueuePage, error) {
+	if err := authorize(user, game.PermissionModerateGames); err != nil {
+		return nil, err
+	}
+
+	order := GameSort{Key: reportedAtSortKey}
+
+	page, err := h.cursors.page(params, order)
+	if err != nil {
+		return nil, err
+	}
+
+	cases, err := h.readModel.ReadModerationQueue(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.cursors.moderationQueuePage(cases, page, order)
+}
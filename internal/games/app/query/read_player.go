@@ -1,6 +1,15 @@
 package query
 
-import "context"
+import (
+	"context"
+	stderrors "errors"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/games/domain/game"
+)
+
+var (
+	ErrorPlayerNotFound = errors.NewNotFoundError("player not found", "player-not-found")
+)
 
 // ReadPlayerHandler handles reading a player.
 type ReadPlayerHandler struct {
@@ -18,10 +27,21 @@ func NewReadPlayerHandler(readModel PlayerReadModel) ReadPlayerHandler {
 
 // PlayerReadModel is the interface used for reading a Player for a client query.
 type PlayerReadModel interface {
+	// ReadPlayer returns ErrorProjectionNotFound if the player does not exist.
 	ReadPlayer(ctx context.Context, uuid string) (*Player, error)
 }
 
-// Handle handles the use case for reading a player.
-func (h ReadPlayerHandler) Handle(ctx context.Context, uuid string) (*Player, error) {
-	return h.readModel.ReadPlayer(ctx, uuid)
+// Handle handles the use case for reading a player. ErrorNotPlayer is returned if the user isn't the
+// player and can't manage players and ErrorPlayerNotFound if the player does not exist.
+func (h ReadPlayerHandler) Handle(ctx context.Context, user game.User, uuid string) (*Player, error) {
+	if err := authorizePlayer(user, uuid); err != nil {
+		return nil, err
+	}
+
+	p, err := h.readModel.ReadPlayer(ctx, uuid)
+	if stderrors.Is(err, ErrorProjectionNotFound) {
+		return nil, ErrorPlayerNotFound
+	}
+
+	return p, err
 }
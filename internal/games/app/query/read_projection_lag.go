@@ -0,0 +1,35 @@
+package query
+
+import (
+	"context"
+	"gopher-cache/internal/games/domain/game"
+)
+
+// ReadProjectionLagHandler handles reading the projection lag metrics.
+type ReadProjectionLagHandler struct {
+	readModel ProjectionLagReadModel
+}
+
+// NewReadProjectionLagHandler creates a new handler.
+func NewReadProjectionLagHandler(readModel ProjectionLagReadModel) ReadProjectionLagHandler {
+	if readModel == nil {
+		panic("nil readModel")
+	}
+
+	return ReadProjectionLagHandler{readModel: readModel}
+}
+
+// ProjectionLagReadModel is the interface used for reading the ProjectionLag for a client query.
+type ProjectionLagReadModel interface {
+	ReadProjectionLag(ctx context.Context) (*ProjectionLag, error)
+}
+
+// Handle handles the use case for reading the projection lag. Only users who can manage projections
+// may read it.
+func (h ReadProjectionLagHandler) Handle(ctx context.Context, user game.User) (*ProjectionLag, error) {
+	if err := authorize(user, game.PermissionManageProjections); err != nil {
+		return nil, err
+	}
+
+	return h.readModel.ReadProjectionLag(ctx)
+}
@@ -1,6 +1,15 @@
 package query
 
-import "context"
+import (
+	"context"
+	stderrors "errors"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/games/domain/game"
+)
+
+var (
+	ErrorStateNotFound = errors.NewNotFoundError("game state not found", "game-state-not-found")
+)
 
 // ReadStateHandler handles the reading of game states.
 type ReadStateHandler struct {
@@ -18,10 +27,49 @@ func NewReadStateHandler(readModel StateReadModel) ReadStateHandler {
 
 // StateReadModel is the interface used for reading State for a client query.
 type StateReadModel interface {
+	// ReadState returns ErrorProjectionNotFound if the state does not exist.
 	ReadState(ctx context.Context, uuid string) (*State, error)
+	// ReadStatesByUUID returns the states with the uuids in the same order. States that don't exist are nil.
+	ReadStatesByUUID(ctx context.Context, uuids []string) ([]*State, error)
 }
 
-// Handle handles the use case for reading game states.
-func (h ReadStateHandler) Handle(ctx context.Context, uuid string) (*State, error) {
-	return h.readModel.ReadState(ctx, uuid)
+// Handle handles the use case for reading game states. ErrorStateNotFound is returned if the state does
+// not exist or if the user isn't the player of the state and can't manage players, so the state of
+// another player can't be told apart from one that doesn't exist.
+func (h ReadStateHandler) Handle(ctx context.Context, user game.User, uuid string) (*State, error) {
+	s, err := h.readModel.ReadState(ctx, uuid)
+	if stderrors.Is(err, ErrorProjectionNotFound) {
+		return nil, ErrorStateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizePlayer(user, s.PlayerUUID); err != nil {
+		return nil, ErrorStateNotFound
+	}
+
+	return s, nil
+}
+
+// HandleBatch handles the use case for reading several game states at once, e.g. the states of a player's
+// history. The states are in the same order as the uuids and are nil if they don't exist or the user may
+// not read them.
+func (h ReadStateHandler) HandleBatch(ctx context.Context, user game.User, uuids []string) ([]*State, error) {
+	states, err := h.readModel.ReadStatesByUUID(ctx, uuids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, s := range states {
+		if s == nil {
+			continue
+		}
+
+		if err := authorizePlayer(user, s.PlayerUUID); err != nil {
+			states[i] = nil
+		}
+	}
+
+	return states, nil
 }
@@ -0,0 +1,135 @@
+package query
+
+import (
+	"context"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/games/domain/game"
+	"unicode/utf8"
+)
+
+// MaxSearchTextLength is the longest text games can be searched for.
+const MaxSearchTextLength = 200
+
+// SearchGamesHandler handles searching the text of games.
+type SearchGamesHandler struct {
+	readModel GameSearchReadModel
+	cursors   CursorSigner
+}
+
+// NewSearchGamesHandler creates a new handler.
+func NewSearchGamesHandler(readModel GameSearchReadModel, cursors CursorSigner) SearchGamesHandler {
+	if readModel == nil {
+		panic("nil readModel")
+	}
+
+	return SearchGamesHandler{readModel: readModel, cursors: cursors}
+}
+
+// GameSearchIndex is the interface used to keep a full-text index of games up to date.
+type GameSearchIndex interface {
+	// IndexGame adds the game to the index or replaces it if it was already indexed.
+	IndexGame(ctx context.Context, game *Game) error
+	// RemoveGame removes the game from the index. It does nothing if the game isn't indexed.
+	RemoveGame(ctx context.Context, uuid string) error
+	// ClearIndex removes every game from the index.
+	ClearIndex(ctx context.Context) error
+}
+
+// GameProjectionWatcher is the interface used to follow the game projections saved by every instance.
+type GameProjectionWatcher interface {
+	// WatchGameProjections calls changed with every game projection and then with the changes made since the
+	// last call, until ctx is done or changed returns an error.
+	WatchGameProjections(ctx context.Context, changed func(ctx context.Context, changes GameProjectionChanges) error) error
+}
+
+// GameProjectionChanges are the game projections saved and removed since the last changes.
+type GameProjectionChanges struct {
+	// All is true if Saved has every game projection, so any others have been removed.
+	All     bool
+	Saved   []*Game
+	Removed []string
+}
+
+// SyncGameSearchIndex fills index with the published games and keeps it up to date with the games projected
+// by every instance. It returns when ctx is done or following the game projections fails.
+func SyncGameSearchIndex(ctx context.Context, watcher GameProjectionWatcher, index GameSearchIndex) error {
+	return watcher.WatchGameProjections(ctx, func(ctx context.Context, changes GameProjectionChanges) error {
+		if changes.All {
+			if err := index.ClearIndex(ctx); err != nil {
+				return err
+			}
+		}
+
+		for _, g := range changes.Saved {
+			if err := indexGame(ctx, index, g); err != nil {
+				return err
+			}
+		}
+
+		for _, uuid := range changes.Removed {
+			if err := index.RemoveGame(ctx, uuid); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// indexGame adds a published game to index and removes any other game, e.g. one that was unpublished.
+func indexGame(ctx context.Context, index GameSearchIndex, g *Game) error {
+	if g.Status == string(game.StatusPublished) {
+		return index.IndexGame(ctx, g)
+	}
+
+	return index.RemoveGame(ctx, g.UUID)
+}
+
+// GameSearchReadModel is the interface used for searching games for a client query.
+type GameSearchReadModel interface {
+	// SearchGames returns the page of games matching the search ordered by relevance, then UUID, in
+	// descending order with Game.Score set. It will return an empty non-nil slice if no games are found.
+	SearchGames(ctx context.Context, search GameSearch, page Page) ([]*Game, error)
+}
+
+// Handle is the use case for searching games.
+func (h SearchGamesHandler) Handle(ctx context.Context, user game.User, search GameSearch, params PageParams) (*GamesPage, error) {
+	if err := authorizeReader(user); err != nil {
+		return nil, err
+	}
+
+	if search.Text == "" {
+		return nil, errors.NewIncorrectInputError("search text is required", "empty-search")
+	}
+
+	if utf8.RuneCountInString(search.Text) > MaxSearchTextLength {
+		return nil, errors.NewIncorrectInputError("search text length greater than 200", "search-too-long")
+	}
+
+	order := GameSort{Key: scoreSortKey, Descending: true}
+
+	page, err := h.cursors.page(params, order)
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := h.readModel.SearchGames(ctx, search, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.cursors.gamesPage(games, page, order, func(g *Game) interface{} {
+		return *g.Score
+	})
+}
+
+// GameSearch is used for finding games by the words in their title, description and city.
+// The facets are optional and only games matching them, ignoring case, are returned.
+type GameSearch struct {
+	Text string
+
+	Kind    string
+	City    string
+	State   string
+	Country string
+}
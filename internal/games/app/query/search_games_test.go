@@ -0,0 +1,58 @@
+package query
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/games/domain/game"
+	"testing"
+)
+
+// changesWatcher sends the changes given as if each was made by an instance.
+type changesWatcher []GameProjectionChanges
+
+func (w changesWatcher) WatchGameProjections(ctx context.Context, changed func(ctx context.Context, changes GameProjectionChanges) error) error {
+	for _, changes := range w {
+		if err := changed(ctx, changes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setIndex records the UUIDs of the games indexed.
+type setIndex map[string]bool
+
+func (i setIndex) IndexGame(_ context.Context, g *Game) error {
+	i[g.UUID] = true
+	return nil
+}
+
+func (i setIndex) RemoveGame(_ context.Context, uuid string) error {
+	delete(i, uuid)
+	return nil
+}
+
+func (i setIndex) ClearIndex(_ context.Context) error {
+	for uuid := range i {
+		delete(i, uuid)
+	}
+
+	return nil
+}
+
+func TestSyncGameSearchIndex(t *testing.T) {
+	published := string(game.StatusPublished)
+	index := setIndex{"stale": true}
+
+	err := SyncGameSearchIndex(context.Background(), changesWatcher{
+		{All: true, Saved: []*Game{{UUID: "a", Status: published}, {UUID: "b", Status: published}, {UUID: "held"}}},
+		{Saved: []*Game{{UUID: "c", Status: published}, {UUID: "b"}}},
+		{Removed: []string{"a"}},
+	}, index)
+	require.NoError(t, err)
+
+	// The stale game was gone before the index was filled, b was unpublished and a was removed.
+	assert.Equal(t, setIndex{"c": true}, index)
+}
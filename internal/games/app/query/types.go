@@ -1,21 +1,202 @@
 package query
 
-import "gopher-cache/internal/games/domain/game"
+import (
+	"gopher-cache/internal/games/domain/game"
+	"time"
+)
 
 // Game represents how Game queries will be presented to clients.
 type Game struct {
 	UUID        string `json:"uuid"`
+	CreatorUUID string `json:"-"`
+	// CreatorName is the display name of the creator.
+	CreatorName string `json:"creatorName"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
+	Kind        string `json:"kind"`
+	City        string `json:"city"`
+	State       string `json:"state"`
+	Country     string `json:"country"`
+	Levels      int    `json:"levels"`
+	Value       int    `json:"value"`
+	PlayCount   int    `json:"playCount"`
+	// CompletionCount is the number of times the game has been finished by a player.
+	CompletionCount int `json:"completionCount"`
+	// AverageCompletionSeconds is the average time it took players to finish the game.
+	AverageCompletionSeconds float64 `json:"averageCompletionSeconds"`
+	RatingCount              int     `json:"ratingCount"`
+	// RatingTotal is the sum of the stars of every rating.
+	RatingTotal int `json:"-"`
+	// RatingRevision is the revision of the game's rating summary the rating count and total are from.
+	RatingRevision int       `json:"-"`
+	AverageRating  float64   `json:"averageRating"`
+	CreatedAt      time.Time `json:"createdAt"`
+	// Status is the moderation status of the game. Only published games are shown to players.
+	Status string `json:"status"`
+	// Location is the starting point of the game. It is nil if the game has no location.
+	Location *Location `json:"location,omitempty"`
+	// DistanceKm is only set when games are queried near a location.
+	DistanceKm *float64 `json:"distanceKm,omitempty"`
+	// Score is the relevance of the game to a search. It is only set when games are searched.
+	Score *float64 `json:"score,omitempty"`
+}
+
+// GameDetail represents how the public details of a single game will be presented to clients.
+// Like Game it never includes the clues, answers or ending of the game.
+type GameDetail struct {
+	Game
+	// EstimatedDurationSeconds is the average time players took to finish the game or an estimate
+	// from the number of levels if no one has finished it yet.
+	EstimatedDurationSeconds float64 `json:"estimatedDurationSeconds"`
+}
+
+// Review represents how a player's rating of a game will be presented to clients.
+type Review struct {
+	// ID is unique to the game and player since players only have one rating per game.
+	ID         string    `json:"-"`
+	GameUUID   string    `json:"gameUUID"`
+	PlayerUUID string    `json:"-"`
+	PlayerName string    `json:"playerName"`
+	Stars      int       `json:"stars"`
+	Review     string    `json:"review,omitempty"`
+	RatedAt    time.Time `json:"ratedAt"`
+}
+
+// ReviewsPage is a page of reviews of a game.
+type ReviewsPage struct {
+	Reviews []*Review `json:"reviews"`
+	// NextCursor gets the next page. It is empty if this is the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Location represents a point on the earth.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
 }
 
 // Player represents how Player queries will be presented to clients.
 type Player struct {
-	GamesFinished int `json:"gamesFinished"`
-	TotalPoints   int `json:"totalPoints"`
+	UUID          string       `json:"uuid"`
+	GamesStarted  int          `json:"gamesStarted"`
+	GamesFinished int          `json:"gamesFinished"`
+	TotalPoints   int          `json:"totalPoints"`
+	History       []PlayedGame `json:"history"`
+}
+
+// PlayedGame is an entry in a player's history.
+type PlayedGame struct {
+	StateUUID string `json:"stateUUID"`
+	GameUUID  string `json:"gameUUID"`
+	GameTitle string `json:"gameTitle"`
+	Completed bool   `json:"completed"`
+	// Quit is true if the player quit the game before completing it.
+	Quit       bool      `json:"quit"`
+	Points     int       `json:"points"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
 }
 
 // State represents how State queries will be presented to clients.
 type State struct {
+	UUID       string `json:"uuid"`
+	PlayerUUID string `json:"playerUUID"`
+	GameUUID   string `json:"gameUUID"`
+	GameLevels int    `json:"gameLevels"`
+	Level      int    `json:"level"`
+	Completed  bool   `json:"completed"`
+	// Quit is true if the player quit the game, so it can't be continued.
+	Quit            bool          `json:"quit"`
 	CurrentResponse game.Response `json:"currentResponse"`
+	// CurrentLevel is the level the player is on. It is nil once the game is completed.
+	CurrentLevel *Level    `json:"currentLevel,omitempty"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// Level represents the public details of a level of a game, which never include its clues or answers.
+type Level struct {
+	// Number is the index of the level in the game.
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// GameAnalytics represents how analytics for a game will be presented to its creator.
+type GameAnalytics struct {
+	GameUUID    string           `json:"gameUUID"`
+	CreatorUUID string           `json:"-"`
+	Title       string           `json:"title"`
+	Levels      []LevelAnalytics `json:"levels"`
+}
+
+// LevelAnalytics holds how players fared on one level of a game.
+type LevelAnalytics struct {
+	Level int `json:"level"`
+	// PlayersReached is the number of players that made it to the level.
+	PlayersReached int `json:"playersReached"`
+	// PlayersCompleted is the number of players that answered the level correctly.
+	PlayersCompleted int `json:"playersCompleted"`
+	// PlayersSkipped is the number of players that moved on from the level without answering it.
+	PlayersSkipped int `json:"playersSkipped"`
+	// DropOff is the number of players that reached the level but haven't completed or skipped it.
+	DropOff int `json:"dropOff"`
+	// Attempts is the total number of inputs submitted on the level, correct or not.
+	Attempts        int     `json:"attempts"`
+	AverageAttempts float64 `json:"averageAttempts"`
+	// CluesShown is the total number of clues shown to players on the level.
+	CluesShown        int     `json:"cluesShown"`
+	AverageCluesShown float64 `json:"averageCluesShown"`
+	// WrongAnswers counts each distinct wrong answer given on the level.
+	WrongAnswers           map[string]int `json:"-"`
+	MostCommonWrongAnswers []WrongAnswer  `json:"mostCommonWrongAnswers"`
+}
+
+// WrongAnswer is a wrong answer and the number of times it was given.
+type WrongAnswer struct {
+	Answer string `json:"answer"`
+	Count  int    `json:"count"`
+}
+
+// ModerationCase represents a game in the moderation review queue with the open reports against it.
+type ModerationCase struct {
+	GameUUID    string `json:"gameUUID"`
+	CreatorUUID string `json:"creatorUUID"`
+	Title       string `json:"title"`
+	Status      string `json:"status"`
+	// Reports is the number of open reports.
+	Reports int `json:"reports"`
+	// Reasons counts the open reports by reason.
+	Reasons map[string]int `json:"reasons"`
+	// Details holds the details given by players, most recent last.
+	Details []string `json:"details"`
+	// Flags holds the problems found by moderation when the game was created.
+	Flags           []ModerationFlag `json:"flags"`
+	FirstReportedAt time.Time        `json:"firstReportedAt"`
+	LastReportedAt  time.Time        `json:"lastReportedAt"`
+}
+
+// ModerationFlag represents a problem moderation found in the content of a game.
+type ModerationFlag struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	Match string `json:"match"`
+}
+
+// ModerationQueuePage is a page of the moderation review queue.
+type ModerationQueuePage struct {
+	Cases []*ModerationCase `json:"cases"`
+	// NextCursor gets the next page. It is empty if this is the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// APIKey represents how an API key will be presented to admins. The key itself is never shown.
+type APIKey struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Scopes    []string   `json:"scopes"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+	Active    bool       `json:"active"`
 }
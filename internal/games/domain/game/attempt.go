@@ -0,0 +1,91 @@
+package game
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+var ErrorAttemptStatesDiffer = newValidationError("attempt is for another game state", "attempt-state-mismatch")
+
+// Attempt is a record of a single input a player submitted while on a level of a game.
+type Attempt struct {
+	uuid       string
+	stateUUID  string
+	gameUUID   string
+	playerUUID string
+	level      int
+	input      string
+	correct    bool
+	// clueShown is the index of the clue shown in response to the input or -1 if none was shown.
+	clueShown   int
+	submittedAt time.Time
+}
+
+func (a Attempt) UUID() string           { return a.uuid }
+func (a Attempt) StateUUID() string      { return a.stateUUID }
+func (a Attempt) GameUUID() string       { return a.gameUUID }
+func (a Attempt) PlayerUUID() string     { return a.playerUUID }
+func (a Attempt) Level() int             { return a.level }
+func (a Attempt) Input() string          { return a.input }
+func (a Attempt) Correct() bool          { return a.correct }
+func (a Attempt) ClueShown() int         { return a.clueShown }
+func (a Attempt) SubmittedAt() time.Time { return a.submittedAt }
+
+// NewAttempt creates an Attempt from the state before and after the input was applied with State.Update.
+func NewAttempt(before State, after *State, input string) (*Attempt, error) {
+	if before.uuid != after.uuid {
+		return nil, ErrorAttemptStatesDiffer
+	}
+
+	if before.completed {
+		return nil, newConflictError("game was already completed", "game-already-completed")
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Attempt{
+		uuid:        id.String(),
+		stateUUID:   after.uuid,
+		gameUUID:    after.gameUUID,
+		playerUUID:  after.playerUUID,
+		level:       before.level,
+		input:       input,
+		correct:     after.level > before.level,
+		clueShown:   -1,
+		submittedAt: now(),
+	}
+
+	if !a.correct && after.currentResponse.Kind == ClueResponse {
+		a.clueShown = after.clue
+	}
+
+	return a, nil
+}
+
+// UnmarshalAttemptFromDatabase should only be used in repo implementations to unmarshal data from a database
+// into a domain attempt.
+func UnmarshalAttemptFromDatabase(
+	uuid,
+	stateUUID,
+	gameUUID,
+	playerUUID string,
+	level int,
+	input string,
+	correct bool,
+	clueShown int,
+	submittedAt time.Time) *Attempt {
+	return &Attempt{
+		uuid:        uuid,
+		stateUUID:   stateUUID,
+		gameUUID:    gameUUID,
+		playerUUID:  playerUUID,
+		level:       level,
+		input:       input,
+		correct:     correct,
+		clueShown:   clueShown,
+		submittedAt: submittedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewAttempt(t *testing.T) {
+	g := newValidTestUrbanGame()
+	p := newValidTestPlayer()
+	s, _, err := Start(g, p)
+	require.NoError(t, err)
+
+	t.Run("wrong answer", func(t *testing.T) {
+		before := *s
+		_, err := s.Update(g, "wrong answer", p)
+		require.NoError(t, err)
+
+		a, err := NewAttempt(before, s, "wrong answer")
+		require.NoError(t, err)
+
+		assert.Equal(t, s.UUID(), a.StateUUID())
+		assert.Equal(t, g.UUID(), a.GameUUID())
+		assert.Equal(t, p.UUID(), a.PlayerUUID())
+		assert.Equal(t, 0, a.Level())
+		assert.Equal(t, "wrong answer", a.Input())
+		assert.False(t, a.Correct())
+		assert.Equal(t, 0, a.ClueShown())
+	})
+
+	t.Run("correct answer", func(t *testing.T) {
+		before := *s
+		_, err := s.Update(g, g.levels[0].answers[0], p)
+		require.NoError(t, err)
+
+		a, err := NewAttempt(before, s, g.levels[0].answers[0])
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, a.Level())
+		assert.True(t, a.Correct())
+		assert.Equal(t, -1, a.ClueShown())
+	})
+
+	t.Run("mismatched states", func(t *testing.T) {
+		_, err := NewAttempt(State{}, s, "input")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("completed game", func(t *testing.T) {
+		_, err := s.Update(g, g.levels[1].answers[0], p)
+		require.NoError(t, err)
+		_, err = s.Update(g, g.levels[2].answers[0], p)
+		require.NoError(t, err)
+		require.True(t, s.Completed())
+
+		_, err = NewAttempt(*s, s, "input")
+		assert.NotNil(t, err)
+	})
+}
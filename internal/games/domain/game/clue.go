@@ -0,0 +1,51 @@
+package game
+
+import (
+	"time"
+)
+
+// MaxClueCooldown is the longest a game can make players wait between clues.
+const MaxClueCooldown = time.Hour
+
+var (
+	ErrorInvalidClueCooldown = newValidationError("clue cooldown must be between 0 and 1h", "invalid-clue-cooldown")
+	ErrorClueCooldown        = newConflictError("the next clue isn't available yet", "clue-cooldown")
+)
+
+// ClueSettings control how players get the clues of a game's levels.
+type ClueSettings struct {
+	// wrongAnswersRevealClues is whether a wrong answer reveals the next clue as well as asking for one.
+	wrongAnswersRevealClues bool
+	// cooldown is how long a player must wait after a clue is revealed before the next one is.
+	cooldown time.Duration
+}
+
+func (c ClueSettings) WrongAnswersRevealClues() bool { return c.wrongAnswersRevealClues }
+func (c ClueSettings) Cooldown() time.Duration       { return c.cooldown }
+
+// DefaultClueSettings are the settings of games that don't choose any, which are how clues were given
+// before players could ask for them: every wrong answer reveals the next clue straight away.
+func DefaultClueSettings() ClueSettings {
+	return ClueSettings{wrongAnswersRevealClues: true}
+}
+
+// NewClueSettings creates clue settings. A cooldown of 0 lets players get clues as fast as they ask.
+func NewClueSettings(wrongAnswersRevealClues bool, cooldown time.Duration) (ClueSettings, error) {
+	if cooldown < 0 || cooldown > MaxClueCooldown {
+		return ClueSettings{}, ErrorInvalidClueCooldown
+	}
+
+	return ClueSettings{
+		wrongAnswersRevealClues: wrongAnswersRevealClues,
+		cooldown:                cooldown,
+	}, nil
+}
+
+// UnmarshalClueSettingsFromDatabase should only be used in repo implementations to unmarshal data from a
+// database into domain clue settings.
+func UnmarshalClueSettingsFromDatabase(wrongAnswersRevealClues bool, cooldown time.Duration) ClueSettings {
+	return ClueSettings{
+		wrongAnswersRevealClues: wrongAnswersRevealClues,
+		cooldown:                cooldown,
+	}
+}
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestNewClueSettings(t *testing.T) {
+	c, err := NewClueSettings(false, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, c.WrongAnswersRevealClues())
+	assert.Equal(t, time.Minute, c.Cooldown())
+
+	_, err = NewClueSettings(true, -time.Second)
+	assert.Equal(t, ErrorInvalidClueCooldown, err)
+
+	_, err = NewClueSettings(true, MaxClueCooldown+time.Second)
+	assert.Equal(t, ErrorInvalidClueCooldown, err)
+
+	assert.True(t, newValidTestUrbanGame().ClueSettings().WrongAnswersRevealClues())
+}
+
+func TestState_ClueSettings(t *testing.T) {
+	g := newValidTestUrbanGame()
+	p := newValidTestPlayer()
+	s, _, err := Start(g, p)
+	require.NoError(t, err)
+
+	l1 := g.levels[0]
+
+	clues, err := NewClueSettings(false, time.Minute)
+	require.NoError(t, err)
+	g.SetClueSettings(clues)
+
+	t.Run("wrong answers don't reveal clues", func(t *testing.T) {
+		resp, err := s.Update(g, "wrong answer", p)
+		require.NoError(t, err)
+		assert.Equal(t, IncorrectResponse, resp.Kind)
+		assert.Equal(t, -1, s.Clue())
+		assert.Equal(t, LevelResponse, s.CurrentResponse().Kind)
+	})
+
+	t.Run("cooldown", func(t *testing.T) {
+		assert.True(t, s.NextClueAt(g).IsZero())
+
+		resp, err := s.RequestClue(g)
+		require.NoError(t, err)
+		assert.Equal(t, l1.clues[0], resp.Clue)
+		assert.Equal(t, s.ClueRevealedAt().Add(time.Minute), s.NextClueAt(g))
+
+		_, err = s.RequestClue(g)
+		assert.Equal(t, ErrorClueCooldown, err)
+		assert.Equal(t, 0, s.Clue())
+
+		s.clueRevealedAt = s.clueRevealedAt.Add(-time.Minute)
+
+		resp, err = s.RequestClue(g)
+		require.NoError(t, err)
+		assert.Equal(t, l1.clues[1], resp.Clue)
+	})
+
+	t.Run("wrong answers during cooldown", func(t *testing.T) {
+		clues, err := NewClueSettings(true, time.Minute)
+		require.NoError(t, err)
+		g.SetClueSettings(clues)
+
+		resp, err := s.Update(g, "wrong answer", p)
+		require.NoError(t, err)
+		assert.Equal(t, IncorrectResponse, resp.Kind)
+		assert.Equal(t, 1, s.Clue())
+
+		s.clueRevealedAt = s.clueRevealedAt.Add(-time.Minute)
+
+		resp, err = s.Update(g, "wrong answer", p)
+		require.NoError(t, err)
+		assert.Equal(t, l1.clues[2], resp.Clue)
+	})
+
+	t.Run("last clue repeats without cooldown", func(t *testing.T) {
+		assert.True(t, s.NextClueAt(g).IsZero())
+
+		resp, err := s.RequestClue(g)
+		require.NoError(t, err)
+		assert.Equal(t, l1.clues[2], resp.Clue)
+	})
+
+	t.Run("next level has no cooldown", func(t *testing.T) {
+		_, err := s.Update(g, l1.answers[0], p)
+		require.NoError(t, err)
+		assert.True(t, s.ClueRevealedAt().IsZero())
+
+		resp, err := s.RequestClue(g)
+		require.NoError(t, err)
+		assert.Equal(t, g.levels[1].clues[0], resp.Clue)
+	})
+}
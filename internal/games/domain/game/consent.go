@@ -0,0 +1,185 @@
+package game
+
+import (
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+var (
+	ErrorNumberOptedOut = newForbiddenError("number has opted out of messages", "number-opted-out")
+)
+
+// ConsentAction is what a player did about receiving messages.
+type ConsentAction string
+
+const (
+	ConsentGiven     ConsentAction = "given"
+	ConsentWithdrawn ConsentAction = "withdrawn"
+)
+
+// ConsentSourceStartGame is the source of the consent a player gives by starting their first game.
+const ConsentSourceStartGame = "start-game"
+
+// ConsentRecord is kept every time a player gives or withdraws their consent to receive messages so it can
+// be shown when and how the player agreed to them.
+type ConsentRecord struct {
+	uuid       string
+	playerUUID string
+	// number is the number consent was given or withdrawn for, in case the player's number changes.
+	number string
+	action ConsentAction
+	// source is how consent was given or withdrawn, e.g. start-game or the keyword texted.
+	source     string
+	recordedAt time.Time
+}
+
+func (r *ConsentRecord) UUID() string          { return r.uuid }
+func (r *ConsentRecord) PlayerUUID() string    { return r.playerUUID }
+func (r *ConsentRecord) Number() string        { return r.number }
+func (r *ConsentRecord) Action() ConsentAction { return r.action }
+func (r *ConsentRecord) Source() string        { return r.source }
+func (r *ConsentRecord) RecordedAt() time.Time { return r.recordedAt }
+
+func newConsentRecord(p *Player, action ConsentAction, source string, at time.Time) (*ConsentRecord, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConsentRecord{
+		uuid:       id.String(),
+		playerUUID: p.uuid,
+		number:     p.number,
+		action:     action,
+		source:     source,
+		recordedAt: at,
+	}, nil
+}
+
+// UnmarshalConsentRecordFromDatabase should only be used in repo implementations to unmarshal data from a
+// database into a domain consent record.
+func UnmarshalConsentRecordFromDatabase(
+	uuid,
+	playerUUID,
+	number string,
+	action ConsentAction,
+	source string,
+	recordedAt time.Time) *ConsentRecord {
+	return &ConsentRecord{
+		uuid:       uuid,
+		playerUUID: playerUUID,
+		number:     number,
+		action:     action,
+		source:     source,
+		recordedAt: recordedAt,
+	}
+}
+
+// Consented reports whether the player has agreed to receive messages.
+func (p *Player) Consented() bool { return !p.consentedAt.IsZero() }
+
+// OptedOut reports whether the player has asked not to receive any more messages.
+func (p *Player) OptedOut() bool { return !p.optedOutAt.IsZero() }
+
+// CanReceiveMessages reports whether game messages may be sent to the player.
+func (p *Player) CanReceiveMessages() bool {
+	return p.NumberVerified() && !p.OptedOut()
+}
+
+// GiveConsent records that the player agreed to receive messages from source. It returns a nil record if
+// the player had already consented and ErrorNumberOptedOut if the player has opted out, since only opting
+// back in with a keyword undoes that.
+func (p *Player) GiveConsent(source string) (*ConsentRecord, error) {
+	if p.OptedOut() {
+		return nil, ErrorNumberOptedOut
+	}
+
+	if p.Consented() {
+		return nil, nil
+	}
+
+	p.consentedAt = now()
+
+	return newConsentRecord(p, ConsentGiven, source, p.consentedAt)
+}
+
+// OptOut records that the player asked not to receive any more messages with source. It returns a nil record
+// if the player had already opted out.
+func (p *Player) OptOut(source string) (*ConsentRecord, error) {
+	if p.OptedOut() {
+		return nil, nil
+	}
+
+	p.optedOutAt = now()
+
+	return newConsentRecord(p, ConsentWithdrawn, source, p.optedOutAt)
+}
+
+// OptIn records that the player asked to receive messages again with source. It returns a nil record if the
+// player had consented and not opted out.
+func (p *Player) OptIn(source string) (*ConsentRecord, error) {
+	if p.Consented() && !p.OptedOut() {
+		return nil, nil
+	}
+
+	p.optedOutAt = time.Time{}
+	p.consentedAt = now()
+
+	return newConsentRecord(p, ConsentGiven, source, p.consentedAt)
+}
+
+// MessagingKeyword is a keyword that carriers require every SMS program to honour, whatever else the
+// program does with a player's messages.
+type MessagingKeyword string
+
+const (
+	KeywordStop  MessagingKeyword = "STOP"
+	KeywordStart MessagingKeyword = "START"
+	KeywordHelp  MessagingKeyword = "HELP"
+)
+
+// messagingKeywords are the words players may text for each keyword.
+var messagingKeywords = map[string]MessagingKeyword{
+	"STOP":        KeywordStop,
+	"STOPALL":     KeywordStop,
+	"UNSUBSCRIBE": KeywordStop,
+	"CANCEL":      KeywordStop,
+	"END":         KeywordStop,
+	"QUIT":        KeywordStop,
+	"START":       KeywordStart,
+	"UNSTOP":      KeywordStart,
+	"HELP":        KeywordHelp,
+	"INFO":        KeywordHelp,
+}
+
+// ParseMessagingKeyword returns the keyword if the whole message is one, ignoring case, spaces and
+// trailing punctuation. Keywords take precedence over answers.
+func ParseMessagingKeyword(message string) (MessagingKeyword, bool) {
+	keyword, ok := messagingKeywords[keywordText(message)]
+	return keyword, ok
+}
+
+// KeywordSource returns the consent source of a keyword texted by a player, e.g. sms:UNSUBSCRIBE.
+func KeywordSource(message string) string {
+	return "sms:" + keywordText(message)
+}
+
+func keywordText(message string) string {
+	return strings.ToUpper(strings.TrimRight(strings.TrimSpace(message), ".!"))
+}
+
+// NewKeywordResponse returns the reply to a messaging keyword in the message locale that best matches
+// locale.
+func NewKeywordResponse(keyword MessagingKeyword, locale string) *Response {
+	p := printer(locale)
+
+	switch keyword {
+	case KeywordStop:
+		return newMessageResponse(p.Sprintf(OptOutMessage))
+	case KeywordStart:
+		return newMessageResponse(p.Sprintf(OptInMessage))
+	default:
+		return newMessageResponse(p.Sprintf(HelpMessage))
+	}
+}
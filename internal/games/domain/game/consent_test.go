@@ -0,0 +1,85 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParseMessagingKeyword(t *testing.T) {
+	tests := []struct {
+		message string
+		keyword MessagingKeyword
+		ok      bool
+	}{
+		{"STOP", KeywordStop, true},
+		{" stop! ", KeywordStop, true},
+		{"Unsubscribe.", KeywordStop, true},
+		{"start", KeywordStart, true},
+		{"UNSTOP", KeywordStart, true},
+		{"help", KeywordHelp, true},
+		{"info", KeywordHelp, true},
+		{"stop sending me clues", "", false},
+		{"The answer", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			keyword, ok := ParseMessagingKeyword(tt.message)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.keyword, keyword)
+		})
+	}
+
+	assert.Equal(t, "sms:UNSUBSCRIBE", KeywordSource(" unsubscribe. "))
+}
+
+func TestPlayer_Consent(t *testing.T) {
+	p := newValidTestPlayer()
+	require.NoError(t, p.StartNumberVerification("123456", time.Now()))
+	require.NoError(t, p.VerifyNumber("123456", time.Now()))
+
+	assert.False(t, p.Consented())
+	assert.True(t, p.CanReceiveMessages())
+
+	record, err := p.GiveConsent(ConsentSourceStartGame)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, p.UUID(), record.PlayerUUID())
+	assert.Equal(t, p.Number(), record.Number())
+	assert.Equal(t, ConsentGiven, record.Action())
+	assert.Equal(t, ConsentSourceStartGame, record.Source())
+	assert.True(t, p.Consented())
+
+	// Consent is only recorded once.
+	record, err = p.GiveConsent(ConsentSourceStartGame)
+	require.NoError(t, err)
+	assert.Nil(t, record)
+
+	record, err = p.OptOut("sms:STOP")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, ConsentWithdrawn, record.Action())
+	assert.True(t, p.OptedOut())
+	assert.False(t, p.CanReceiveMessages())
+
+	record, err = p.OptOut("sms:STOP")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+
+	// Only opting back in undoes opting out.
+	_, err = p.GiveConsent(ConsentSourceStartGame)
+	assert.Equal(t, ErrorNumberOptedOut, err)
+
+	record, err = p.OptIn("sms:START")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, ConsentGiven, record.Action())
+	assert.False(t, p.OptedOut())
+	assert.True(t, p.CanReceiveMessages())
+
+	record, err = p.OptIn("sms:START")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+}
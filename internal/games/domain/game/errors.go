@@ -0,0 +1,27 @@
+package game
+
+import "gopher-cache/internal/common/errors"
+
+// The errors of the game domain are typed so callers can tell input that breaks the rules of a game from
+// something that doesn't exist, a change the current state prevents or a user who isn't allowed. Errors
+// that aren't typed are bugs or failures outside the domain.
+
+// newValidationError creates an error for input that breaks the rules of the domain.
+func newValidationError(message, slug string) errors.SlugError {
+	return errors.NewIncorrectInputError(message, slug)
+}
+
+// newNotFoundError creates an error for something that doesn't exist.
+func newNotFoundError(message, slug string) errors.SlugError {
+	return errors.NewNotFoundError(message, slug)
+}
+
+// newConflictError creates an error for a change the current state of something prevents.
+func newConflictError(message, slug string) errors.SlugError {
+	return errors.NewConflictError(message, slug)
+}
+
+// newForbiddenError creates an error for a user or player who isn't allowed to do something.
+func newForbiddenError(message, slug string) errors.SlugError {
+	return errors.NewAuthorizationError(message, slug)
+}
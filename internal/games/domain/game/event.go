@@ -0,0 +1,303 @@
+package game
+
+import "time"
+
+// Event is something that happened in the game domain that other parts of the system may react to.
+type Event interface {
+	// EventName returns a name unique to the kind of event.
+	EventName() string
+	// OccurredAt returns the time the event happened.
+	OccurredAt() time.Time
+}
+
+// GameCreated is emitted after a game has been created.
+type GameCreated struct {
+	GameUUID    string
+	CreatorUUID string
+	CreatorName string
+	Title       string
+	Description string
+	Kind        string
+	City        string
+	State       string
+	Country     string
+	Levels      int
+	Value       int
+	// Location is nil if the game has no starting point.
+	Location *Location
+	Status   Status
+	At       time.Time
+}
+
+func (e GameCreated) EventName() string     { return "GameCreated" }
+func (e GameCreated) OccurredAt() time.Time { return e.At }
+
+// NewGameCreatedEvent creates a GameCreated event for a game.
+func NewGameCreatedEvent(g *Game) GameCreated {
+	return GameCreated{
+		GameUUID:    g.uuid,
+		CreatorUUID: g.creatorUUID,
+		CreatorName: g.creatorName,
+		Title:       g.title,
+		Description: g.description,
+		Kind:        g.kind,
+		City:        g.city,
+		State:       g.state,
+		Country:     g.country,
+		Levels:      len(g.levels),
+		Value:       g.value,
+		Location:    g.location,
+		Status:      g.status,
+		At:          g.createdAt,
+	}
+}
+
+// GameStarted is emitted after a player has started a game.
+type GameStarted struct {
+	StateUUID  string
+	GameUUID   string
+	PlayerUUID string
+	GameLevels int
+	Response   Response
+	At         time.Time
+}
+
+func (e GameStarted) EventName() string     { return "GameStarted" }
+func (e GameStarted) OccurredAt() time.Time { return e.At }
+
+// NewGameStartedEvent creates a GameStarted event for a newly started state.
+func NewGameStartedEvent(s *State) GameStarted {
+	return GameStarted{
+		StateUUID:  s.uuid,
+		GameUUID:   s.gameUUID,
+		PlayerUUID: s.playerUUID,
+		GameLevels: s.gameLevels,
+		Response:   s.currentResponse,
+		At:         s.startedAt,
+	}
+}
+
+// StateUpdated is emitted after a player's input has been applied to a state.
+type StateUpdated struct {
+	StateUUID  string
+	GameUUID   string
+	PlayerUUID string
+	Level      int
+	Completed  bool
+	Response   Response
+	At         time.Time
+}
+
+func (e StateUpdated) EventName() string     { return "StateUpdated" }
+func (e StateUpdated) OccurredAt() time.Time { return e.At }
+
+// NewStateUpdatedEvent creates a StateUpdated event for a state.
+func NewStateUpdatedEvent(s *State) StateUpdated {
+	return StateUpdated{
+		StateUUID:  s.uuid,
+		GameUUID:   s.gameUUID,
+		PlayerUUID: s.playerUUID,
+		Level:      s.level,
+		Completed:  s.completed,
+		Response:   s.currentResponse,
+		At:         now(),
+	}
+}
+
+// GameFinished is emitted after a player has completed every level of a game.
+type GameFinished struct {
+	StateUUID  string
+	GameUUID   string
+	PlayerUUID string
+	// Points are the points the player earned, which are none if they skipped a level.
+	Points int
+	// Duration is the time it took the player to go from starting to finishing the game.
+	Duration time.Duration
+	At       time.Time
+}
+
+func (e GameFinished) EventName() string     { return "GameFinished" }
+func (e GameFinished) OccurredAt() time.Time { return e.At }
+
+// NewGameFinishedEvent creates a GameFinished event for a completed state.
+func NewGameFinishedEvent(g *Game, s *State) GameFinished {
+	return GameFinished{
+		StateUUID:  s.uuid,
+		GameUUID:   s.gameUUID,
+		PlayerUUID: s.playerUUID,
+		Points:     s.points(g),
+		Duration:   s.finishedAt.Sub(s.startedAt),
+		At:         s.finishedAt,
+	}
+}
+
+// GameQuit is emitted after a player has quit a game, which is then no longer their current game.
+type GameQuit struct {
+	StateUUID  string
+	GameUUID   string
+	PlayerUUID string
+	At         time.Time
+}
+
+func (e GameQuit) EventName() string     { return "GameQuit" }
+func (e GameQuit) OccurredAt() time.Time { return e.At }
+
+// NewGameQuitEvent creates a GameQuit event for a state the player quit.
+func NewGameQuitEvent(s *State) GameQuit {
+	return GameQuit{
+		StateUUID:  s.uuid,
+		GameUUID:   s.gameUUID,
+		PlayerUUID: s.playerUUID,
+		At:         now(),
+	}
+}
+
+// LevelSkipped is emitted after a player has moved on from a level without answering it.
+type LevelSkipped struct {
+	StateUUID  string
+	GameUUID   string
+	PlayerUUID string
+	Level      int
+	At         time.Time
+}
+
+func (e LevelSkipped) EventName() string     { return "LevelSkipped" }
+func (e LevelSkipped) OccurredAt() time.Time { return e.At }
+
+// NewLevelSkippedEvent creates a LevelSkipped event for a level of the state that the player skipped.
+func NewLevelSkippedEvent(s *State, level int) LevelSkipped {
+	return LevelSkipped{
+		StateUUID:  s.uuid,
+		GameUUID:   s.gameUUID,
+		PlayerUUID: s.playerUUID,
+		Level:      level,
+		At:         now(),
+	}
+}
+
+// AttemptRecorded is emitted after a player's input has been recorded as an attempt at a level.
+type AttemptRecorded struct {
+	AttemptUUID string
+	StateUUID   string
+	GameUUID    string
+	PlayerUUID  string
+	Level       int
+	Input       string
+	Correct     bool
+	ClueShown   int
+	At          time.Time
+}
+
+func (e AttemptRecorded) EventName() string     { return "AttemptRecorded" }
+func (e AttemptRecorded) OccurredAt() time.Time { return e.At }
+
+// NewAttemptRecordedEvent creates an AttemptRecorded event for an attempt.
+func NewAttemptRecordedEvent(a *Attempt) AttemptRecorded {
+	return AttemptRecorded{
+		AttemptUUID: a.uuid,
+		StateUUID:   a.stateUUID,
+		GameUUID:    a.gameUUID,
+		PlayerUUID:  a.playerUUID,
+		Level:       a.level,
+		Input:       a.input,
+		Correct:     a.correct,
+		ClueShown:   a.clueShown,
+		At:          a.submittedAt,
+	}
+}
+
+// now returns the current time in a form that survives a round trip through the database.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// GameRated is emitted after a player has rated a game or changed their rating.
+type GameRated struct {
+	GameUUID   string
+	PlayerUUID string
+	PlayerName string
+	Stars      int
+	Review     string
+	// PreviousStars is the stars of the rating replaced or 0 if the player hadn't rated the game before.
+	PreviousStars int
+	// RatingCount, RatingTotal and RatingRevision are the game's RatingSummary once the rating was saved.
+	RatingCount    int
+	RatingTotal    int
+	RatingRevision int
+	At             time.Time
+}
+
+func (e GameRated) EventName() string     { return "GameRated" }
+func (e GameRated) OccurredAt() time.Time { return e.At }
+
+// NewGameRatedEvent creates a GameRated event for a rating that replaced previous, which is nil if there
+// was no previous rating, and the summary of the game's ratings once it was saved.
+func NewGameRatedEvent(r *Rating, previous *Rating, summary RatingSummary) GameRated {
+	e := GameRated{
+		GameUUID:       r.gameUUID,
+		PlayerUUID:     r.playerUUID,
+		PlayerName:     r.playerName,
+		Stars:          r.stars,
+		Review:         r.review,
+		RatingCount:    summary.count,
+		RatingTotal:    summary.total,
+		RatingRevision: summary.revision,
+		At:             r.ratedAt,
+	}
+
+	if previous != nil {
+		e.PreviousStars = previous.stars
+	}
+
+	return e
+}
+
+// GameReported is emitted after a game has been reported by a player or flagged by moderation.
+type GameReported struct {
+	ReportUUID string
+	GameUUID   string
+	// ReporterUUID is empty for automatic reports.
+	ReporterUUID string
+	Reason       ReportReason
+	Details      string
+	Flags        []ModerationFlag
+	At           time.Time
+}
+
+func (e GameReported) EventName() string     { return "GameReported" }
+func (e GameReported) OccurredAt() time.Time { return e.At }
+
+// NewGameReportedEvent creates a GameReported event for a report.
+func NewGameReportedEvent(r *Report) GameReported {
+	return GameReported{
+		ReportUUID:   r.uuid,
+		GameUUID:     r.gameUUID,
+		ReporterUUID: r.reporterUUID,
+		Reason:       r.reason,
+		Details:      r.details,
+		Flags:        r.flags,
+		At:           r.reportedAt,
+	}
+}
+
+// GameModerated is emitted after a moderator has published or unpublished a game.
+type GameModerated struct {
+	GameUUID string
+	Status   Status
+	// ResolvedReports is the number of reports closed by the decision.
+	ResolvedReports int
+	At              time.Time
+}
+
+func (e GameModerated) EventName() string     { return "GameModerated" }
+func (e GameModerated) OccurredAt() time.Time { return e.At }
+
+// NewGameModeratedEvent creates a GameModerated event for a game and the reports resolved with it.
+func NewGameModeratedEvent(g *Game, resolved []*Report) GameModerated {
+	return GameModerated{
+		GameUUID:        g.uuid,
+		Status:          g.status,
+		ResolvedReports: len(resolved),
+		At:              now(),
+	}
+}
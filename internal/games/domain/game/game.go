@@ -2,11 +2,11 @@
 package game
 
 import (
-	"errors"
 	"github.com/google/uuid"
+	"time"
 )
 
-// These are the limits imposed on games.
+// These are the limits imposed on games. Lengths are in characters as players count them, not bytes.
 const (
 	MaxTitleLength       = 64
 	MaxDescriptionLength = 200
@@ -15,10 +15,16 @@ const (
 	MaxEndingLength      = 200
 )
 
+var (
+	ErrorInvalidCreator   = newValidationError("game has no creator", "invalid-creator")
+	ErrorUnrecognizedKind = newValidationError("unrecognized kind", "invalid-kind")
+)
+
 // Game holds all information about a game.
 type Game struct {
 	uuid        string
 	creatorUUID string
+	creatorName string
 	title       string
 	description string
 	levels      []*Level
@@ -28,58 +34,85 @@ type Game struct {
 	state       string
 	country     string
 	value       int
+	createdAt   time.Time
+	location    *Location
+	status      Status
+	clues       ClueSettings
+	// locale is the locale of the game's own text. Its text in other locales is in translations.
+	locale       string
+	translations map[string]Translation
 }
 
-func (g *Game) UUID() string        { return g.uuid }
-func (g *Game) CreatorUUID() string { return g.creatorUUID }
-func (g *Game) Title() string       { return g.title }
-func (g *Game) Description() string { return g.description }
-func (g *Game) Levels() []*Level    { return g.levels }
-func (g *Game) Ending() string      { return g.ending }
-func (g *Game) Kind() string        { return g.kind }
-func (g *Game) City() string        { return g.city }
-func (g *Game) State() string       { return g.state }
-func (g *Game) Country() string     { return g.country }
-func (g *Game) Value() int          { return g.value }
+func (g *Game) UUID() string         { return g.uuid }
+func (g *Game) CreatorUUID() string  { return g.creatorUUID }
+func (g *Game) CreatorName() string  { return g.creatorName }
+func (g *Game) Title() string        { return g.title }
+func (g *Game) Description() string  { return g.description }
+func (g *Game) Levels() []*Level     { return g.levels }
+func (g *Game) Ending() string       { return g.ending }
+func (g *Game) Kind() string         { return g.kind }
+func (g *Game) City() string         { return g.city }
+func (g *Game) State() string        { return g.state }
+func (g *Game) Country() string      { return g.country }
+func (g *Game) Value() int           { return g.value }
+func (g *Game) CreatedAt() time.Time { return g.createdAt }
+
+// Status returns the moderation status of the game.
+func (g *Game) Status() Status { return g.status }
+
+// Published reports whether players can see and start the game.
+func (g *Game) Published() bool { return g.status == StatusPublished }
+
+// HoldForReview keeps the game from players until a moderator has reviewed it.
+func (g *Game) HoldForReview() {
+	g.status = StatusPendingReview
+}
 
-// newGame creates a new game for public constructors.
-func newGame(creator User, title, description, ending string, kind string, levelAdders ...LevelAdder) (*Game, error) {
-	if creator.UUID() == "" {
-		return nil, errors.New("invalid creator")
-	}
+// Publish makes the game available to players.
+func (g *Game) Publish() {
+	g.status = StatusPublished
+}
 
-	if title == "" {
-		return nil, errors.New("game has no title")
-	}
+// Unpublish takes the game away from players.
+func (g *Game) Unpublish() {
+	g.status = StatusUnpublished
+}
 
-	if len(title) > MaxTitleLength {
-		return nil, errors.New("game title greater than 64")
-	}
+// ClueSettings returns how players get the clues of the game's levels.
+func (g *Game) ClueSettings() ClueSettings { return g.clues }
 
-	if description == "" {
-		return nil, errors.New("game has no description")
-	}
+// SetClueSettings overrides the DefaultClueSettings.
+func (g *Game) SetClueSettings(c ClueSettings) {
+	g.clues = c
+}
 
-	if len(description) > MaxDescriptionLength {
-		return nil, errors.New("game description greater than 200")
-	}
+// Location returns the starting point of the game or nil if the game has no location.
+func (g *Game) Location() *Location { return g.location }
 
-	if ending == "" {
-		return nil, errors.New("game has no ending")
+// SetStartingLocation overrides the starting point derived from the first level of the game.
+func (g *Game) SetStartingLocation(l Location) {
+	g.location = &l
+}
+
+// newGame creates a new game for public constructors.
+func newGame(creator User, title, description, ending string, kind string, levelAdders ...LevelAdder) (*Game, error) {
+	if creator.UUID() == "" {
+		return nil, ErrorInvalidCreator
 	}
 
 	switch kind {
 	case "urban":
 	default:
-		return nil, errors.New("unrecognized kind")
+		return nil, ErrorUnrecognizedKind
 	}
 
-	if len(ending) > MaxEndingLength {
-		return nil, errors.New("ending length greater than 200")
-	}
+	var v validator
+	v.text("title", title, MaxTitleLength)
+	v.multilineText("description", description, MaxDescriptionLength)
+	v.multilineText("ending", ending, MaxEndingLength)
 
 	if len(levelAdders) == 0 {
-		return nil, errors.New("game has no levels")
+		v.add("levels", RuleRequired, 0)
 	}
 
 	id, err := uuid.NewRandom()
@@ -90,19 +123,36 @@ func newGame(creator User, title, description, ending string, kind string, level
 	g := &Game{
 		uuid:        id.String(),
 		creatorUUID: creator.UUID(),
+		creatorName: creator.DisplayName(),
 		title:       title,
 		description: description,
 		ending:      ending,
 		kind:        kind,
 		value:       42,
+		createdAt:   now(),
+		status:      StatusPublished,
+		clues:       DefaultClueSettings(),
+		locale:      DefaultLocale,
 	}
 
 	for _, addLevel := range levelAdders {
+		// The levels after one with errors would be given the wrong index so stop at the first.
 		if err := addLevel(g); err != nil {
-			return nil, err
+			if !v.merge(err) {
+				return nil, err
+			}
+
+			break
 		}
 	}
 
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	// Players start at the first level so use its location as the starting point if it has one.
+	g.location = g.levels[0].location
+
 	return g, nil
 }
 
@@ -111,6 +161,7 @@ func newGame(creator User, title, description, ending string, kind string, level
 func UnmarshalFromDataBase(
 	uuid,
 	creatorUUID,
+	creatorName,
 	title,
 	description string,
 	levels []*Level,
@@ -119,18 +170,49 @@ func UnmarshalFromDataBase(
 	city,
 	state,
 	country string,
-	value int) (*Game, error) {
+	value int,
+	createdAt time.Time,
+	location *Location,
+	status Status,
+	clues ClueSettings,
+	locale string,
+	translations []Translation) (*Game, error) {
+	// Games stored before moderation was added have no status and were all published.
+	if status == "" {
+		status = StatusPublished
+	}
+
+	// Games stored before translations were added are all in the default locale.
+	if locale == "" {
+		locale = DefaultLocale
+	}
+
+	var byLocale map[string]Translation
+	if len(translations) > 0 {
+		byLocale = make(map[string]Translation, len(translations))
+		for _, t := range translations {
+			byLocale[t.locale] = t
+		}
+	}
+
 	return &Game{
-		uuid:        uuid,
-		creatorUUID: creatorUUID,
-		title:       title,
-		description: description,
-		levels:      levels,
-		ending:      ending,
-		kind:        kind,
-		city:        city,
-		state:       state,
-		country:     country,
-		value:       value,
+		uuid:         uuid,
+		creatorUUID:  creatorUUID,
+		creatorName:  creatorName,
+		title:        title,
+		description:  description,
+		levels:       levels,
+		ending:       ending,
+		kind:         kind,
+		city:         city,
+		state:        state,
+		country:      country,
+		value:        value,
+		createdAt:    createdAt,
+		location:     location,
+		status:       status,
+		clues:        clues,
+		locale:       locale,
+		translations: byLocale,
 	}, nil
 }
@@ -6,6 +6,7 @@ type Level struct {
 	description string
 	clues       []string
 	answers     []string
+	location    *Location
 }
 
 func (l *Level) Title() string       { return l.title }
@@ -13,6 +14,9 @@ func (l *Level) Description() string { return l.description }
 func (l *Level) Clues() []string     { return l.clues }
 func (l *Level) Answers() []string   { return l.answers }
 
+// Location returns where the level takes place or nil if the level has no location.
+func (l *Level) Location() *Location { return l.location }
+
 func (l *Level) isAnswer(input string) bool {
 	for _, ans := range l.answers {
 		if ans == input {
@@ -25,11 +29,12 @@ func (l *Level) isAnswer(input string) bool {
 
 // UnmarshalLevelFromDatabase should only be used in repo implementations to unmarshal data from a database
 // into a domain game level.
-func UnmarshalLevelFromDatabase(title, description string, clues, answers []string) *Level {
+func UnmarshalLevelFromDatabase(title, description string, clues, answers []string, location *Location) *Level {
 	return &Level{
 		title:       title,
 		description: description,
 		clues:       clues,
 		answers:     answers,
+		location:    location,
 	}
 }
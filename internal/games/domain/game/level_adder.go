@@ -1,64 +1,68 @@
 package game
 
-import "errors"
+import "fmt"
 
-// LevelAdder checks a level for errors and if it is error free adds it to a game.
+// LevelAdder checks a level for errors and if it is error free adds it to a game. The errors are returned as
+// a ValidationError with fields such as levels[0].title.
 type LevelAdder func(g *Game) error
 
 // NewLevelAdder creates a new LevelAdder.
 func NewLevelAdder(title, description string, clues, answers []string) LevelAdder {
+	return newLevelAdder(title, description, clues, answers, nil)
+}
+
+// NewLocatedLevelAdder creates a new LevelAdder for a level that takes place at a location.
+func NewLocatedLevelAdder(title, description string, clues, answers []string, location Location) LevelAdder {
+	return newLevelAdder(title, description, clues, answers, &location)
+}
+
+func newLevelAdder(title, description string, clues, answers []string, location *Location) LevelAdder {
 	return func(g *Game) error {
-		if title == "" {
-			return errors.New("level has not title")
-		}
+		field := fmt.Sprintf("levels[%d]", len(g.levels))
 
-		if len(title) > MaxTitleLength {
-			return errors.New("level title length greater than 64")
-		}
+		var v validator
+		validateLevelText(&v, field, title, description, clues, answers)
 
-		if description == "" {
-			return errors.New("level has no description")
+		if len(answers) == 0 {
+			v.add(field+".answers", RuleRequired, 0)
 		}
 
-		if len(description) > MaxDescriptionLength {
-			return errors.New("level description length greater than 200")
+		if err := v.err(); err != nil {
+			return err
 		}
 
 		l := Level{
 			title:       title,
 			description: description,
+			location:    location,
 		}
 
-		for _, clue := range clues {
-			if clue == "" {
-				return errors.New("clue is empty")
-			}
+		l.clues = append(l.clues, clues...)
+		l.answers = append(l.answers, answers...)
 
-			if len(clue) > MaxClueLength {
-				return errors.New("clue length greater than 64")
-			}
+		g.levels = append(g.levels, &l)
 
-			l.clues = append(l.clues, clue)
-		}
+		return nil
+	}
+}
 
-		if len(answers) == 0 {
-			return errors.New("level has no answers")
-		}
+// validateLevelText checks the text of the level at field, which is the same for a level and its
+// translations. field is empty for a level on its own.
+func validateLevelText(v *validator, field, title, description string, clues, answers []string) {
+	v.text(fieldPath(field, "title"), title, MaxTitleLength)
+	v.multilineText(fieldPath(field, "description"), description, MaxDescriptionLength)
 
-		for _, answer := range answers {
-			if answer == "" {
-				return errors.New("answer is empty")
-			}
+	for i, clue := range clues {
+		v.text(fieldPath(field, fmt.Sprintf("clues[%d]", i)), clue, MaxClueLength)
+	}
 
-			if len(answer) > MaxAnswerLength {
-				return errors.New("answer length greater than 64")
-			}
+	for i, answer := range answers {
+		answerField := fieldPath(field, fmt.Sprintf("answers[%d]", i))
+		v.text(answerField, answer, MaxAnswerLength)
 
-			l.answers = append(l.answers, answer)
+		// Messaging keywords and text commands take precedence over answers so the answer could never be given.
+		if reservedWord(answer) {
+			v.add(answerField, RuleReservedKeyword, 0)
 		}
-
-		g.levels = append(g.levels, &l)
-
-		return nil
 	}
 }
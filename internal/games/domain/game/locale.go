@@ -0,0 +1,52 @@
+package game
+
+import (
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is the locale of games that don't choose one.
+const DefaultLocale = "en"
+
+var (
+	ErrorInvalidLocale = newValidationError("invalid locale", "invalid-locale")
+)
+
+// NormalizeLocale returns locale as a canonical BCP 47 tag, e.g. es-MX for ES_mx. It returns
+// ErrorInvalidLocale if locale isn't a valid tag.
+func NormalizeLocale(locale string) (string, error) {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", ErrorInvalidLocale
+	}
+
+	return tag.String(), nil
+}
+
+// MatchLocale returns the available locale that best matches the first preference that matches any. A
+// preference may be a locale or an Accept-Language header. The first available locale is returned if none
+// of the preferences match.
+func MatchLocale(available []string, preferences ...string) string {
+	tags := make([]language.Tag, len(available))
+	for i, locale := range available {
+		tags[i] = language.Make(locale)
+	}
+
+	matcher := language.NewMatcher(tags)
+
+	for _, preference := range preferences {
+		if preference == "" {
+			continue
+		}
+
+		preferred, _, err := language.ParseAcceptLanguage(preference)
+		if err != nil || len(preferred) == 0 {
+			continue
+		}
+
+		if _, i, confidence := matcher.Match(preferred...); confidence != language.No {
+			return available[i]
+		}
+	}
+
+	return available[0]
+}
@@ -0,0 +1,53 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	locale, err := NormalizeLocale("ES_mx")
+	require.NoError(t, err)
+	assert.Equal(t, "es-MX", locale)
+
+	_, err = NormalizeLocale("not a locale")
+	assert.Equal(t, ErrorInvalidLocale, err)
+}
+
+func TestMatchLocale(t *testing.T) {
+	available := []string{"en", "es", "fr"}
+
+	testCases := []struct {
+		name        string
+		preferences []string
+		locale      string
+	}{
+		{"no preferences", nil, "en"},
+		{"locale", []string{"fr"}, "fr"},
+		{"region", []string{"es-MX"}, "es"},
+		{"accept language", []string{"", "de, es;q=0.8, en;q=0.5"}, "es"},
+		{"first match", []string{"es", "fr"}, "es"},
+		{"unavailable preference", []string{"de", "fr"}, "fr"},
+		{"invalid preference", []string{"not a locale;;", "fr"}, "fr"},
+		{"no match", []string{"de"}, "en"},
+	}
+
+	for _, c := range testCases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.locale, MatchLocale(available, c.preferences...))
+		})
+	}
+}
+
+func TestMessages(t *testing.T) {
+	assert.Equal(t, HelpMessage, NewKeywordResponse(KeywordHelp, "").Message)
+	assert.Equal(t, OptOutMessage, NewKeywordResponse(KeywordStop, "de").Message)
+	assert.Contains(t, NewKeywordResponse(KeywordStop, "es-MX").Message, "Te has dado de baja")
+
+	assert.Equal(t, "Gopher Cache: There are no games to play yet.", NewGamesResponse(nil, "en").Message)
+	assert.Equal(t, "Gopher Cache games: A, B.", NewGamesResponse([]string{"A", "B"}, "en").Message)
+	assert.Equal(t, "Juegos de Gopher Cache: A.", NewGamesResponse([]string{"A"}, "es").Message)
+
+	assert.Equal(t, "Votre code de vérification Gopher Cache est 123456", VerificationCodeMessage("123456", "fr"))
+}
@@ -0,0 +1,40 @@
+package game
+
+import (
+	"gopher-cache/internal/common/geo"
+)
+
+// Location is a point on the earth.
+type Location struct {
+	latitude  float64
+	longitude float64
+}
+
+func (l Location) Latitude() float64  { return l.latitude }
+func (l Location) Longitude() float64 { return l.longitude }
+
+// NewLocation creates a new location from a latitude and longitude in degrees.
+func NewLocation(latitude, longitude float64) (Location, error) {
+	if !geo.ValidCoordinates(latitude, longitude) {
+		return Location{}, newValidationError("invalid coordinates", "invalid-coordinates")
+	}
+
+	return Location{
+		latitude:  latitude,
+		longitude: longitude,
+	}, nil
+}
+
+// DistanceKm returns the distance in kilometers to another location.
+func (l Location) DistanceKm(to Location) float64 {
+	return geo.DistanceKm(l.latitude, l.longitude, to.latitude, to.longitude)
+}
+
+// UnmarshalLocationFromDatabase should only be used in repo implementations to unmarshal data from a database
+// into a domain location.
+func UnmarshalLocationFromDatabase(latitude, longitude float64) *Location {
+	return &Location{
+		latitude:  latitude,
+		longitude: longitude,
+	}
+}
@@ -0,0 +1,60 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewLocation(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		l, err := NewLocation(30.2672, -97.7431)
+		require.NoError(t, err)
+
+		assert.Equal(t, 30.2672, l.Latitude())
+		assert.Equal(t, -97.7431, l.Longitude())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewLocation(91, 0)
+		assert.NotNil(t, err)
+
+		_, err = NewLocation(0, 181)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGame_Location(t *testing.T) {
+	creator := newTestUser()
+	austin, err := NewLocation(30.2672, -97.7431)
+	require.NoError(t, err)
+	dallas, err := NewLocation(32.7767, -96.7970)
+	require.NoError(t, err)
+
+	t.Run("derived from first level", func(t *testing.T) {
+		g, err := NewUrbanGame(creator, "title", "description", "ending", "austin", "texas", "usa",
+			NewLocatedLevelAdder("level one", "level one description", nil, []string{"answer"}, austin),
+			NewLocatedLevelAdder("level two", "level two description", nil, []string{"answer"}, dallas),
+		)
+		require.NoError(t, err)
+
+		require.NotNil(t, g.Location())
+		assert.Equal(t, austin, *g.Location())
+		assert.Equal(t, austin, *g.Levels()[0].Location())
+	})
+
+	t.Run("set by creator", func(t *testing.T) {
+		g, err := NewUrbanGame(creator, "title", "description", "ending", "austin", "texas", "usa",
+			NewLocatedLevelAdder("level one", "level one description", nil, []string{"answer"}, austin),
+		)
+		require.NoError(t, err)
+
+		g.SetStartingLocation(dallas)
+		assert.Equal(t, dallas, *g.Location())
+	})
+
+	t.Run("no location", func(t *testing.T) {
+		g := newValidTestUrbanGame()
+		assert.Nil(t, g.Location())
+	})
+}
@@ -0,0 +1,101 @@
+package game
+
+import (
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+// MessageLocales are the locales system messages are translated into. The first is used for players whose
+// locale isn't one of them.
+var MessageLocales = []string{DefaultLocale, "es", "fr"}
+
+// These are the system messages sent to players. They are the English text of each message and the key of
+// its translations.
+const (
+	OptOutMessage    = "Gopher Cache: You have been unsubscribed and will receive no more messages. Reply START to resubscribe."
+	OptInMessage     = "Gopher Cache: You have been resubscribed. Reply HELP for help or STOP to unsubscribe."
+	HelpMessage      = "Gopher Cache: Text your answer to play the current level or HINT, REPEAT, STATUS, SKIP, LEAVE or GAMES. Reply STOP to unsubscribe or START to resubscribe. Msg & data rates may apply."
+	IncorrectMessage = "That isn't the answer. Text HINT for a clue."
+
+	noCluesMessage          = "this level has no clues"
+	statusMessage           = "Gopher Cache: You are on level %d of %d of %s with %d of %d clues revealed."
+	finishedMessage         = "Gopher Cache: You have finished %s."
+	quitMessage             = "Gopher Cache: You have quit %s. Text GAMES to find another game."
+	gamesMessage            = "Gopher Cache games: %[2]s."
+	verificationCodeMessage = "Your Gopher Cache verification code is %s"
+)
+
+var messages = newMessageCatalog()
+
+func newMessageCatalog() *catalog.Builder {
+	en := language.English
+	es := language.Spanish
+	fr := language.French
+
+	b := catalog.NewBuilder(catalog.Fallback(en))
+
+	set := func(tag language.Tag, key string, msg ...catalog.Message) {
+		if err := b.Set(tag, key, msg...); err != nil {
+			panic(err)
+		}
+	}
+
+	for _, key := range []string{
+		OptOutMessage, OptInMessage, HelpMessage, IncorrectMessage, noCluesMessage, finishedMessage, quitMessage,
+		verificationCodeMessage,
+	} {
+		set(en, key, catalog.String(key))
+	}
+
+	set(en, statusMessage, plural.Selectf(5, "%d",
+		plural.One, "Gopher Cache: You are on level %[1]d of %[2]d of %[3]s with %[4]d of %[5]d clue revealed.",
+		plural.Other, "Gopher Cache: You are on level %[1]d of %[2]d of %[3]s with %[4]d of %[5]d clues revealed."))
+	set(en, gamesMessage, plural.Selectf(1, "%d",
+		"=0", "Gopher Cache: There are no games to play yet.",
+		plural.Other, "Gopher Cache games: %[2]s."))
+
+	set(es, OptOutMessage, catalog.String("Gopher Cache: Te has dado de baja y no recibirás más mensajes. Responde START para volver a suscribirte."))
+	set(es, OptInMessage, catalog.String("Gopher Cache: Te has vuelto a suscribir. Responde HELP para obtener ayuda o STOP para darte de baja."))
+	set(es, HelpMessage, catalog.String("Gopher Cache: Envía tu respuesta para jugar el nivel actual o PISTA, REPETIR, ESTADO, SALTAR, SALIR o JUEGOS. Responde STOP para darte de baja o START para volver a suscribirte. Pueden aplicarse tarifas de mensajes y datos."))
+	set(es, IncorrectMessage, catalog.String("Esa no es la respuesta. Envía PISTA para obtener una pista."))
+	set(es, noCluesMessage, catalog.String("este nivel no tiene pistas"))
+	set(es, statusMessage, plural.Selectf(5, "%d",
+		plural.One, "Gopher Cache: Estás en el nivel %[1]d de %[2]d de %[3]s con %[4]d de %[5]d pista revelada.",
+		plural.Other, "Gopher Cache: Estás en el nivel %[1]d de %[2]d de %[3]s con %[4]d de %[5]d pistas reveladas."))
+	set(es, finishedMessage, catalog.String("Gopher Cache: Has terminado %s."))
+	set(es, quitMessage, catalog.String("Gopher Cache: Has abandonado %s. Envía JUEGOS para encontrar otro juego."))
+	set(es, gamesMessage, plural.Selectf(1, "%d",
+		"=0", "Gopher Cache: Todavía no hay juegos.",
+		plural.Other, "Juegos de Gopher Cache: %[2]s."))
+	set(es, verificationCodeMessage, catalog.String("Tu código de verificación de Gopher Cache es %s"))
+
+	set(fr, OptOutMessage, catalog.String("Gopher Cache : Vous êtes désabonné et ne recevrez plus de messages. Répondez START pour vous réabonner."))
+	set(fr, OptInMessage, catalog.String("Gopher Cache : Vous êtes réabonné. Répondez HELP pour obtenir de l'aide ou STOP pour vous désabonner."))
+	set(fr, HelpMessage, catalog.String("Gopher Cache : Envoyez votre réponse pour jouer au niveau actuel ou INDICE, REPETER, STATUT, PASSER, QUITTER ou JEUX. Répondez STOP pour vous désabonner ou START pour vous réabonner. Des frais de messages et de données peuvent s'appliquer."))
+	set(fr, IncorrectMessage, catalog.String("Ce n'est pas la réponse. Envoyez INDICE pour obtenir un indice."))
+	set(fr, noCluesMessage, catalog.String("ce niveau n'a pas d'indices"))
+	set(fr, statusMessage, plural.Selectf(5, "%d",
+		plural.One, "Gopher Cache : Vous êtes au niveau %[1]d sur %[2]d de %[3]s avec %[4]d indice sur %[5]d révélé.",
+		plural.Other, "Gopher Cache : Vous êtes au niveau %[1]d sur %[2]d de %[3]s avec %[4]d indices sur %[5]d révélés."))
+	set(fr, finishedMessage, catalog.String("Gopher Cache : Vous avez terminé %s."))
+	set(fr, quitMessage, catalog.String("Gopher Cache : Vous avez abandonné %s. Envoyez JEUX pour trouver un autre jeu."))
+	set(fr, gamesMessage, plural.Selectf(1, "%d",
+		"=0", "Gopher Cache : Il n'y a pas encore de jeux.",
+		plural.Other, "Jeux Gopher Cache : %[2]s."))
+	set(fr, verificationCodeMessage, catalog.String("Votre code de vérification Gopher Cache est %s"))
+
+	return b
+}
+
+// printer returns the printer of system messages in the message locale that best matches locale.
+func printer(locale string) *message.Printer {
+	tag := language.MustParse(MatchLocale(MessageLocales, locale))
+	return message.NewPrinter(tag, message.Catalog(messages))
+}
+
+// VerificationCodeMessage returns the message that sends a player their verification code.
+func VerificationCodeMessage(code, locale string) string {
+	return printer(locale).Sprintf(verificationCodeMessage, code)
+}
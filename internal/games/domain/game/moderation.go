@@ -0,0 +1,215 @@
+package game
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+// MaxReportDetailsLength is the limit imposed on the details of a report.
+const MaxReportDetailsLength = 500
+
+var (
+	ErrorGameNotPublished   = newNotFoundError("game is not published", "game-not-published")
+	ErrorInvalidReporter    = newValidationError("report has no reporter", "invalid-reporter")
+	ErrorReportWithoutFlags = newValidationError("report has no flags", "invalid-report-flags")
+	ErrorReportWithoutGame  = newValidationError("report has no game", "invalid-report-game")
+)
+
+// Status is the moderation status of a game.
+type Status string
+
+const (
+	// StatusPublished games can be seen and played by everyone.
+	StatusPublished Status = "published"
+	// StatusPendingReview games were flagged when they were created and wait for a moderator.
+	StatusPendingReview Status = "pending-review"
+	// StatusUnpublished games were taken down by a moderator.
+	StatusUnpublished Status = "unpublished"
+)
+
+// Content is a piece of free text written by the creator of a game.
+type Content struct {
+	// Field is where the text is found in the game, e.g. levels[0].clues[1].
+	Field string
+	Text  string
+}
+
+// Content returns all the free text of the game so it can be moderated.
+func (g *Game) Content() []Content {
+	content := []Content{
+		{Field: "title", Text: g.title},
+		{Field: "description", Text: g.description},
+		{Field: "ending", Text: g.ending},
+	}
+
+	for i, l := range g.levels {
+		content = append(content,
+			Content{Field: fmt.Sprintf("levels[%d].title", i), Text: l.title},
+			Content{Field: fmt.Sprintf("levels[%d].description", i), Text: l.description},
+		)
+
+		for j, clue := range l.clues {
+			content = append(content, Content{Field: fmt.Sprintf("levels[%d].clues[%d]", i, j), Text: clue})
+		}
+
+		for j, answer := range l.answers {
+			content = append(content, Content{Field: fmt.Sprintf("levels[%d].answers[%d]", i, j), Text: answer})
+		}
+	}
+
+	for _, t := range g.Translations() {
+		field := fmt.Sprintf("translations[%s]", t.locale)
+
+		content = append(content,
+			Content{Field: field + ".title", Text: t.title},
+			Content{Field: field + ".description", Text: t.description},
+			Content{Field: field + ".ending", Text: t.ending},
+		)
+
+		for i, l := range t.levels {
+			content = append(content,
+				Content{Field: fmt.Sprintf("%s.levels[%d].title", field, i), Text: l.title},
+				Content{Field: fmt.Sprintf("%s.levels[%d].description", field, i), Text: l.description},
+			)
+
+			for j, clue := range l.clues {
+				content = append(content, Content{Field: fmt.Sprintf("%s.levels[%d].clues[%d]", field, i, j), Text: clue})
+			}
+
+			for j, answer := range l.answers {
+				content = append(content, Content{Field: fmt.Sprintf("%s.levels[%d].answers[%d]", field, i, j), Text: answer})
+			}
+		}
+	}
+
+	return content
+}
+
+// ModerationFlag is a problem found in the content of a game.
+type ModerationFlag struct {
+	Field string
+	// Rule is the name of the rule that was broken, e.g. profanity or url.
+	Rule string
+	// Match is the text that broke the rule.
+	Match string
+}
+
+// ReportReason is why a game was reported.
+type ReportReason string
+
+const (
+	ReportReasonSpam                ReportReason = "spam"
+	ReportReasonOffensive           ReportReason = "offensive"
+	ReportReasonPersonalInformation ReportReason = "personal-information"
+	ReportReasonOther               ReportReason = "other"
+	// ReportReasonAutomatic is used for reports made by moderation when a game is created.
+	ReportReasonAutomatic ReportReason = "automatic"
+)
+
+// Report is a complaint about the content of a game waiting to be reviewed by a moderator.
+type Report struct {
+	uuid     string
+	gameUUID string
+	// reporterUUID is empty for automatic reports.
+	reporterUUID string
+	reason       ReportReason
+	details      string
+	flags        []ModerationFlag
+	reportedAt   time.Time
+	// resolvedAt is zero until a moderator has reviewed the game.
+	resolvedAt time.Time
+}
+
+func (r *Report) UUID() string            { return r.uuid }
+func (r *Report) GameUUID() string        { return r.gameUUID }
+func (r *Report) ReporterUUID() string    { return r.reporterUUID }
+func (r *Report) Reason() ReportReason    { return r.reason }
+func (r *Report) Details() string         { return r.details }
+func (r *Report) Flags() []ModerationFlag { return r.flags }
+func (r *Report) ReportedAt() time.Time   { return r.reportedAt }
+func (r *Report) ResolvedAt() time.Time   { return r.resolvedAt }
+func (r *Report) Resolved() bool          { return !r.resolvedAt.IsZero() }
+
+// NewReport creates a report by a player about a game.
+func NewReport(reporter User, gameUUID string, reason ReportReason, details string) (*Report, error) {
+	if reporter.UUID() == "" {
+		return nil, ErrorInvalidReporter
+	}
+
+	switch reason {
+	case ReportReasonSpam, ReportReasonOffensive, ReportReasonPersonalInformation, ReportReasonOther:
+	default:
+		return nil, newValidationError("unrecognized report reason", "invalid-report-reason")
+	}
+
+	if reason == ReportReasonOther && details == "" {
+		return nil, newValidationError("report has no details", "invalid-report-details")
+	}
+
+	if len(details) > MaxReportDetailsLength {
+		return nil, newValidationError("report details length greater than 500", "invalid-report-details")
+	}
+
+	return newReport(gameUUID, reporter.UUID(), reason, details, nil)
+}
+
+// NewAutomaticReport creates a report for the problems moderation found in a game.
+func NewAutomaticReport(gameUUID string, flags []ModerationFlag) (*Report, error) {
+	if len(flags) == 0 {
+		return nil, ErrorReportWithoutFlags
+	}
+
+	return newReport(gameUUID, "", ReportReasonAutomatic, "", flags)
+}
+
+func newReport(gameUUID, reporterUUID string, reason ReportReason, details string, flags []ModerationFlag) (*Report, error) {
+	if gameUUID == "" {
+		return nil, ErrorReportWithoutGame
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Report{
+		uuid:         id.String(),
+		gameUUID:     gameUUID,
+		reporterUUID: reporterUUID,
+		reason:       reason,
+		details:      details,
+		flags:        flags,
+		reportedAt:   now(),
+	}, nil
+}
+
+// Resolve closes the report after a moderator has reviewed the game.
+func (r *Report) Resolve() {
+	if r.resolvedAt.IsZero() {
+		r.resolvedAt = now()
+	}
+}
+
+// UnmarshalReportFromDatabase should only be used in repo implementations to unmarshal data from a database
+// into a domain report.
+func UnmarshalReportFromDatabase(
+	uuid,
+	gameUUID,
+	reporterUUID string,
+	reason ReportReason,
+	details string,
+	flags []ModerationFlag,
+	reportedAt,
+	resolvedAt time.Time) *Report {
+	return &Report{
+		uuid:         uuid,
+		gameUUID:     gameUUID,
+		reporterUUID: reporterUUID,
+		reason:       reason,
+		details:      details,
+		flags:        flags,
+		reportedAt:   reportedAt,
+		resolvedAt:   resolvedAt,
+	}
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestGame_Content(t *testing.T) {
+	g := newValidTestUrbanGame()
+
+	content := g.Content()
+	assert.Equal(t, Content{Field: "title", Text: g.title}, content[0])
+	assert.Contains(t, content, Content{Field: "levels[0].clues[1]", Text: g.levels[0].clues[1]})
+	assert.Contains(t, content, Content{Field: "levels[1].answers[0]", Text: g.levels[1].answers[0]})
+}
+
+func TestGame_Publishing(t *testing.T) {
+	g := newValidTestUrbanGame()
+	assert.Equal(t, StatusPublished, g.Status())
+
+	g.HoldForReview()
+	assert.False(t, g.Published())
+
+	_, _, err := Start(g, newValidTestPlayer())
+	assert.Equal(t, ErrorGameNotPublished, err)
+
+	g.Publish()
+	assert.True(t, g.Published())
+
+	_, _, err = Start(g, newValidTestPlayer())
+	assert.NoError(t, err)
+
+	g.Unpublish()
+	assert.Equal(t, StatusUnpublished, g.Status())
+}
+
+func TestNewReport(t *testing.T) {
+	reporter := newTestUser()
+
+	r, err := NewReport(reporter, "game", ReportReasonSpam, "")
+	require.NoError(t, err)
+	assert.Equal(t, "game", r.GameUUID())
+	assert.Equal(t, reporter.UUID(), r.ReporterUUID())
+	assert.False(t, r.Resolved())
+
+	r.Resolve()
+	assert.True(t, r.Resolved())
+
+	_, err = NewReport(User{}, "game", ReportReasonSpam, "")
+	assert.Error(t, err)
+
+	_, err = NewReport(reporter, "", ReportReasonSpam, "")
+	assert.Error(t, err)
+
+	_, err = NewReport(reporter, "game", ReportReasonAutomatic, "")
+	assert.Error(t, err)
+
+	_, err = NewReport(reporter, "game", ReportReasonOther, "")
+	assert.Error(t, err)
+
+	_, err = NewReport(reporter, "game", ReportReasonOther, strings.Repeat("a", MaxReportDetailsLength+1))
+	assert.Error(t, err)
+
+	_, err = NewAutomaticReport("game", nil)
+	assert.Error(t, err)
+
+	r, err = NewAutomaticReport("game", []ModerationFlag{{Field: "title", Rule: "url", Match: "example.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, ReportReasonAutomatic, r.Reason())
+	assert.Empty(t, r.ReporterUUID())
+}
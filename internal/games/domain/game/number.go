@@ -0,0 +1,27 @@
+package game
+
+import (
+	"fmt"
+	"github.com/ttacon/libphonenumber"
+)
+
+// defaultRegion is the region numbers without a country code are assumed to be from.
+const defaultRegion = "US"
+
+var ErrorInvalidNumber = newValidationError("invalid phone number", "invalid-number")
+
+// NormalizeNumber returns the number in E.164 format, e.g. "+1 (573) 449-7033" and "15734497033" are both
+// "+15734497033". Numbers without a country code are assumed to be from the US. ErrorInvalidNumber is
+// returned if the number isn't a valid phone number.
+func NormalizeNumber(number string) (string, error) {
+	n, err := libphonenumber.Parse(number, defaultRegion)
+	if err != nil {
+		return "", fmt.Errorf("%w %q: %v", ErrorInvalidNumber, number, err)
+	}
+
+	if !libphonenumber.IsValidNumber(n) {
+		return "", fmt.Errorf("%w %q", ErrorInvalidNumber, number)
+	}
+
+	return libphonenumber.Format(n, libphonenumber.E164), nil
+}
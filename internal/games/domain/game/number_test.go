@@ -0,0 +1,58 @@
+package game
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNormalizeNumber(t *testing.T) {
+	tests := []struct {
+		number string
+		want   string
+	}{
+		{"15734497033", "+15734497033"},
+		{"+15734497033", "+15734497033"},
+		{"+1 (573) 449-7033", "+15734497033"},
+		{"573-449-7033", "+15734497033"},
+		{"+44 20 7946 0958", "+442079460958"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			got, err := NormalizeNumber(tt.number)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, number := range []string{"1", "not a number", "+1 (000) 000-0000"} {
+		t.Run(number, func(t *testing.T) {
+			_, err := NormalizeNumber(number)
+			assert.True(t, errors.Is(err, ErrorInvalidNumber))
+		})
+	}
+}
+
+func TestNewUser_Number(t *testing.T) {
+	u, err := NewUser("uuid", "1 (573) 449-7033")
+	assert.NoError(t, err)
+	assert.Equal(t, "+15734497033", u.Number())
+
+	_, err = NewUser("uuid", "12")
+	assert.True(t, errors.Is(err, ErrorInvalidNumber))
+}
+
+func TestPlayer_NormalizeNumber(t *testing.T) {
+	p := UnmarshalPlayerFromDatabase("uuid", "573-449-7033", 0, 0, 0, "", time.Time{}, Verification{}, time.Time{}, time.Time{}, "")
+
+	changed, err := p.NormalizeNumber()
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "+15734497033", p.Number())
+
+	changed, err = p.NormalizeNumber()
+	assert.NoError(t, err)
+	assert.False(t, changed)
+}
@@ -1,7 +1,7 @@
 package game
 
 import (
-	"errors"
+	"time"
 )
 
 // Player holds all information about a player.
@@ -12,6 +12,16 @@ type Player struct {
 	gamesFinished        int
 	totalPoints          int
 	currentGameStateUUID string
+	// numberVerifiedAt is the zero time until the player proves they can receive messages at the number.
+	numberVerifiedAt time.Time
+	verification     Verification
+	// consentedAt is the zero time until the player agrees to receive messages.
+	consentedAt time.Time
+	// optedOutAt is the zero time unless the player has asked not to receive any more messages.
+	optedOutAt time.Time
+	// locale is the locale the player prefers games and messages in. It is empty if the player hasn't chosen
+	// one.
+	locale string
 }
 
 func (p *Player) UUID() string                 { return p.uuid }
@@ -20,11 +30,55 @@ func (p *Player) GamesStarted() int            { return p.gamesStarted }
 func (p *Player) GamesFinished() int           { return p.gamesFinished }
 func (p *Player) TotalPoints() int             { return p.totalPoints }
 func (p *Player) CurrentGameStateUUID() string { return p.currentGameStateUUID }
+func (p *Player) NumberVerifiedAt() time.Time  { return p.numberVerifiedAt }
+func (p *Player) Verification() Verification   { return p.verification }
+func (p *Player) ConsentedAt() time.Time       { return p.consentedAt }
+func (p *Player) OptedOutAt() time.Time        { return p.optedOutAt }
+func (p *Player) Locale() string               { return p.locale }
+
+// SetLocale sets the locale the player prefers. An empty locale removes the player's preference.
+func (p *Player) SetLocale(locale string) error {
+	if locale == "" {
+		p.locale = ""
+		return nil
+	}
+
+	locale, err := NormalizeLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	p.locale = locale
+
+	return nil
+}
+
+// NumberVerified reports whether the player has verified their number. Game messages are only sent to
+// verified numbers.
+func (p *Player) NumberVerified() bool { return !p.numberVerifiedAt.IsZero() }
+
+// NormalizeNumber changes the player's number to E.164 format. It reports whether the number changed and
+// returns ErrorInvalidNumber if the number isn't a valid phone number. It is only needed for players that
+// were created before numbers were normalized.
+func (p *Player) NormalizeNumber() (bool, error) {
+	number, err := NormalizeNumber(p.number)
+	if err != nil {
+		return false, err
+	}
+
+	if number == p.number {
+		return false, nil
+	}
+
+	p.number = number
+
+	return true, nil
+}
 
 // NewPlayer creates a new Player from a User.
 func NewPlayerFromUser(u User) (*Player, error) {
 	if u.UUID() == "" {
-		return &Player{}, errors.New("invalid user")
+		return &Player{}, ErrorUserWithoutUUID
 	}
 
 	return &Player{
@@ -35,19 +89,19 @@ func NewPlayerFromUser(u User) (*Player, error) {
 
 func (p *Player) finishGame(g *Game, s *State) error {
 	if s.completed == false {
-		return errors.New("game is not completed")
+		return ErrorGameNotCompleted
 	}
 
 	if s.gameUUID != g.uuid {
-		return errors.New("invalid game")
+		return ErrorStateOfAnotherGame
 	}
 
 	if p.uuid != s.playerUUID {
-		return errors.New("invalid state")
+		return ErrorStateOfAnotherPlayer
 	}
 
 	p.gamesFinished++
-	p.totalPoints += g.value
+	p.totalPoints += s.points(g)
 
 	return nil
 }
@@ -60,7 +114,12 @@ func UnmarshalPlayerFromDatabase(
 	gamesStarted,
 	gamesFinished,
 	totalPoints int,
-	currentGameStateUUID string) *Player {
+	currentGameStateUUID string,
+	numberVerifiedAt time.Time,
+	verification Verification,
+	consentedAt,
+	optedOutAt time.Time,
+	locale string) *Player {
 	return &Player{
 		uuid:                 uuid,
 		number:               number,
@@ -68,5 +127,10 @@ func UnmarshalPlayerFromDatabase(
 		gamesFinished:        gamesFinished,
 		totalPoints:          totalPoints,
 		currentGameStateUUID: currentGameStateUUID,
+		numberVerifiedAt:     numberVerifiedAt,
+		verification:         verification,
+		consentedAt:          consentedAt,
+		optedOutAt:           optedOutAt,
+		locale:               locale,
 	}
 }
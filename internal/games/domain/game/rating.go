@@ -0,0 +1,113 @@
+package game
+
+import (
+	"time"
+)
+
+// These are the limits imposed on ratings.
+const (
+	MinStars        = 1
+	MaxStars        = 5
+	MaxReviewLength = 500
+)
+
+var (
+	ErrorRatingNotFound   = newNotFoundError("rating not found", "rating-not-found")
+	ErrorGameNotCompleted = newForbiddenError("game has not been completed", "game-not-completed")
+)
+
+// Rating is a player's rating of a game they have finished. A player has at most one rating per game.
+type Rating struct {
+	gameUUID   string
+	playerUUID string
+	playerName string
+	stars      int
+	// review is optional.
+	review  string
+	ratedAt time.Time
+}
+
+func (r Rating) GameUUID() string   { return r.gameUUID }
+func (r Rating) PlayerUUID() string { return r.playerUUID }
+func (r Rating) PlayerName() string { return r.playerName }
+func (r Rating) Stars() int         { return r.stars }
+func (r Rating) Review() string     { return r.review }
+func (r Rating) RatedAt() time.Time { return r.ratedAt }
+
+// NewRating creates a rating by rater of the game played in s. The game must have been completed in s.
+func NewRating(rater User, s *State, stars int, review string) (*Rating, error) {
+	if rater.UUID() == "" || s.playerUUID != rater.UUID() {
+		return nil, newForbiddenError("state does not belong to rater", "not-player")
+	}
+
+	if !s.completed {
+		return nil, ErrorGameNotCompleted
+	}
+
+	if stars < MinStars || stars > MaxStars {
+		return nil, newValidationError("stars must be between 1 and 5", "invalid-stars")
+	}
+
+	if length(review) > MaxReviewLength {
+		return nil, newValidationError("review length greater than 500", "invalid-review")
+	}
+
+	return &Rating{
+		gameUUID:   s.gameUUID,
+		playerUUID: rater.UUID(),
+		playerName: rater.DisplayName(),
+		stars:      stars,
+		review:     review,
+		ratedAt:    now(),
+	}, nil
+}
+
+// RatingSummary adds up the ratings of a game. It is saved with every rating so the two always agree.
+type RatingSummary struct {
+	count int
+	total int
+	// revision goes up every time a rating is saved so a later summary can be told from an earlier one.
+	revision int
+}
+
+func (s RatingSummary) Count() int    { return s.count }
+func (s RatingSummary) Total() int    { return s.total }
+func (s RatingSummary) Revision() int { return s.revision }
+
+// Rate adds rating to the summary. previous is the player's rating of the game that rating replaces, or nil
+// if they hadn't rated it before.
+func (s *RatingSummary) Rate(rating, previous *Rating) {
+	if previous == nil {
+		s.count++
+	} else {
+		s.total -= previous.stars
+	}
+
+	s.total += rating.stars
+	s.revision++
+}
+
+// UnmarshalRatingSummaryFromDatabase should only be used in repo implementations to unmarshal data from a
+// database into a domain rating summary.
+func UnmarshalRatingSummaryFromDatabase(count, total, revision int) RatingSummary {
+	return RatingSummary{count: count, total: total, revision: revision}
+}
+
+// UnmarshalRatingFromDatabase should only be used in repo implementations to unmarshal data from a database
+// into a domain rating.
+func UnmarshalRatingFromDatabase(
+	gameUUID,
+	playerUUID,
+	playerName string,
+	stars int,
+	review string,
+	ratedAt time.Time) *Rating {
+	return &Rating{
+		gameUUID:   gameUUID,
+		playerUUID: playerUUID,
+		playerName: playerName,
+		stars:      stars,
+		review:     review,
+		ratedAt:    ratedAt,
+	}
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestNewRating(t *testing.T) {
+	g := newValidTestUrbanGame()
+	p := newValidTestPlayer()
+	rater := User{uuid: p.uuid, number: p.number, displayName: "Gopher"}
+
+	s, _, err := Start(g, p)
+	require.NoError(t, err)
+
+	_, err = NewRating(rater, s, 5, "")
+	assert.Equal(t, ErrorGameNotCompleted, err)
+
+	for _, l := range g.levels {
+		_, err := s.Update(g, l.answers[0], p)
+		require.NoError(t, err)
+	}
+	require.True(t, s.Completed())
+
+	r, err := NewRating(rater, s, 4, "Great fun")
+	require.NoError(t, err)
+	assert.Equal(t, g.UUID(), r.GameUUID())
+	assert.Equal(t, p.UUID(), r.PlayerUUID())
+	assert.Equal(t, "Gopher", r.PlayerName())
+	assert.Equal(t, 4, r.Stars())
+	assert.Equal(t, "Great fun", r.Review())
+
+	_, err = NewRating(rater, s, 0, "")
+	assert.NotNil(t, err)
+
+	_, err = NewRating(rater, s, 6, "")
+	assert.NotNil(t, err)
+
+	_, err = NewRating(rater, s, 3, strings.Repeat("a", MaxReviewLength+1))
+	assert.NotNil(t, err)
+
+	// Reviews are limited in characters rather than bytes.
+	_, err = NewRating(rater, s, 3, strings.Repeat("ゴ", MaxReviewLength))
+	assert.NoError(t, err)
+
+	_, err = NewRating(newTestUser(), s, 3, "")
+	assert.NotNil(t, err)
+}
+
+func TestRatingSummary_Rate(t *testing.T) {
+	var summary RatingSummary
+
+	summary.Rate(&Rating{stars: 3}, nil)
+	summary.Rate(&Rating{stars: 5}, nil)
+	assert.Equal(t, UnmarshalRatingSummaryFromDatabase(2, 8, 2), summary)
+
+	// Rating again replaces the previous rating rather than adding another.
+	summary.Rate(&Rating{stars: 1}, &Rating{stars: 3})
+	assert.Equal(t, UnmarshalRatingSummaryFromDatabase(2, 6, 3), summary)
+}
@@ -1,28 +1,63 @@
 package game
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 var (
-	ErrorPlayerNotFound = errors.New("player not found")
+	ErrorGameNotFound   = newNotFoundError("game not found", "game-not-found")
+	ErrorPlayerNotFound = newNotFoundError("player not found", "player-not-found")
+	ErrorStateNotFound  = newNotFoundError("game state not found", "game-state-not-found")
+
+	ErrorGameAlreadyExists   = newConflictError("game already exists", "game-already-exists")
+	ErrorReportAlreadyExists = newConflictError("report already exists", "report-already-exists")
+	ErrorPlayerAlreadyExists = newConflictError("player already exists", "player-already-exists")
+	ErrorStateAlreadyExists  = newConflictError("game state already exists", "game-state-already-exists")
 )
 
 // Repository is the interface used to persist domain types.
 type Repository interface {
+	// AddGame returns ErrorGameAlreadyExists if a game with the same uuid exists.
 	AddGame(ctx context.Context, game *Game) error
+	// GetGame returns ErrorGameNotFound if game does not exist.
 	GetGame(ctx context.Context, uuid string) (*Game, error)
 
+	// AddReport returns ErrorReportAlreadyExists if a report with the same uuid exists.
+	AddReport(ctx context.Context, report *Report) error
+	// GetOpenReports returns the reports of a game which have not been resolved.
+	GetOpenReports(ctx context.Context, gameUUID string) ([]*Report, error)
+	// UpdateGameAndReports saves the moderation status of the game and the reports resolved with it. It
+	// returns ErrorGameNotFound if game does not exist.
+	UpdateGameAndReports(ctx context.Context, game *Game, reports []*Report) error
+
+	// AddPlayer returns ErrorPlayerAlreadyExists if a player with the same uuid exists.
 	AddPlayer(ctx context.Context, player *Player) error
 	// GetPlayer returns ErrorPlayerNotFound if player does not exist.
 	GetPlayer(ctx context.Context, uuid string) (*Player, error)
 	// GetPlayerByNumber returns ErrorPlayerNotFound if player with number does not exist.
 	GetPlayerByNumber(ctx context.Context, playerNumber string) (*Player, error)
+	UpdatePlayer(ctx context.Context, player *Player) error
 
+	AddConsentRecord(ctx context.Context, record *ConsentRecord) error
+	// GetConsentRecords returns every consent record of a player, oldest first.
+	GetConsentRecords(ctx context.Context, playerUUID string) ([]*ConsentRecord, error)
+
+	// AddState returns ErrorStateAlreadyExists if a state with the same uuid exists.
 	AddState(ctx context.Context, state *State) error
+	// GetState returns ErrorStateNotFound if state does not exist.
 	GetState(ctx context.Context, uuid string) (*State, error)
+	// GetPlayerGameStates returns every state of a player for a game.
+	GetPlayerGameStates(ctx context.Context, playerUUID, gameUUID string) ([]*State, error)
 	UpdateState(ctx context.Context, state *State) error
+	// AddStateAndUpdatePlayer returns ErrorStateAlreadyExists if a state with the same uuid exists.
 	AddStateAndUpdatePlayer(ctx context.Context, state *State, player *Player) error
 	UpdateStateAndPlayer(ctx context.Context, state *State, player *Player) error
+	// UpdateStateAndPlayerWithAttempt adds the attempt in the same transaction as the state and player are
+	// updated, so an answer is never applied without being recorded.
+	UpdateStateAndPlayerWithAttempt(ctx context.Context, state *State, player *Player, attempt *Attempt) error
+
+	// GetRating returns ErrorRatingNotFound if the player has not rated the game.
+	GetRating(ctx context.Context, gameUUID, playerUUID string) (*Rating, error)
+	// SaveRating adds the rating or replaces the player's previous rating of the game. In the same transaction
+	// updateSummary is called with the previous rating, which is nil if there isn't one, to update the game's
+	// RatingSummary. updateSummary may be called more than once.
+	SaveRating(ctx context.Context, rating *Rating, updateSummary func(previous *Rating, summary *RatingSummary) error) error
 }
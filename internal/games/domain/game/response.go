@@ -7,6 +7,10 @@ const (
 	LevelResponse ResponseKind = "level"
 	ClueResponse  ResponseKind = "clue"
 	EndResponse   ResponseKind = "end"
+	// IncorrectResponse is given for a wrong answer that doesn't reveal a clue.
+	IncorrectResponse ResponseKind = "incorrect"
+	// MessageResponse is a message about the player's messaging rather than the game, e.g. after they opted out.
+	MessageResponse ResponseKind = "message"
 )
 
 // Response represents the response from the game based on its current state and the player's input.
@@ -16,6 +20,7 @@ type Response struct {
 	LevelDescription string       `json:"levelDescription"`
 	Clue             string       `json:"clue"`
 	EndMessage       string       `json:"endMessage"`
+	Message          string       `json:"message"`
 }
 
 func newGameEndResponse(msg string) *Response {
@@ -39,3 +44,17 @@ func newClueResponse(clue string) *Response {
 		Clue: clue,
 	}
 }
+
+func newMessageResponse(msg string) *Response {
+	return &Response{
+		Kind:    MessageResponse,
+		Message: msg,
+	}
+}
+
+func newIncorrectResponse(msg string) *Response {
+	return &Response{
+		Kind:    IncorrectResponse,
+		Message: msg,
+	}
+}
@@ -0,0 +1,89 @@
+package game
+
+import "fmt"
+
+// Role is what a user does in the app. A user can have more than one role.
+type Role string
+
+const (
+	// RolePlayer plays games.
+	RolePlayer Role = "player"
+	// RoleCreator creates games.
+	RoleCreator Role = "creator"
+	// RoleOrganizer runs events and looks after the players taking part.
+	RoleOrganizer Role = "organizer"
+	// RoleAdmin runs the app and moderates games.
+	RoleAdmin Role = "admin"
+)
+
+var ErrorUnknownPermission = newValidationError("unknown permission", "unknown-permission")
+
+// DefaultRoles are the roles of a user who hasn't been given any.
+var DefaultRoles = []Role{RolePlayer, RoleCreator}
+
+// Permission is something a user is allowed to do because of their roles, or because it was given to
+// the API key a client is using.
+type Permission string
+
+const (
+	// PermissionReadGames allows reading games, their reviews and analytics.
+	PermissionReadGames         Permission = "read-games"
+	PermissionCreateGames       Permission = "create-games"
+	PermissionPlayGames         Permission = "play-games"
+	PermissionModerateGames     Permission = "moderate-games"
+	PermissionManageProjections Permission = "manage-projections"
+	// PermissionManagePlayers allows acting on players and game states that belong to other users.
+	PermissionManagePlayers Permission = "manage-players"
+	PermissionManageAPIKeys Permission = "manage-api-keys"
+)
+
+var permissions = []Permission{
+	PermissionReadGames,
+	PermissionCreateGames,
+	PermissionPlayGames,
+	PermissionModerateGames,
+	PermissionManageProjections,
+	PermissionManagePlayers,
+	PermissionManageAPIKeys,
+}
+
+// NewPermission returns the permission named s.
+func NewPermission(s string) (Permission, error) {
+	for _, p := range permissions {
+		if string(p) == s {
+			return p, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w %q", ErrorUnknownPermission, s)
+}
+
+var rolePermissions = map[Role][]Permission{
+	RolePlayer:    {PermissionReadGames, PermissionPlayGames},
+	RoleCreator:   {PermissionReadGames, PermissionCreateGames},
+	RoleOrganizer: {PermissionReadGames, PermissionPlayGames, PermissionManagePlayers},
+	RoleAdmin: {
+		PermissionReadGames,
+		PermissionCreateGames,
+		PermissionPlayGames,
+		PermissionModerateGames,
+		PermissionManageProjections,
+		PermissionManagePlayers,
+		PermissionManageAPIKeys,
+	},
+}
+
+func (r Role) valid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+func (r Role) grants(p Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+
+	return false
+}
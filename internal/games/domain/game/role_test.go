@@ -0,0 +1,76 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestUser_Can(t *testing.T) {
+	u, err := NewUser("uuid", "15734497033")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRoles, u.Roles())
+
+	admin, err := NewUserWithRoles("uuid", "15734497033", "Admin", RoleAdmin, "superuser", RoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, []Role{RoleAdmin}, admin.Roles())
+
+	player, err := NewUserWithRoles("uuid", "15734497033", "Player", RolePlayer)
+	require.NoError(t, err)
+
+	organizer, err := NewUserWithRoles("uuid", "15734497033", "Organizer", RoleOrganizer)
+	require.NoError(t, err)
+
+	nobody, err := NewUserWithRoles("uuid", "15734497033", "Nobody", "superuser")
+	require.NoError(t, err)
+	assert.Empty(t, nobody.Roles())
+
+	client, err := NewClientUser("api-key:id", "SMS gateway", PermissionPlayGames, PermissionManagePlayers)
+	require.NoError(t, err)
+	assert.Empty(t, client.Roles())
+
+	tests := []struct {
+		name       string
+		user       User
+		permission Permission
+		can        bool
+	}{
+		{"default read", u, PermissionReadGames, true},
+		{"default create", u, PermissionCreateGames, true},
+		{"default play", u, PermissionPlayGames, true},
+		{"default moderate", u, PermissionModerateGames, false},
+		{"default projections", u, PermissionManageProjections, false},
+		{"player create", player, PermissionCreateGames, false},
+		{"player play", player, PermissionPlayGames, true},
+		{"admin moderate", admin, PermissionModerateGames, true},
+		{"admin projections", admin, PermissionManageProjections, true},
+		{"admin create", admin, PermissionCreateGames, true},
+		{"admin players", admin, PermissionManagePlayers, true},
+		{"default players", u, PermissionManagePlayers, false},
+		{"organizer players", organizer, PermissionManagePlayers, true},
+		{"organizer moderate", organizer, PermissionModerateGames, false},
+		{"organizer read", organizer, PermissionReadGames, true},
+		{"admin read", admin, PermissionReadGames, true},
+		{"no roles", nobody, PermissionPlayGames, false},
+		{"client play", client, PermissionPlayGames, true},
+		{"client players", client, PermissionManagePlayers, true},
+		{"client create", client, PermissionCreateGames, false},
+		{"client read", client, PermissionReadGames, false},
+		{"client api keys", client, PermissionManageAPIKeys, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.can, tt.user.Can(tt.permission))
+		})
+	}
+}
+
+func TestNewPermission(t *testing.T) {
+	p, err := NewPermission("manage-players")
+	require.NoError(t, err)
+	assert.Equal(t, PermissionManagePlayers, p)
+
+	_, err = NewPermission("fly")
+	assert.Error(t, err)
+}
@@ -3,18 +3,37 @@ package game
 import (
 	"errors"
 	"github.com/google/uuid"
+	"time"
+)
+
+var (
+	ErrorNoCurrentGame        = newConflictError("player isn't playing a game", "no-current-game")
+	ErrorNotCurrentGame       = newConflictError("game state isn't the player's current game", "not-current-game")
+	ErrorInvalidGame          = newValidationError("game has no uuid", "invalid-game")
+	ErrorInvalidPlayer        = newValidationError("player has no uuid", "invalid-player")
+	ErrorInvalidGameState     = newConflictError("game state is past the last level", "invalid-game-state")
+	ErrorStateOfAnotherGame   = newValidationError("game state is of another game", "state-of-another-game")
+	ErrorStateOfAnotherPlayer = newForbiddenError("game state is of another player", "state-of-another-player")
 )
 
 // State holds all the information for the state of a game.
 type State struct {
-	uuid            string
-	playerUUID      string
-	gameUUID        string
-	gameLevels      int
-	level           int
-	clue            int
+	uuid       string
+	playerUUID string
+	gameUUID   string
+	gameLevels int
+	level      int
+	clue       int
+	// skipped is the number of levels the player skipped. Players only get a game's points if they didn't
+	// skip any levels.
+	skipped         int
 	completed       bool
 	currentResponse Response
+	startedAt       time.Time
+	finishedAt      time.Time
+	// clueRevealedAt is when the last clue of the current level was revealed. It is the zero time if none
+	// have been.
+	clueRevealedAt time.Time
 }
 
 func (s State) UUID() string              { return s.uuid }
@@ -23,65 +42,186 @@ func (s State) GameUUID() string          { return s.gameUUID }
 func (s State) GameLevels() int           { return s.gameLevels }
 func (s State) Level() int                { return s.level }
 func (s State) Clue() int                 { return s.clue }
+func (s State) Skipped() int              { return s.skipped }
 func (s State) Completed() bool           { return s.completed }
 func (s State) CurrentResponse() Response { return s.currentResponse }
+func (s State) StartedAt() time.Time      { return s.startedAt }
+func (s State) FinishedAt() time.Time     { return s.finishedAt }
+func (s State) ClueRevealedAt() time.Time { return s.clueRevealedAt }
 
 // Update updates the state and player based on the current state of the game and the input from the player.
 func (s *State) Update(g *Game, input string, p *Player) (*Response, error) {
+	l, resp, err := s.currentLevel(g)
+	if l == nil {
+		return resp, err
+	}
+
+	if l.isAnswer(input) { // Is the input an answer to this level?
+		return s.nextLevel(g, p)
+	}
+
+	// Wrong answers only reveal the next clue if the game allows it and the player could ask for one.
+	if !g.clues.wrongAnswersRevealClues || now().Before(s.NextClueAt(g)) {
+		return newIncorrectResponse(printer(g.locale).Sprintf(IncorrectMessage)), nil
+	}
+
+	return s.nextClue(g, l), nil
+}
+
+// RequestClue reveals the next clue of the current level without it counting as an answer. The last clue
+// is given again once all clues have been revealed. ErrorClueCooldown is returned if the game's clue
+// cooldown hasn't passed since the last clue was revealed.
+func (s *State) RequestClue(g *Game) (*Response, error) {
+	l, resp, err := s.currentLevel(g)
+	if l == nil {
+		return resp, err
+	}
+
+	if now().Before(s.NextClueAt(g)) {
+		return nil, ErrorClueCooldown
+	}
+
+	return s.nextClue(g, l), nil
+}
+
+// NextClueAt returns when the next clue of the current level can be revealed. It is the zero time if it
+// can be revealed straight away.
+func (s State) NextClueAt(g *Game) time.Time {
+	if s.clueRevealedAt.IsZero() || g.clues.cooldown == 0 {
+		return time.Time{}
+	}
+
+	// Once every clue has been revealed the last one can be given again at any time.
+	if s.level < len(g.levels) && s.clue >= len(g.levels[s.level].clues)-1 {
+		return time.Time{}
+	}
+
+	return s.clueRevealedAt.Add(g.clues.cooldown)
+}
+
+// Skip moves the player on to the next level without answering the current one. Skipping the last level
+// finishes the game without the game's points.
+func (s *State) Skip(g *Game, p *Player) (*Response, error) {
+	l, resp, err := s.currentLevel(g)
+	if l == nil {
+		return resp, err
+	}
+
+	s.skipped++
+
+	return s.nextLevel(g, p)
+}
+
+// points returns the points earned for finishing the game, which are none if the player skipped a level.
+func (s State) points(g *Game) int {
+	if s.skipped > 0 {
+		return 0
+	}
+
+	return g.value
+}
+
+// Quit stops the state being the player's current game. It can't be continued afterwards.
+func (s *State) Quit(g *Game, p *Player) (*Response, error) {
 	if s.gameUUID != g.UUID() {
-		return nil, errors.New("invalid game")
+		return nil, ErrorStateOfAnotherGame
+	}
+
+	if p.currentGameStateUUID == "" {
+		return nil, ErrorNoCurrentGame
+	}
+
+	if p.currentGameStateUUID != s.uuid {
+		return nil, ErrorNotCurrentGame
+	}
+
+	p.currentGameStateUUID = ""
+
+	return newMessageResponse(printer(g.locale).Sprintf(quitMessage, g.title)), nil
+}
+
+// Status returns a message saying how far the player has got in the game.
+func (s State) Status(g *Game) *Response {
+	p := printer(g.locale)
+
+	if s.completed {
+		return newMessageResponse(p.Sprintf(finishedMessage, g.title))
+	}
+
+	clues := 0
+	if s.level < len(g.levels) {
+		clues = len(g.levels[s.level].clues)
+	}
+
+	return newMessageResponse(p.Sprintf(statusMessage, s.level+1, s.gameLevels, g.title, s.clue+1, clues))
+}
+
+// currentLevel returns the level the player is on. If the game has been finished already the level is nil
+// and the game's ending is returned instead.
+func (s *State) currentLevel(g *Game) (*Level, *Response, error) {
+	if s.gameUUID != g.UUID() {
+		return nil, nil, ErrorStateOfAnotherGame
 	}
 
 	// Check if game has been finished already.
 	if s.completed {
 		resp := newGameEndResponse(g.ending)
-		return resp, nil
+		return nil, resp, nil
 	}
 
 	if s.level >= len(g.levels) {
-		return nil, errors.New("invalid game state")
+		return nil, nil, ErrorInvalidGameState
 	}
 
-	l := g.levels[s.level]
+	return g.levels[s.level], nil, nil
+}
 
-	if l.isAnswer(input) { // Is the input an answer to this level?
-		s.level++
-		s.clue = -1
-		if s.level == len(g.levels) { // Have all levels been completed?
-			s.completed = true
-			resp := newGameEndResponse(g.ending)
-			s.currentResponse = *resp
-			if err := p.finishGame(g, s); err != nil {
-				return nil, err
-			}
-			return resp, nil
-		} else {
-			l := g.levels[s.level]
-			resp := newLevelResponse(l.title, l.description)
-			s.currentResponse = *resp
-			return resp, nil
-		}
-	} else {
-		if len(l.clues) > 0 { // Does this level have any clues?
-			if s.clue < len(l.clues)-1 {
-				s.clue++
-			}
-
-			resp := newClueResponse(l.clues[s.clue])
-			s.currentResponse = *resp
-			return resp, nil
-		} else {
-			resp := newClueResponse("this level has no clues")
-			s.currentResponse = *resp
-			return resp, nil
+func (s *State) nextLevel(g *Game, p *Player) (*Response, error) {
+	s.level++
+	s.clue = -1
+	s.clueRevealedAt = time.Time{}
+	if s.level == len(g.levels) { // Have all levels been completed?
+		s.completed = true
+		s.finishedAt = now()
+		resp := newGameEndResponse(g.ending)
+		s.currentResponse = *resp
+		if err := p.finishGame(g, s); err != nil {
+			return nil, err
 		}
+		return resp, nil
+	}
+
+	l := g.levels[s.level]
+	resp := newLevelResponse(l.title, l.description)
+	s.currentResponse = *resp
+	return resp, nil
+}
+
+func (s *State) nextClue(g *Game, l *Level) *Response {
+	if len(l.clues) == 0 { // Does this level have any clues?
+		resp := newClueResponse(printer(g.locale).Sprintf(noCluesMessage))
+		s.currentResponse = *resp
+		return resp
+	}
+
+	if s.clue < len(l.clues)-1 {
+		s.clue++
+		s.clueRevealedAt = now()
 	}
+
+	resp := newClueResponse(l.clues[s.clue])
+	s.currentResponse = *resp
+	return resp
 }
 
 // Start starts a game. It will update the player and return a new State.
 func Start(g *Game, p *Player) (*State, *Response, error) {
 	if g.uuid == "" {
-		return nil, nil, errors.New("invalid game")
+		return nil, nil, ErrorInvalidGame
+	}
+
+	if !g.Published() {
+		return nil, nil, ErrorGameNotPublished
 	}
 
 	if p == nil {
@@ -89,7 +229,7 @@ func Start(g *Game, p *Player) (*State, *Response, error) {
 	}
 
 	if p.uuid == "" {
-		return nil, nil, errors.New("invalid player")
+		return nil, nil, ErrorInvalidPlayer
 	}
 
 	id, err := uuid.NewRandom()
@@ -110,6 +250,7 @@ func Start(g *Game, p *Player) (*State, *Response, error) {
 		level:           0,
 		clue:            -1,
 		currentResponse: *resp,
+		startedAt:       now(),
 	}, resp, nil
 }
 
@@ -121,9 +262,13 @@ func UnmarshalGameStateFromDatabase(
 	gameUUID string,
 	gameLevels,
 	level,
-	clue int,
+	clue,
+	skipped int,
 	completed bool,
-	currentResponse Response) *State {
+	currentResponse Response,
+	startedAt,
+	finishedAt,
+	clueRevealedAt time.Time) *State {
 	return &State{
 		uuid:            uuid,
 		playerUUID:      playerUUID,
@@ -131,7 +276,11 @@ func UnmarshalGameStateFromDatabase(
 		gameLevels:      gameLevels,
 		level:           level,
 		clue:            clue,
+		skipped:         skipped,
 		completed:       completed,
 		currentResponse: currentResponse,
+		startedAt:       startedAt,
+		finishedAt:      finishedAt,
+		clueRevealedAt:  clueRevealedAt,
 	}
 }
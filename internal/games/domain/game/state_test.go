@@ -123,3 +123,137 @@ func TestState_Update(t *testing.T) {
 		assert.Equal(t, g.Value(), p.TotalPoints())
 	})
 }
+
+func TestState_RequestClue(t *testing.T) {
+	g := newValidTestUrbanGame()
+	p := newValidTestPlayer()
+	s, _, err := Start(g, p)
+	require.NoError(t, err)
+
+	l1 := g.levels[0]
+
+	t.Run("invalid game", func(t *testing.T) {
+		_, err := s.RequestClue(&Game{})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("clues", func(t *testing.T) {
+		for _, clue := range l1.clues {
+			resp, err := s.RequestClue(g)
+			require.NoError(t, err)
+			assert.Equal(t, ClueResponse, resp.Kind)
+			assert.Equal(t, clue, resp.Clue)
+			assert.Equal(t, *resp, s.CurrentResponse())
+		}
+
+		resp, err := s.RequestClue(g)
+		require.NoError(t, err)
+		assert.Equal(t, l1.clues[len(l1.clues)-1], resp.Clue) // The last clue should repeat.
+		assert.Equal(t, 0, s.Level())
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		for _, l := range g.levels {
+			_, err := s.Update(g, l.answers[0], p)
+			require.NoError(t, err)
+		}
+
+		resp, err := s.RequestClue(g)
+		require.NoError(t, err)
+		assert.Equal(t, EndResponse, resp.Kind)
+	})
+}
+
+func TestState_Skip(t *testing.T) {
+	g := newValidTestUrbanGame()
+	p := newValidTestPlayer()
+	s, _, err := Start(g, p)
+	require.NoError(t, err)
+
+	t.Run("invalid game", func(t *testing.T) {
+		_, err := s.Skip(&Game{}, p)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("next level", func(t *testing.T) {
+		_, err := s.RequestClue(g)
+		require.NoError(t, err)
+
+		resp, err := s.Skip(g, p)
+		require.NoError(t, err)
+		assert.Equal(t, LevelResponse, resp.Kind)
+		assert.Equal(t, g.levels[1].title, resp.LevelTitle)
+		assert.Equal(t, 1, s.Level())
+		assert.Equal(t, -1, s.Clue())
+		assert.Equal(t, 1, s.Skipped())
+	})
+
+	t.Run("no points after skipping", func(t *testing.T) {
+		_, err := s.Update(g, g.levels[1].answers[0], p)
+		require.NoError(t, err)
+
+		resp, err := s.Skip(g, p)
+		require.NoError(t, err)
+		assert.Equal(t, EndResponse, resp.Kind)
+		assert.True(t, s.Completed())
+		assert.Equal(t, 1, p.GamesFinished())
+		assert.Equal(t, 0, p.TotalPoints())
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		resp, err := s.Skip(g, p)
+		require.NoError(t, err)
+		assert.Equal(t, EndResponse, resp.Kind)
+		assert.Equal(t, 2, s.Skipped())
+		assert.Equal(t, 1, p.GamesFinished())
+	})
+}
+
+func TestState_Quit(t *testing.T) {
+	g := newValidTestUrbanGame()
+	p := newValidTestPlayer()
+	s, _, err := Start(g, p)
+	require.NoError(t, err)
+
+	t.Run("other state", func(t *testing.T) {
+		other, _, err := Start(g, newValidTestPlayer())
+		require.NoError(t, err)
+
+		_, err = other.Quit(g, p)
+		assert.NotNil(t, err)
+		assert.Equal(t, s.UUID(), p.CurrentGameStateUUID())
+	})
+
+	t.Run("quit", func(t *testing.T) {
+		resp, err := s.Quit(g, p)
+		require.NoError(t, err)
+		assert.Equal(t, MessageResponse, resp.Kind)
+		assert.Equal(t, "", p.CurrentGameStateUUID())
+	})
+
+	t.Run("not playing", func(t *testing.T) {
+		_, err := s.Quit(g, p)
+		assert.Equal(t, ErrorNoCurrentGame, err)
+	})
+}
+
+func TestState_Status(t *testing.T) {
+	g := newValidTestUrbanGame()
+	p := newValidTestPlayer()
+	s, _, err := Start(g, p)
+	require.NoError(t, err)
+
+	_, err = s.RequestClue(g)
+	require.NoError(t, err)
+
+	resp := s.Status(g)
+	assert.Equal(t, MessageResponse, resp.Kind)
+	assert.Equal(t, "Gopher Cache: You are on level 1 of 3 of game title with 1 of 3 clues revealed.", resp.Message)
+
+	for _, l := range g.levels {
+		_, err := s.Update(g, l.answers[0], p)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, "Gopher Cache: You have finished game title.", s.Status(g).Message)
+}
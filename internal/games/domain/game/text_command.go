@@ -0,0 +1,158 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TextCommand is something a player can ask for by texting a word instead of an answer.
+type TextCommand string
+
+const (
+	// TextCommandHint reveals the next clue without it counting as a wrong answer.
+	TextCommandHint TextCommand = "hint"
+	// TextCommandRepeat sends the last response again.
+	TextCommandRepeat TextCommand = "repeat"
+	// TextCommandStatus says how far the player has got in their game.
+	TextCommandStatus TextCommand = "status"
+	// TextCommandSkip moves on to the next level without answering the current one.
+	TextCommandSkip TextCommand = "skip"
+	// TextCommandQuit stops the player's current game.
+	TextCommandQuit TextCommand = "quit"
+	// TextCommandGames lists games the player can start.
+	TextCommandGames TextCommand = "games"
+)
+
+var textCommands = map[TextCommand]bool{
+	TextCommandHint:   true,
+	TextCommandRepeat: true,
+	TextCommandStatus: true,
+	TextCommandSkip:   true,
+	TextCommandQuit:   true,
+	TextCommandGames:  true,
+}
+
+var (
+	ErrorUnknownTextCommand      = newValidationError("unknown text command", "unknown-text-command")
+	ErrorInvalidTextCommandAlias = newValidationError(
+		"text command aliases must be a single word", "invalid-text-command-alias")
+	ErrorTextCommandAliasKeyword = newValidationError(
+		"text command aliases can't be messaging keywords", "text-command-alias-keyword")
+	ErrorDuplicateTextCommandAlias = newValidationError(
+		"text command alias is used for more than one command", "duplicate-text-command-alias")
+)
+
+// DefaultTextCommandAliases are the words players may text for each TextCommand by language. QUIT isn't an
+// alias of TextCommandQuit since carriers require it to opt the player out of messages.
+var DefaultTextCommandAliases = map[string]map[string]TextCommand{
+	"en": {
+		"HINT":   TextCommandHint,
+		"CLUE":   TextCommandHint,
+		"REPEAT": TextCommandRepeat,
+		"AGAIN":  TextCommandRepeat,
+		"STATUS": TextCommandStatus,
+		"SKIP":   TextCommandSkip,
+		"LEAVE":  TextCommandQuit,
+		"EXIT":   TextCommandQuit,
+		"GAMES":  TextCommandGames,
+	},
+	"es": {
+		"PISTA":   TextCommandHint,
+		"REPETIR": TextCommandRepeat,
+		"ESTADO":  TextCommandStatus,
+		"SALTAR":  TextCommandSkip,
+		"SALIR":   TextCommandQuit,
+		"JUEGOS":  TextCommandGames,
+	},
+	"fr": {
+		"INDICE":  TextCommandHint,
+		"REPETER": TextCommandRepeat,
+		"STATUT":  TextCommandStatus,
+		"PASSER":  TextCommandSkip,
+		"QUITTER": TextCommandQuit,
+		"JEUX":    TextCommandGames,
+	},
+}
+
+// defaultTextCommandAliases recognizes the DefaultTextCommandAliases, which answers can't be.
+var defaultTextCommandAliases = mustTextCommandAliases(DefaultTextCommandAliases)
+
+func mustTextCommandAliases(languages map[string]map[string]TextCommand) TextCommandAliases {
+	aliases, err := NewTextCommandAliases(languages)
+	if err != nil {
+		panic(err)
+	}
+
+	return aliases
+}
+
+// reservedWord reports whether players texting message get something other than an answer because it is
+// a messaging keyword or one of the DefaultTextCommandAliases.
+func reservedWord(message string) bool {
+	if _, ok := ParseMessagingKeyword(message); ok {
+		return true
+	}
+
+	_, ok := defaultTextCommandAliases.Parse(message)
+
+	return ok
+}
+
+// TextCommandAliases recognizes the words players text for each TextCommand. The aliases of every language
+// are recognized since players don't choose a language.
+type TextCommandAliases struct {
+	aliases map[string]TextCommand
+}
+
+// NewTextCommandAliases creates aliases from the words for each TextCommand by language. An alias may only
+// be used for one command across all languages and can't be a messaging keyword such as STOP, which must
+// always be honoured.
+func NewTextCommandAliases(languages map[string]map[string]TextCommand) (TextCommandAliases, error) {
+	aliases := make(map[string]TextCommand)
+
+	// Languages are checked in order so the same error is returned for the same aliases.
+	names := make([]string, 0, len(languages))
+	for language := range languages {
+		names = append(names, language)
+	}
+	sort.Strings(names)
+
+	for _, language := range names {
+		for alias, command := range languages[language] {
+			if !textCommands[command] {
+				return TextCommandAliases{}, fmt.Errorf("%w %q in %s", ErrorUnknownTextCommand, command, language)
+			}
+
+			word := keywordText(alias)
+			if len(strings.Fields(word)) != 1 {
+				return TextCommandAliases{}, fmt.Errorf("%w: %q in %s", ErrorInvalidTextCommandAlias, alias, language)
+			}
+
+			if _, ok := ParseMessagingKeyword(word); ok {
+				return TextCommandAliases{}, fmt.Errorf("%w: %q in %s", ErrorTextCommandAliasKeyword, alias, language)
+			}
+
+			if other, ok := aliases[word]; ok && other != command {
+				return TextCommandAliases{}, fmt.Errorf("%w: %q in %s", ErrorDuplicateTextCommandAlias, alias, language)
+			}
+
+			aliases[word] = command
+		}
+	}
+
+	return TextCommandAliases{aliases: aliases}, nil
+}
+
+// Parse returns the text command if the whole message is one of its aliases, ignoring case, spaces and
+// trailing punctuation.
+func (a TextCommandAliases) Parse(message string) (TextCommand, bool) {
+	command, ok := a.aliases[keywordText(message)]
+	return command, ok
+}
+
+// NewGamesResponse returns a message listing the titles of games a player can start in the message locale
+// that best matches locale.
+func NewGamesResponse(titles []string, locale string) *Response {
+	return newMessageResponse(printer(locale).Sprintf(gamesMessage, len(titles), strings.Join(titles, ", ")))
+}
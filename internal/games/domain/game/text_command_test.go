@@ -0,0 +1,81 @@
+package game
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewTextCommandAliases(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		aliases, err := NewTextCommandAliases(DefaultTextCommandAliases)
+		require.NoError(t, err)
+
+		testCases := []struct {
+			message string
+			command TextCommand
+		}{
+			{"HINT", TextCommandHint},
+			{" hint ", TextCommandHint},
+			{"Clue!", TextCommandHint},
+			{"pista", TextCommandHint},
+			{"repeat", TextCommandRepeat},
+			{"status", TextCommandStatus},
+			{"skip", TextCommandSkip},
+			{"leave", TextCommandQuit},
+			{"quitter", TextCommandQuit},
+			{"games", TextCommandGames},
+		}
+
+		for _, c := range testCases {
+			t.Run(c.message, func(t *testing.T) {
+				command, ok := aliases.Parse(c.message)
+				assert.True(t, ok)
+				assert.Equal(t, c.command, command)
+			})
+		}
+
+		for _, message := range []string{"QUIT", "STOP", "hint please", "level one answer"} {
+			_, ok := aliases.Parse(message)
+			assert.False(t, ok, message)
+		}
+	})
+
+	testCases := []struct {
+		name      string
+		languages map[string]map[string]TextCommand
+		err       error
+	}{
+		{
+			name:      "unknown command",
+			languages: map[string]map[string]TextCommand{"en": {"FLY": "fly"}},
+			err:       ErrorUnknownTextCommand,
+		},
+		{
+			name:      "more than one word",
+			languages: map[string]map[string]TextCommand{"en": {"NEXT CLUE": TextCommandHint}},
+			err:       ErrorInvalidTextCommandAlias,
+		},
+		{
+			name:      "messaging keyword",
+			languages: map[string]map[string]TextCommand{"en": {"QUIT": TextCommandQuit}},
+			err:       ErrorTextCommandAliasKeyword,
+		},
+		{
+			name: "duplicate",
+			languages: map[string]map[string]TextCommand{
+				"en": {"PASS": TextCommandSkip},
+				"fr": {"pass": TextCommandHint},
+			},
+			err: ErrorDuplicateTextCommandAlias,
+		},
+	}
+
+	for _, c := range testCases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := NewTextCommandAliases(c.languages)
+			assert.True(t, errors.Is(err, c.err), err)
+		})
+	}
+}
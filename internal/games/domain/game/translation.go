@@ -0,0 +1,210 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+)
+
+var (
+	ErrorTranslationLevels = newValidationError(
+		"translation must have the same levels as the game", "invalid-translation-levels")
+	ErrorTranslationClues = newValidationError(
+		"translated level must have the same number of clues as the game's level", "invalid-translation-clues")
+	ErrorDefaultLocale = newValidationError(
+		"the game's default locale can't be translated", "default-locale-translation")
+)
+
+// Translation is the text of a game in a locale other than its default locale.
+type Translation struct {
+	locale      string
+	title       string
+	description string
+	ending      string
+	levels      []LevelTranslation
+}
+
+func (t Translation) Locale() string             { return t.locale }
+func (t Translation) Title() string              { return t.title }
+func (t Translation) Description() string        { return t.description }
+func (t Translation) Ending() string             { return t.ending }
+func (t Translation) Levels() []LevelTranslation { return t.levels }
+
+// LevelTranslation is the text of a level in a Translation. Its answers are accepted as well as the answers
+// of the level in the game's default locale.
+type LevelTranslation struct {
+	title       string
+	description string
+	clues       []string
+	answers     []string
+}
+
+func (l LevelTranslation) Title() string       { return l.title }
+func (l LevelTranslation) Description() string { return l.description }
+func (l LevelTranslation) Clues() []string     { return l.clues }
+func (l LevelTranslation) Answers() []string   { return l.answers }
+
+// NewLevelTranslation creates a new LevelTranslation. answers may be empty if the level's answers don't
+// need translating.
+func NewLevelTranslation(title, description string, clues, answers []string) (LevelTranslation, error) {
+	var v validator
+	validateLevelText(&v, "", title, description, clues, answers)
+
+	if err := v.err(); err != nil {
+		return LevelTranslation{}, err
+	}
+
+	return LevelTranslation{
+		title:       title,
+		description: description,
+		clues:       clues,
+		answers:     answers,
+	}, nil
+}
+
+// NewTranslation creates a new Translation with a LevelTranslation for every level of the game.
+func NewTranslation(locale, title, description, ending string, levels ...LevelTranslation) (Translation, error) {
+	locale, err := NormalizeLocale(locale)
+	if err != nil {
+		return Translation{}, err
+	}
+
+	var v validator
+	v.text("title", title, MaxTitleLength)
+	v.multilineText("description", description, MaxDescriptionLength)
+	v.multilineText("ending", ending, MaxEndingLength)
+
+	if err := v.err(); err != nil {
+		return Translation{}, err
+	}
+
+	return Translation{
+		locale:      locale,
+		title:       title,
+		description: description,
+		ending:      ending,
+		levels:      levels,
+	}, nil
+}
+
+// Locale returns the locale of the game's own text.
+func (g *Game) Locale() string { return g.locale }
+
+// SetLocale changes the locale of the game's own text from the DefaultLocale.
+func (g *Game) SetLocale(locale string) error {
+	locale, err := NormalizeLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := g.translations[locale]; ok {
+		return ErrorDefaultLocale
+	}
+
+	g.locale = locale
+
+	return nil
+}
+
+// Translations returns the translations of the game sorted by locale.
+func (g *Game) Translations() []Translation {
+	translations := make([]Translation, 0, len(g.translations))
+	for _, t := range g.translations {
+		translations = append(translations, t)
+	}
+
+	sort.Slice(translations, func(i, j int) bool {
+		return translations[i].locale < translations[j].locale
+	})
+
+	return translations
+}
+
+// Locales returns the locales the game can be played in, starting with its default locale.
+func (g *Game) Locales() []string {
+	locales := []string{g.locale}
+	for _, t := range g.Translations() {
+		locales = append(locales, t.locale)
+	}
+
+	return locales
+}
+
+// AddTranslation adds the translation of the game or replaces its previous translation in the same locale.
+func (g *Game) AddTranslation(t Translation) error {
+	if t.locale == g.locale {
+		return ErrorDefaultLocale
+	}
+
+	if len(t.levels) != len(g.levels) {
+		return ErrorTranslationLevels
+	}
+
+	// Players' states point at clues by index so every locale must have the same clues.
+	for i, l := range t.levels {
+		if len(l.clues) != len(g.levels[i].clues) {
+			return fmt.Errorf("%w: level %d", ErrorTranslationClues, i+1)
+		}
+	}
+
+	if g.translations == nil {
+		g.translations = make(map[string]Translation)
+	}
+
+	g.translations[t.locale] = t
+
+	return nil
+}
+
+// Localize returns a copy of the game with its text in locale, which should be one of its Locales. The game
+// is returned unchanged if it has no translation in locale. System messages in the game's responses are
+// given in the message locale that best matches the locale of its text.
+func (g *Game) Localize(locale string) *Game {
+	t, ok := g.translations[locale]
+	if !ok {
+		return g
+	}
+
+	localized := *g
+	localized.locale = t.locale
+	localized.title = t.title
+	localized.description = t.description
+	localized.ending = t.ending
+	localized.levels = make([]*Level, len(g.levels))
+
+	for i, l := range g.levels {
+		lt := t.levels[i]
+
+		level := *l
+		level.title = lt.title
+		level.description = lt.description
+		level.clues = lt.clues
+		level.answers = append(append([]string{}, l.answers...), lt.answers...)
+
+		localized.levels[i] = &level
+	}
+
+	return &localized
+}
+
+// UnmarshalTranslationFromDatabase should only be used in repo implementations to unmarshal data from a
+// database into a domain game translation.
+func UnmarshalTranslationFromDatabase(locale, title, description, ending string, levels []LevelTranslation) Translation {
+	return Translation{
+		locale:      locale,
+		title:       title,
+		description: description,
+		ending:      ending,
+		levels:      levels,
+	}
+}
+
+// UnmarshalLevelTranslationFromDatabase should only be used in repo implementations to unmarshal data from
+// a database into a domain level translation.
+func UnmarshalLevelTranslationFromDatabase(title, description string, clues, answers []string) LevelTranslation {
+	return LevelTranslation{
+		title:       title,
+		description: description,
+		clues:       clues,
+		answers:     answers,
+	}
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newTestTranslation(t *testing.T, g *Game, locale string) Translation {
+	var levels []LevelTranslation
+	for i, l := range g.levels {
+		var clues []string
+		for j := range l.clues {
+			clues = append(clues, locale+" clue "+string(rune('a'+i))+string(rune('a'+j)))
+		}
+
+		level, err := NewLevelTranslation(locale+" level title", locale+" level description", clues, []string{locale + " answer"})
+		require.NoError(t, err)
+
+		levels = append(levels, level)
+	}
+
+	translation, err := NewTranslation(locale, locale+" title", locale+" description", locale+" ending", levels...)
+	require.NoError(t, err)
+
+	return translation
+}
+
+func TestGame_AddTranslation(t *testing.T) {
+	g := newValidTestUrbanGame()
+
+	t.Run("valid", func(t *testing.T) {
+		require.NoError(t, g.AddTranslation(newTestTranslation(t, g, "es")))
+		require.NoError(t, g.AddTranslation(newTestTranslation(t, g, "fr")))
+		assert.Equal(t, []string{"en", "es", "fr"}, g.Locales())
+	})
+
+	t.Run("default locale", func(t *testing.T) {
+		err := g.AddTranslation(newTestTranslation(t, g, "en"))
+		assert.Equal(t, ErrorDefaultLocale, err)
+
+		err = g.SetLocale("es")
+		assert.Equal(t, ErrorDefaultLocale, err)
+	})
+
+	t.Run("missing level", func(t *testing.T) {
+		translation := newTestTranslation(t, g, "de")
+		translation.levels = translation.levels[1:]
+
+		err := g.AddTranslation(translation)
+		assert.Equal(t, ErrorTranslationLevels, err)
+	})
+
+	t.Run("missing clue", func(t *testing.T) {
+		translation := newTestTranslation(t, g, "de")
+		translation.levels[1].clues = translation.levels[1].clues[1:]
+
+		err := g.AddTranslation(translation)
+		assert.True(t, errors.Is(err, ErrorTranslationClues))
+	})
+
+	t.Run("invalid locale", func(t *testing.T) {
+		_, err := NewTranslation("not a locale", "title", "description", "ending")
+		assert.Equal(t, ErrorInvalidLocale, err)
+	})
+
+	t.Run("content", func(t *testing.T) {
+		found := false
+		for _, c := range g.Content() {
+			if c.Field == "translations[es].levels[0].clues[0]" {
+				found = true
+				assert.Equal(t, "es clue aa", c.Text)
+			}
+		}
+		assert.True(t, found)
+	})
+}
+
+func TestGame_Localize(t *testing.T) {
+	g := newValidTestUrbanGame()
+	require.NoError(t, g.AddTranslation(newTestTranslation(t, g, "es")))
+
+	assert.Equal(t, g, g.Localize("en"))
+	assert.Equal(t, g, g.Localize("de"))
+
+	localized := g.Localize("es")
+	assert.Equal(t, "es", localized.Locale())
+	assert.Equal(t, "es title", localized.Title())
+	assert.Equal(t, "es ending", localized.Ending())
+	assert.Equal(t, g.UUID(), localized.UUID())
+
+	// The game itself isn't changed.
+	assert.Equal(t, "game title", g.Title())
+	assert.Equal(t, "level one title", g.levels[0].title)
+
+	p := newValidTestPlayer()
+	s, resp, err := Start(localized, p)
+	require.NoError(t, err)
+	assert.Equal(t, "es level title", resp.LevelTitle)
+
+	resp, err = s.Update(localized, "wrong answer", p)
+	require.NoError(t, err)
+	assert.Equal(t, "es clue aa", resp.Clue)
+
+	// Translated answers and the game's own answers are both accepted.
+	_, err = s.Update(localized, "es answer", p)
+	require.NoError(t, err)
+	_, err = s.Update(localized, g.levels[1].answers[0], p)
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.Level())
+
+	assert.Equal(t, "Gopher Cache: Estás en el nivel 3 de 3 de es title con 0 de 3 pistas reveladas.", s.Status(localized).Message)
+}
@@ -1,24 +1,28 @@
 package game
 
-import "errors"
-
 // NewUrbanGame creates a new Game with urban game info.
 func NewUrbanGame(creator User, title, description, ending, city, state, country string, levelAdders ...LevelAdder) (*Game, error) {
 	g, err := newGame(creator, title, description, ending, "urban", levelAdders...)
-	if err != nil {
+
+	var v validator
+	if err != nil && !v.merge(err) {
 		return nil, err
 	}
 
 	if city == "" {
-		return nil, errors.New("missing city")
+		v.add("city", RuleRequired, 0)
 	}
 
 	if state == "" {
-		return nil, errors.New("missing state")
+		v.add("state", RuleRequired, 0)
 	}
 
 	if country == "" {
-		return nil, errors.New("missing country")
+		v.add("country", RuleRequired, 0)
+	}
+
+	if err := v.err(); err != nil {
+		return nil, err
 	}
 
 	g.city = city
@@ -1,28 +1,123 @@
 package game
 
-import "errors"
+var (
+	ErrorUserWithoutUUID   = newValidationError("user has no uuid", "user-without-uuid")
+	ErrorUserWithoutNumber = newValidationError("user has no number", "user-without-number")
+)
 
 // User holds all user info.
 type User struct {
-	uuid   string
-	number string
+	uuid        string
+	number      string
+	displayName string
+	roles       []Role
+	// permissions are given to clients directly rather than through roles.
+	permissions []Permission
 }
 
-func (u User) UUID() string   { return u.uuid }
-func (u User) Number() string { return u.number }
+func (u User) UUID() string        { return u.uuid }
+func (u User) Number() string      { return u.number }
+func (u User) DisplayName() string { return u.displayName }
 
-// NewUser creates a new user.
+// Roles returns a copy of the user's roles.
+func (u User) Roles() []Role {
+	roles := make([]Role, len(u.roles))
+	copy(roles, u.roles)
+
+	return roles
+}
+
+// HasRole reports whether the user has the role r.
+func (u User) HasRole(r Role) bool {
+	for _, role := range u.roles {
+		if role == r {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Can reports whether the user was given the permission p or any of the user's roles grants it.
+func (u User) Can(p Permission) bool {
+	for _, permission := range u.permissions {
+		if permission == p {
+			return true
+		}
+	}
+
+	for _, role := range u.roles {
+		if role.grants(p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewUser creates a new user with the DefaultRoles. The number is normalized to E.164 format so the same
+// number always belongs to the same player.
 func NewUser(uuid, number string) (User, error) {
 	if uuid == "" {
-		return User{}, errors.New("user has no uuid")
+		return User{}, ErrorUserWithoutUUID
 	}
 
 	if number == "" {
-		return User{}, errors.New("user has no number")
+		return User{}, ErrorUserWithoutNumber
+	}
+
+	number, err := NormalizeNumber(number)
+	if err != nil {
+		return User{}, err
 	}
 
 	return User{
 		uuid:   uuid,
 		number: number,
+		roles:  DefaultRoles,
+	}, nil
+}
+
+// NewNamedUser creates a new user with the name they are shown as to other users.
+func NewNamedUser(uuid, number, displayName string) (User, error) {
+	u, err := NewUser(uuid, number)
+	if err != nil {
+		return User{}, err
+	}
+
+	u.displayName = displayName
+
+	return u, nil
+}
+
+// NewUserWithRoles creates a new named user with the given roles. Roles that aren't known are ignored,
+// so the user may end up with none.
+func NewUserWithRoles(uuid, number, displayName string, roles ...Role) (User, error) {
+	u, err := NewNamedUser(uuid, number, displayName)
+	if err != nil {
+		return User{}, err
+	}
+
+	u.roles = nil
+	for _, r := range roles {
+		if r.valid() && !u.HasRole(r) {
+			u.roles = append(u.roles, r)
+		}
+	}
+
+	return u, nil
+}
+
+// NewClientUser creates a user for a machine client, such as the SMS gateway, authenticated with an API
+// key. Clients have no number or roles and can only do what the permissions allow.
+func NewClientUser(uuid, displayName string, permissions ...Permission) (User, error) {
+	if uuid == "" {
+		return User{}, ErrorUserWithoutUUID
+	}
+
+	return User{
+		uuid:        uuid,
+		displayName: displayName,
+		permissions: permissions,
 	}, nil
 }
@@ -10,6 +10,7 @@ func newTestUser() User {
 
 	return User{
 		uuid:   id.String(),
-		number: "15734497033",
+		number: "+15734497033",
+		roles:  DefaultRoles,
 	}
 }
@@ -0,0 +1,156 @@
+package game
+
+import (
+	"fmt"
+	"github.com/rivo/uniseg"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// These are the rules a field of a game can break.
+const (
+	RuleRequired          = "required"
+	RuleMaxLength         = "max-length"
+	RuleInvalidUTF8       = "invalid-utf8"
+	RuleControlCharacters = "control-characters"
+	// RuleReservedKeyword is broken by an answer that players text for something else, e.g. STOP or HINT.
+	RuleReservedKeyword = "reserved-keyword"
+)
+
+// FieldError is a rule broken by a field of a game. Its field is a path such as levels[0].clues[1].
+type FieldError struct {
+	field string
+	rule  string
+	limit int
+}
+
+func (e FieldError) Field() string { return e.field }
+func (e FieldError) Rule() string  { return e.rule }
+
+// Limit returns the limit of the rule broken, e.g. the max length in characters, or 0 if the rule has none.
+func (e FieldError) Limit() int { return e.limit }
+
+func (e FieldError) Error() string {
+	switch e.rule {
+	case RuleRequired:
+		return fmt.Sprintf("%s is required", e.field)
+	case RuleMaxLength:
+		return fmt.Sprintf("%s is longer than %d characters", e.field, e.limit)
+	case RuleInvalidUTF8:
+		return fmt.Sprintf("%s isn't valid UTF-8", e.field)
+	case RuleControlCharacters:
+		return fmt.Sprintf("%s contains control characters", e.field)
+	case RuleReservedKeyword:
+		return fmt.Sprintf("%s is a reserved keyword", e.field)
+	default:
+		return fmt.Sprintf("%s breaks rule %s", e.field, e.rule)
+	}
+}
+
+// ValidationError is returned when the fields of a game break its rules. It lists every field error found
+// rather than only the first.
+type ValidationError struct {
+	fields []FieldError
+}
+
+// Fields returns the field errors in the order they were found.
+func (e ValidationError) Fields() []FieldError { return e.fields }
+
+func (e ValidationError) Error() string {
+	messages := make([]string, len(e.fields))
+	for i, f := range e.fields {
+		messages[i] = f.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Within returns a copy of the error with its fields nested in field, e.g. title becomes
+// translations[0].title.
+func (e ValidationError) Within(field string) ValidationError {
+	fields := make([]FieldError, len(e.fields))
+	for i, f := range e.fields {
+		f.field = fieldPath(field, f.field)
+		fields[i] = f
+	}
+
+	return ValidationError{fields: fields}
+}
+
+// fieldPath returns the path of field in parent, which may be empty.
+func fieldPath(parent, field string) string {
+	if parent == "" {
+		return field
+	}
+
+	return parent + "." + field
+}
+
+// validator collects the field errors of a game as its fields are checked.
+type validator struct {
+	fields []FieldError
+}
+
+func (v *validator) add(field, rule string, limit int) {
+	v.fields = append(v.fields, FieldError{field: field, rule: rule, limit: limit})
+}
+
+// text checks that a required single line of text is valid UTF-8 without control characters and no longer
+// than max characters.
+func (v *validator) text(field, value string, max int) {
+	v.checkText(field, value, max, false)
+}
+
+// multilineText checks text like text but allows line breaks.
+func (v *validator) multilineText(field, value string, max int) {
+	v.checkText(field, value, max, true)
+}
+
+func (v *validator) checkText(field, value string, max int, multiline bool) {
+	if value == "" {
+		v.add(field, RuleRequired, 0)
+		return
+	}
+
+	if !utf8.ValidString(value) {
+		v.add(field, RuleInvalidUTF8, 0)
+		return
+	}
+
+	for _, r := range value {
+		if unicode.IsControl(r) && !(multiline && (r == '\n' || r == '\r')) {
+			v.add(field, RuleControlCharacters, 0)
+			return
+		}
+	}
+
+	if length(value) > max {
+		v.add(field, RuleMaxLength, max)
+	}
+}
+
+// merge adds the field errors of err if it is a ValidationError. It returns false for any other error.
+func (v *validator) merge(err error) bool {
+	validationErr, ok := err.(ValidationError)
+	if ok {
+		v.fields = append(v.fields, validationErr.fields...)
+	}
+
+	return ok
+}
+
+// err returns a ValidationError with the field errors found or nil if there are none.
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+
+	return ValidationError{fields: v.fields}
+}
+
+// length returns the number of characters in s as a player would count them, which is the number of grapheme
+// clusters rather than bytes or runes, e.g. é written as e and a combining accent or 👍🏽 is one character.
+func length(s string) int {
+	return uniseg.GraphemeClusterCount(s)
+}
@@ -0,0 +1,111 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestLength(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{"ascii", "gopher", 6},
+		{"japanese", "ゴーファー", 5},
+		{"combining accent", "cafe\u0301", 4},
+		{"skin tone", "👍🏽", 1},
+		{"zero width joiner", "👩\u200d👩\u200d👧", 1},
+		{"flags", "🇺🇸🇲🇽", 2},
+		{"hangul jamo", "\u1112\u1161\u11ab", 1},
+		{"crlf", "a\r\nb", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, length(tt.s))
+		})
+	}
+}
+
+func TestNewUrbanGame_Validation(t *testing.T) {
+	creator := newTestUser()
+	level := NewLevelAdder("level title", "level description", []string{"clue"}, []string{"answer"})
+
+	t.Run("characters rather than bytes", func(t *testing.T) {
+		title := strings.Repeat("ゴ", MaxTitleLength)
+
+		g, err := NewUrbanGame(creator, title, "description", "ending", "city", "state", "country", level)
+		require.NoError(t, err)
+		assert.Equal(t, title, g.Title())
+	})
+
+	t.Run("line breaks", func(t *testing.T) {
+		_, err := NewUrbanGame(creator, "title", "line one\nline two", "ending", "city", "state", "country", level)
+		require.NoError(t, err)
+
+		_, err = NewUrbanGame(creator, "title\nsubtitle", "description", "ending", "city", "state", "country", level)
+		require.Error(t, err)
+	})
+
+	t.Run("field errors", func(t *testing.T) {
+		_, err := NewUrbanGame(creator, "", "bad \xff", strings.Repeat("e", MaxEndingLength+1), "city", "state",
+			"country",
+			level,
+			NewLevelAdder("level\ttitle", "level description", []string{"clue", ""}, []string{"answer"}),
+			NewLevelAdder("", "", nil, nil),
+		)
+
+		validationErr, ok := err.(ValidationError)
+		require.True(t, ok)
+		assert.Equal(t, []FieldError{
+			{field: "title", rule: RuleRequired},
+			{field: "description", rule: RuleInvalidUTF8},
+			{field: "ending", rule: RuleMaxLength, limit: MaxEndingLength},
+			{field: "levels[1].title", rule: RuleControlCharacters},
+			{field: "levels[1].clues[1]", rule: RuleRequired},
+		}, validationErr.Fields())
+		assert.Equal(t, "levels[1].title contains control characters", validationErr.Fields()[3].Error())
+	})
+
+	t.Run("reserved keywords", func(t *testing.T) {
+		answers := []string{"stop", "Help!", "stopping", " INFO ", "END.", "hint", "Saltar", "skipping"}
+
+		_, err := NewUrbanGame(creator, "title", "description", "ending", "city", "state", "country",
+			NewLevelAdder("level title", "level description", []string{"clue"}, answers),
+		)
+
+		validationErr, ok := err.(ValidationError)
+		require.True(t, ok)
+		assert.Equal(t, []FieldError{
+			{field: "levels[0].answers[0]", rule: RuleReservedKeyword},
+			{field: "levels[0].answers[1]", rule: RuleReservedKeyword},
+			{field: "levels[0].answers[3]", rule: RuleReservedKeyword},
+			{field: "levels[0].answers[4]", rule: RuleReservedKeyword},
+			{field: "levels[0].answers[5]", rule: RuleReservedKeyword},
+			{field: "levels[0].answers[6]", rule: RuleReservedKeyword},
+		}, validationErr.Fields())
+		assert.Equal(t, "levels[0].answers[0] is a reserved keyword", validationErr.Fields()[0].Error())
+	})
+
+	t.Run("no levels", func(t *testing.T) {
+		_, err := NewUrbanGame(creator, "title", "description", "ending", "city", "state", "country")
+
+		validationErr, ok := err.(ValidationError)
+		require.True(t, ok)
+		assert.Equal(t, []FieldError{{field: "levels", rule: RuleRequired}}, validationErr.Fields())
+	})
+}
+
+func TestValidationError_Within(t *testing.T) {
+	_, err := NewLevelTranslation("title", "", nil, nil)
+
+	validationErr, ok := err.(ValidationError)
+	require.True(t, ok)
+
+	nested := validationErr.Within("levels[2]").Within("translations[0]")
+	assert.Equal(t, "translations[0].levels[2].description is required", nested.Error())
+	assert.Equal(t, "description", validationErr.Fields()[0].Field())
+}
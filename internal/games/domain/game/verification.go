@@ -0,0 +1,150 @@
+package game
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// These are the limits imposed on verifying a player's number.
+const (
+	VerificationCodeLength = 6
+	// VerificationCodeTTL is how long a code can be used for after it was sent.
+	VerificationCodeTTL = 10 * time.Minute
+	// VerificationCodeInterval is how long a player must wait before another code is sent.
+	VerificationCodeInterval = time.Minute
+	// MaxVerificationAttempts is how many codes can be tried before a new code must be sent.
+	MaxVerificationAttempts = 5
+)
+
+var (
+	ErrorNumberAlreadyVerified = newConflictError(
+		"number has already been verified", "number-already-verified")
+	ErrorVerificationCodeTooSoon = newConflictError(
+		"a verification code was sent too recently", "verification-code-too-soon")
+	ErrorNoVerificationCode = newConflictError(
+		"no verification code has been sent", "no-verification-code")
+	ErrorVerificationCodeExpired = newConflictError(
+		"verification code has expired", "verification-code-expired")
+	ErrorTooManyVerificationAttempts = newConflictError(
+		"too many incorrect verification codes", "too-many-verification-attempts")
+	ErrorIncorrectVerificationCode = newValidationError(
+		"incorrect verification code", "incorrect-verification-code")
+)
+
+// Verification is a one-time code sent to a player's number to prove the player can receive messages
+// there. Only a hash of the code is kept.
+type Verification struct {
+	codeHash  string
+	sentAt    time.Time
+	expiresAt time.Time
+	// attempts is the number of incorrect codes tried.
+	attempts int
+}
+
+func (v Verification) CodeHash() string     { return v.codeHash }
+func (v Verification) SentAt() time.Time    { return v.sentAt }
+func (v Verification) ExpiresAt() time.Time { return v.expiresAt }
+func (v Verification) Attempts() int        { return v.attempts }
+
+// Pending reports whether a code has been sent and not yet used.
+func (v Verification) Pending() bool { return v.codeHash != "" }
+
+// NewVerificationCode returns a random code of VerificationCodeLength digits.
+func NewVerificationCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < VerificationCodeLength; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", VerificationCodeLength, n), nil
+}
+
+func hashVerificationCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// StartNumberVerification replaces any code sent before with code, which the player has been sent at.
+func (p *Player) StartNumberVerification(code string, at time.Time) error {
+	if p.NumberVerified() {
+		return ErrorNumberAlreadyVerified
+	}
+
+	if p.verification.Pending() && at.Before(p.verification.sentAt.Add(VerificationCodeInterval)) {
+		return ErrorVerificationCodeTooSoon
+	}
+
+	p.verification = Verification{
+		codeHash:  hashVerificationCode(code),
+		sentAt:    at,
+		expiresAt: at.Add(VerificationCodeTTL),
+	}
+
+	return nil
+}
+
+// VerifyNumber verifies the player's number if code is the code that was sent. Incorrect codes are
+// counted, so the player must be saved even if an error is returned.
+func (p *Player) VerifyNumber(code string, at time.Time) error {
+	if p.NumberVerified() {
+		return ErrorNumberAlreadyVerified
+	}
+
+	if !p.verification.Pending() {
+		return ErrorNoVerificationCode
+	}
+
+	if !at.Before(p.verification.expiresAt) {
+		return ErrorVerificationCodeExpired
+	}
+
+	if p.verification.attempts >= MaxVerificationAttempts {
+		return ErrorTooManyVerificationAttempts
+	}
+
+	hash := hashVerificationCode(code)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(p.verification.codeHash)) != 1 {
+		p.verification.attempts++
+		return ErrorIncorrectVerificationCode
+	}
+
+	p.numberVerifiedAt = at
+	p.verification = Verification{}
+
+	return nil
+}
+
+// BackfillNumberVerification verifies the number of a player who was playing games before numbers had to
+// be verified, since they have been getting game messages at it ever since. Players who haven't started a
+// game must verify their number. It reports whether the number was verified.
+func (p *Player) BackfillNumberVerification(at time.Time) bool {
+	if p.NumberVerified() || p.gamesStarted == 0 {
+		return false
+	}
+
+	p.numberVerifiedAt = at
+	p.verification = Verification{}
+
+	return true
+}
+
+// UnmarshalVerificationFromDatabase should only be used in repo implementations to unmarshal data from a
+// database into a domain game verification.
+func UnmarshalVerificationFromDatabase(codeHash string, sentAt, expiresAt time.Time, attempts int) Verification {
+	return Verification{
+		codeHash:  codeHash,
+		sentAt:    sentAt,
+		expiresAt: expiresAt,
+		attempts:  attempts,
+	}
+}
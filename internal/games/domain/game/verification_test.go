@@ -0,0 +1,65 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNewVerificationCode(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		code, err := NewVerificationCode()
+		require.NoError(t, err)
+		assert.Regexp(t, regexp.MustCompile(`^\d{6}$`), code)
+	}
+}
+
+func TestPlayer_VerifyNumber(t *testing.T) {
+	now := time.Now()
+
+	p, err := NewPlayerFromUser(newTestUser())
+	require.NoError(t, err)
+	assert.False(t, p.NumberVerified())
+
+	assert.Equal(t, ErrorNoVerificationCode, p.VerifyNumber("123456", now))
+
+	require.NoError(t, p.StartNumberVerification("123456", now))
+	assert.Equal(t, ErrorVerificationCodeTooSoon, p.StartNumberVerification("654321", now.Add(time.Second)))
+
+	// A new code replaces the old one.
+	later := now.Add(VerificationCodeInterval)
+	require.NoError(t, p.StartNumberVerification("654321", later))
+	assert.Equal(t, ErrorIncorrectVerificationCode, p.VerifyNumber("123456", later))
+	assert.Equal(t, 1, p.Verification().Attempts())
+
+	assert.Equal(t, ErrorVerificationCodeExpired, p.VerifyNumber("654321", later.Add(VerificationCodeTTL)))
+
+	require.NoError(t, p.VerifyNumber("654321", later.Add(time.Minute)))
+	assert.True(t, p.NumberVerified())
+	assert.Equal(t, later.Add(time.Minute), p.NumberVerifiedAt())
+	assert.False(t, p.Verification().Pending())
+
+	assert.Equal(t, ErrorNumberAlreadyVerified, p.StartNumberVerification("111111", later.Add(time.Hour)))
+	assert.Equal(t, ErrorNumberAlreadyVerified, p.VerifyNumber("654321", later.Add(time.Hour)))
+}
+
+func TestPlayer_VerifyNumberTooManyAttempts(t *testing.T) {
+	now := time.Now()
+
+	p, err := NewPlayerFromUser(newTestUser())
+	require.NoError(t, err)
+
+	require.NoError(t, p.StartNumberVerification("123456", now))
+
+	for i := 0; i < MaxVerificationAttempts; i++ {
+		assert.Equal(t, ErrorIncorrectVerificationCode, p.VerifyNumber("000000", now))
+	}
+
+	// Even the right code is rejected until a new code is sent.
+	assert.Equal(t, ErrorTooManyVerificationAttempts, p.VerifyNumber("123456", now))
+
+	require.NoError(t, p.StartNumberVerification("222222", now.Add(VerificationCodeInterval)))
+	require.NoError(t, p.VerifyNumber("222222", now.Add(VerificationCodeInterval)))
+}
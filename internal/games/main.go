@@ -3,17 +3,24 @@ package main
 
 import (
 	"context"
-	"github.com/go-chi/chi"
+	"crypto/rand"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
 	"gopher-cache/internal/common/emulators"
+	"gopher-cache/internal/common/genproto/games"
 	"gopher-cache/internal/common/logs"
 	"gopher-cache/internal/common/server"
 	"gopher-cache/internal/games/adapters"
 	"gopher-cache/internal/games/app"
 	"gopher-cache/internal/games/app/command"
 	"gopher-cache/internal/games/app/query"
+	"gopher-cache/internal/games/domain/game"
 	"gopher-cache/internal/games/ports"
 	"net/http"
+	"os"
+	"time"
 )
 
 func init() {
@@ -23,17 +30,30 @@ func init() {
 func main() {
 	ctx := context.Background()
 
-	application, cleanup := newLocalApplication(ctx)
+	application, apiKeyRepository, cleanup := newLocalApplication(ctx)
 	defer cleanup()
 
+	if os.Getenv("GRPC_PORT") != "" {
+		logrus.Info("Starting gRPC server")
+
+		go server.RunGRPCServer(ctx, apiKeyRepository, func(srv *grpc.Server) {
+			games.RegisterGamesServiceServer(srv, ports.NewGrpcServer(application, ports.WatchStateConfig{
+				Interval:    time.Second,
+				MaxInterval: 15 * time.Second,
+				MaxDuration: 10 * time.Minute,
+				MaxWatchers: 1000,
+			}))
+		})
+	}
+
 	logrus.Info("Starting HTTP server")
 
-	server.RunHTTPServer(ctx, func(router chi.Router) http.Handler {
+	server.RunHTTPServer(ctx, apiKeyRepository, ports.APIDocsHandler, func(router chi.Router) http.Handler {
 		return ports.APIHandler(ports.NewHTTPServer(application), router)
 	})
 }
 
-func newLocalApplication(ctx context.Context) (app.Application, func()) {
+func newLocalApplication(ctx context.Context) (app.Application, adapters.FirestoreAPIKeyRepository, func()) {
 	client, cleanup := emulators.NewFirestoreClient(ctx)
 
 	gamesRepository, err := adapters.NewFirestoreGameRepository(client)
@@ -41,19 +61,129 @@ func newLocalApplication(ctx context.Context) (app.Application, func()) {
 		panic(err)
 	}
 
+	projectionRepository, err := adapters.NewFirestoreProjectionRepository(client)
+	if err != nil {
+		panic(err)
+	}
+
+	apiKeyRepository, err := adapters.NewFirestoreAPIKeyRepository(client)
+	if err != nil {
+		panic(err)
+	}
+
+	// Each instance keeps its own search index in memory, filled and kept up to date with the game
+	// projections saved by every instance.
+	searchIndex := adapters.NewMemorySearchIndex()
+	go syncSearchIndex(ctx, projectionRepository, searchIndex)
+
+	projector := query.NewProjector(projectionRepository)
+	cursors := query.NewCursorSigner(cursorSigningKey())
+	getGames := query.NewReadGamesHandler(projectionRepository, cursors)
+
 	return app.Application{
 			Commands: app.Commands{
-				CreateGame:      command.NewCreateGameHandler(gamesRepository),
-				CreateGameState: command.NewCreateGameStateHandler(gamesRepository),
-				UpdateGameState: command.NewUpdateGameStateHandler(gamesRepository),
+				CreateGame:      command.NewCreateGameHandler(gamesRepository, projector, adapters.NewRuleModerator()),
+				CreateGameState: command.NewCreateGameStateHandler(gamesRepository, projector),
+				UpdateGameState: command.NewUpdateGameStateHandler(gamesRepository, projector),
+				RateGame:        command.NewRateGameHandler(gamesRepository, projector),
+				ReportGame:      command.NewReportGameHandler(gamesRepository, projector),
+				ModerateGame:    command.NewModerateGameHandler(gamesRepository, projector),
+
+				HandleTextMessage: command.NewTextMessageHandler(gamesRepository, projector, getGames, textCommandAliases()),
+				RequestClue:       command.NewRequestClueHandler(gamesRepository, projector),
+
+				RequestNumberVerification: command.NewRequestNumberVerificationHandler(gamesRepository, adapters.NewLogSMSSender()),
+				VerifyNumber:              command.NewVerifyNumberHandler(gamesRepository),
+				SetPlayerLocale:           command.NewSetPlayerLocaleHandler(gamesRepository),
+				MigratePlayerNumbers:      command.NewMigratePlayerNumbersHandler(gamesRepository),
+
+				RebuildProjections: command.NewRebuildProjectionsHandler(gamesRepository, projector),
+
+				CreateAPIKey: command.NewCreateAPIKeyHandler(apiKeyRepository),
+				RevokeAPIKey: command.NewRevokeAPIKeyHandler(apiKeyRepository),
 			},
 			Queries: app.Queries{
-				GetGames:  query.NewReadGamesHandler(gamesRepository),
-				GetPlayer: query.NewReadPlayerHandler(gamesRepository),
-				GetState:  query.NewReadStateHandler(gamesRepository),
+				GetGame:     query.NewReadGameHandler(projectionRepository),
+				GetGames:    getGames,
+				SearchGames: query.NewSearchGamesHandler(searchIndex, cursors),
+				GetPlayer:   query.NewReadPlayerHandler(projectionRepository),
+				GetState:    query.NewReadStateHandler(projectionRepository),
+
+				GetProjectionLag: query.NewReadProjectionLagHandler(projectionRepository),
+				GetGameAnalytics: query.NewReadGameAnalyticsHandler(projectionRepository),
+				GetGameReviews:   query.NewReadGameReviewsHandler(projectionRepository, cursors),
+
+				GetModerationQueue: query.NewReadModerationQueueHandler(projectionRepository, cursors),
+
+				GetAPIKeys: query.NewReadAPIKeysHandler(apiKeyRepository),
 			},
-		}, func() {
+		}, apiKeyRepository, func() {
 			_ = client.Close()
 			cleanup()
 		}
 }
+
+// searchIndexRetryInterval is how long to wait before following the game projections again if it fails.
+const searchIndexRetryInterval = 5 * time.Second
+
+// syncSearchIndex keeps the search index in sync with the game projections until ctx is done.
+func syncSearchIndex(ctx context.Context, watcher query.GameProjectionWatcher, index query.GameSearchIndex) {
+	for {
+		err := query.SyncGameSearchIndex(ctx, watcher, index)
+		if ctx.Err() != nil {
+			return
+		}
+
+		logrus.WithError(err).Warn("Syncing the search index failed")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(searchIndexRetryInterval):
+		}
+	}
+}
+
+// cursorSigningKey returns the key pagination cursors are signed with. If CURSOR_SIGNING_KEY isn't set a
+// random key is used so cursors stop working when the service restarts.
+func cursorSigningKey() []byte {
+	if key := os.Getenv("CURSOR_SIGNING_KEY"); key != "" {
+		return []byte(key)
+	}
+
+	logrus.Warn("CURSOR_SIGNING_KEY is not set, using a random key")
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+
+	return key
+}
+
+// textCommandAliases returns the default text command aliases. TEXT_COMMAND_ALIASES may replace the aliases
+// of a language or add another, e.g. {"de": {"TIPP": "hint"}}.
+func textCommandAliases() game.TextCommandAliases {
+	languages := make(map[string]map[string]game.TextCommand)
+	for language, aliases := range game.DefaultTextCommandAliases {
+		languages[language] = aliases
+	}
+
+	if config := os.Getenv("TEXT_COMMAND_ALIASES"); config != "" {
+		var configured map[string]map[string]game.TextCommand
+		if err := json.Unmarshal([]byte(config), &configured); err != nil {
+			panic(err)
+		}
+
+		for language, aliases := range configured {
+			languages[language] = aliases
+		}
+	}
+
+	aliases, err := game.NewTextCommandAliases(languages)
+	if err != nil {
+		panic(err)
+	}
+
+	return aliases
+}
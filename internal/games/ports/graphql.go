@@ -0,0 +1,1003 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"github.com/go-chi/render"
+	"github.com/graph-gophers/dataloader"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"gopher-cache/internal/common/errors"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/common/server/httperr"
+	"gopher-cache/internal/games/app"
+	"gopher-cache/internal/games/app/command"
+	"gopher-cache/internal/games/app/query"
+	"gopher-cache/internal/games/domain/game"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// graphQLMaxDepth is how deeply the selections of an operation may be nested, which is enough to get a
+	// player's history with the state and game of each entry.
+	graphQLMaxDepth = 8
+	// graphQLMaxComplexity is the greatest cost of an operation, where every field costs 1 and the fields of
+	// lists cost as much as the most objects the list may have.
+	graphQLMaxComplexity = 1000
+	// graphQLMaxParallelism is how many fields are resolved at once, which is enough for the objects of a
+	// list to be resolved together so their states and games are loaded in one batch.
+	graphQLMaxParallelism = 100
+)
+
+// The codes in the extensions of errors. Errors of requests that can't be executed have
+// graphQLCodeInvalidRequest. Errors returned by resolvers get the code of their ErrorType, the same way as
+// httperr.RespondWithSlugError picks an HTTP status.
+const (
+	graphQLCodeInvalidRequest  = "INVALID_REQUEST"
+	graphQLCodeUnauthenticated = "UNAUTHENTICATED"
+	graphQLCodeForbidden       = "FORBIDDEN"
+	graphQLCodeBadUserInput    = "BAD_USER_INPUT"
+	graphQLCodeNotFound        = "NOT_FOUND"
+	graphQLCodeConflict        = "CONFLICT"
+	graphQLCodeInternal        = "INTERNAL_SERVER_ERROR"
+)
+
+// QueryGraphQL runs the GraphQL query in the query params. Mutations must be sent to ExecuteGraphQL.
+func (h HTTPServer) QueryGraphQL(w http.ResponseWriter, r *http.Request, params QueryGraphQLParams) {
+	req := graphQLRequest{Query: params.Query, OperationName: stringValue(params.OperationName)}
+
+	if params.Variables != nil {
+		if err := json.Unmarshal([]byte(*params.Variables), &req.Variables); err != nil {
+			httperr.BadRequest("invalid-graphql-variables", err, w, r)
+			return
+		}
+	}
+
+	h.graphQL.serve(w, r, req, false)
+}
+
+// ExecuteGraphQL runs the GraphQL query or mutation in the body of the request, which is JSON in the form of
+// GraphQLRequest.
+func (h HTTPServer) ExecuteGraphQL(w http.ResponseWriter, r *http.Request) {
+	body := new(GraphQLRequest)
+
+	if err := render.Decode(r, body); err != nil {
+		httperr.BadRequest("invalid-request-body", err, w, r)
+		return
+	}
+
+	req := graphQLRequest{Query: body.Query, OperationName: stringValue(body.OperationName)}
+	if body.Variables != nil {
+		req.Variables = *body.Variables
+	}
+
+	h.graphQL.serve(w, r, req, true)
+}
+
+// GetGraphQLSchema serves the schema of the GraphQL API in the schema definition language.
+func (h HTTPServer) GetGraphQLSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(strings.TrimPrefix(graphQLSchema, "\n")))
+}
+
+type graphQLRequest struct {
+	Query         string
+	OperationName string
+	Variables     map[string]interface{}
+}
+
+// graphQLServer runs the operations of the GraphQL API. Like the other APIs it maps them to the
+// application's commands and queries.
+type graphQLServer struct {
+	app    app.Application
+	schema *graphql.Schema
+	// analysis is the same schema loaded by gqlparser, whose syntax tree of an operation is used to limit its
+	// depth and complexity before it runs.
+	analysis *ast.Schema
+}
+
+func newGraphQLServer(app app.Application) graphQLServer {
+	return graphQLServer{
+		app: app,
+		schema: graphql.MustParseSchema(
+			graphQLSchema,
+			&graphQLResolver{app: app},
+			graphql.UseStringDescriptions(),
+			graphql.MaxParallelism(graphQLMaxParallelism),
+			graphql.PanicHandler(graphQLPanicHandler{}),
+		),
+		analysis: gqlparser.MustLoadSchema(&ast.Source{Name: "schema.graphql", Input: graphQLSchema}),
+	}
+}
+
+// serve responds with the result of req, which may only be a mutation if allowMutations. Responses have
+// status 200 since their errors are in the response.
+func (s graphQLServer) serve(w http.ResponseWriter, r *http.Request, req graphQLRequest, allowMutations bool) {
+	ctx := context.WithValue(r.Context(), acceptLanguageKey{}, r.Header.Get("Accept-Language"))
+
+	resp := s.execute(ctx, req, allowMutations)
+
+	for _, err := range resp.Errors {
+		if err.ResolverError != nil && err.Extensions["code"] == graphQLCodeInternal {
+			logs.GetLogEntry(r).WithError(err.ResolverError).WithField("graphql-path", err.Path).Warn("Internal server error")
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s graphQLServer) execute(ctx context.Context, req graphQLRequest, allowMutations bool) *graphql.Response {
+	doc, errs := gqlparser.LoadQuery(s.analysis, req.Query)
+	if len(errs) > 0 {
+		resp := &graphql.Response{}
+		for _, err := range errs {
+			resp.Errors = append(resp.Errors, invalidGraphQLRequest(err.Message, err.Locations...))
+		}
+		return resp
+	}
+
+	op := doc.Operations.ForName(req.OperationName)
+	if op == nil {
+		return invalidGraphQLResponse("the operation %q isn't in the document or the document has more than one operation", req.OperationName)
+	}
+
+	if op.Operation == ast.Mutation && !allowMutations {
+		return invalidGraphQLResponse("mutations must be sent in a POST request")
+	}
+
+	if depth := selectionDepth(op.SelectionSet); depth > graphQLMaxDepth {
+		return invalidGraphQLResponse("the operation has depth %d, which is more than the limit of %d", depth, graphQLMaxDepth)
+	}
+
+	if complexity := selectionComplexity(op.SelectionSet, req.Variables); complexity > graphQLMaxComplexity {
+		return invalidGraphQLResponse("the operation has complexity %d, which is more than the limit of %d", complexity, graphQLMaxComplexity)
+	}
+
+	ctx = context.WithValue(ctx, graphQLLoadersKey{}, newGraphQLLoaders(s.app))
+
+	resp := s.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	for _, err := range resp.Errors {
+		if err.ResolverError == nil {
+			err.Extensions = map[string]interface{}{"code": graphQLCodeInvalidRequest}
+		} else {
+			presentResolverError(err)
+		}
+	}
+
+	return resp
+}
+
+func invalidGraphQLRequest(message string, locations ...gqlerror.Location) *gqlerrors.QueryError {
+	err := &gqlerrors.QueryError{
+		Message:    message,
+		Extensions: map[string]interface{}{"code": graphQLCodeInvalidRequest},
+	}
+
+	for _, loc := range locations {
+		err.Locations = append(err.Locations, gqlerrors.Location{Line: loc.Line, Column: loc.Column})
+	}
+
+	return err
+}
+
+func invalidGraphQLResponse(format string, args ...interface{}) *graphql.Response {
+	return &graphql.Response{Errors: []*gqlerrors.QueryError{invalidGraphQLRequest(fmt.Sprintf(format, args...))}}
+}
+
+// presentResolverError sets the message and extensions of an error returned by a resolver. Its extensions
+// have the code, slug and fields of a SlugError. The messages of other errors aren't sent since they may
+// give away details of the server.
+func presentResolverError(err *gqlerrors.QueryError) {
+	err.Message = "internal server error"
+	err.Extensions = map[string]interface{}{"code": graphQLCodeInternal, "slug": "internal-server-error"}
+
+	var slugError errors.SlugError
+	if !stderrors.As(err.ResolverError, &slugError) {
+		return
+	}
+
+	err.Extensions["slug"] = slugError.Slug()
+	if fields := slugError.Fields(); len(fields) > 0 {
+		err.Extensions["fields"] = fields
+	}
+
+	switch slugError.ErrorType() {
+	case errors.ErrorTypeAuthentication:
+		err.Extensions["code"] = graphQLCodeUnauthenticated
+	case errors.ErrorTypeAuthorization:
+		err.Extensions["code"] = graphQLCodeForbidden
+	case errors.ErrorTypeIncorrectInput:
+		err.Extensions["code"] = graphQLCodeBadUserInput
+	case errors.ErrorTypeNotFound:
+		err.Extensions["code"] = graphQLCodeNotFound
+	case errors.ErrorTypeConflict:
+		err.Extensions["code"] = graphQLCodeConflict
+	default:
+		return
+	}
+
+	err.Message = slugError.Error()
+}
+
+// graphQLPanicHandler turns a panic in a resolver into an internal server error, which is logged.
+type graphQLPanicHandler struct{}
+
+func (graphQLPanicHandler) MakePanicError(_ context.Context, value interface{}) *gqlerrors.QueryError {
+	return &gqlerrors.QueryError{ResolverError: fmt.Errorf("panic: %v", value)}
+}
+
+// selectionDepth is how deeply the fields of sels are nested. The fields of introspection are left out since
+// they are limited by the schema.
+func selectionDepth(sels ast.SelectionSet) int {
+	depth := 0
+
+	for _, sel := range sels {
+		var d int
+
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if isIntrospectionField(sel) {
+				continue
+			}
+			d = 1 + selectionDepth(sel.SelectionSet)
+		case *ast.InlineFragment:
+			d = selectionDepth(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			d = selectionDepth(sel.Definition.SelectionSet)
+		}
+
+		if d > depth {
+			depth = d
+		}
+	}
+
+	return depth
+}
+
+// graphQLListComplexity is the complexity of the fields of lists by their type and name, e.g.
+// Player.history. Every other field costs 1 plus the complexity of its selections.
+var graphQLListComplexity = map[string]func(args map[string]interface{}, childComplexity int) int{
+	"Player.history": limitComplexity,
+	"Query.games": func(args map[string]interface{}, childComplexity int) int {
+		// Only the games of the page are a list.
+		return 1 + limitComplexity(args, childComplexity)
+	},
+}
+
+// selectionComplexity is the cost of the fields of sels with the variables of the operation. The fields of
+// introspection are left out since they are limited by the schema.
+func selectionComplexity(sels ast.SelectionSet, variables map[string]interface{}) int {
+	complexity := 0
+
+	for _, sel := range sels {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if isIntrospectionField(sel) {
+				continue
+			}
+
+			childComplexity := selectionComplexity(sel.SelectionSet, variables)
+
+			if fn, ok := graphQLListComplexity[sel.ObjectDefinition.Name+"."+sel.Name]; ok {
+				complexity += fn(sel.ArgumentMap(variables), childComplexity)
+			} else {
+				complexity += 1 + childComplexity
+			}
+		case *ast.InlineFragment:
+			complexity += selectionComplexity(sel.SelectionSet, variables)
+		case *ast.FragmentSpread:
+			complexity += selectionComplexity(sel.Definition.SelectionSet, variables)
+		}
+	}
+
+	return complexity
+}
+
+func isIntrospectionField(f *ast.Field) bool {
+	return strings.HasPrefix(f.Name, "__")
+}
+
+// limitComplexity is the complexity of a list field with a limit argument, which is the most objects it
+// may have.
+func limitComplexity(args map[string]interface{}, childComplexity int) int {
+	var limit int
+	switch l := args["limit"].(type) {
+	case int64:
+		limit = int(l)
+	case float64:
+		limit = int(l)
+	}
+
+	if limit < 1 {
+		limit = 1
+	}
+
+	return limit * (1 + childComplexity)
+}
+
+type acceptLanguageKey struct{}
+
+// acceptLanguageFromGraphQLContext returns the Accept-Language header of the GraphQL request.
+func acceptLanguageFromGraphQLContext(ctx context.Context) string {
+	acceptLanguage, _ := ctx.Value(acceptLanguageKey{}).(string)
+	return acceptLanguage
+}
+
+// graphQLLoaders batch the reads of the games and states of an operation, so e.g. the games of a player's
+// history are read together rather than one by one. They are made for each operation since they cache
+// what they read.
+type graphQLLoaders struct {
+	games  *dataloader.Loader
+	states *dataloader.Loader
+}
+
+type graphQLLoadersKey struct{}
+
+func newGraphQLLoaders(app app.Application) graphQLLoaders {
+	return graphQLLoaders{
+		games: dataloader.NewBatchedLoader(func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+			user, err := gameUserFromContext(ctx)
+			if err != nil {
+				return batchResults(len(keys), err, nil)
+			}
+
+			details, err := app.Queries.GetGame.HandleBatch(ctx, user, keys.Keys())
+
+			return batchResults(len(keys), err, func(i int) interface{} { return details[i] })
+		}),
+		states: dataloader.NewBatchedLoader(func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+			user, err := gameUserFromContext(ctx)
+			if err != nil {
+				return batchResults(len(keys), err, nil)
+			}
+
+			states, err := app.Queries.GetState.HandleBatch(ctx, user, keys.Keys())
+
+			return batchResults(len(keys), err, func(i int) interface{} { return states[i] })
+		}),
+	}
+}
+
+// batchResults returns the results of a batch of n keys, which all have err if it isn't nil.
+func batchResults(n int, err error, value func(i int) interface{}) []*dataloader.Result {
+	results := make([]*dataloader.Result, n)
+	for i := range results {
+		if err != nil {
+			results[i] = &dataloader.Result{Error: err}
+		} else {
+			results[i] = &dataloader.Result{Data: value(i)}
+		}
+	}
+
+	return results
+}
+
+// loadGame returns the game with uuid, which is nil if it doesn't exist or isn't published.
+func loadGame(ctx context.Context, uuid string) (*gameResolver, error) {
+	loaders := ctx.Value(graphQLLoadersKey{}).(graphQLLoaders)
+
+	v, err := loaders.games.Load(ctx, dataloader.StringKey(uuid))()
+	if err != nil {
+		return nil, err
+	}
+
+	detail := v.(*query.GameDetail)
+	if detail == nil {
+		return nil, nil
+	}
+
+	return &gameResolver{&detail.Game}, nil
+}
+
+// loadState returns the state with uuid, which is nil if it doesn't exist or the user may not read it.
+func loadState(ctx context.Context, uuid string) (*stateResolver, error) {
+	loaders := ctx.Value(graphQLLoadersKey{}).(graphQLLoaders)
+
+	v, err := loaders.states.Load(ctx, dataloader.StringKey(uuid))()
+	if err != nil {
+		return nil, err
+	}
+
+	state := v.(*query.State)
+	if state == nil {
+		return nil, nil
+	}
+
+	return &stateResolver{state}, nil
+}
+
+// dateTime is the DateTime scalar.
+type dateTime struct {
+	time.Time
+}
+
+func (dateTime) ImplementsGraphQLType(name string) bool {
+	return name == "DateTime"
+}
+
+func (t *dateTime) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("%T isn't a DateTime", input)
+	}
+
+	var err error
+	t.Time, err = time.Parse(time.RFC3339Nano, s)
+
+	return err
+}
+
+func (t dateTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Format(time.RFC3339Nano))
+}
+
+// graphQLResolver resolves the fields of Query and Mutation with the application.
+type graphQLResolver struct {
+	app app.Application
+}
+
+func (r graphQLResolver) Game(ctx context.Context, args struct{ UUID graphql.ID }) (*gameResolver, error) {
+	user, err := gameUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := r.app.Queries.GetGame.Handle(ctx, user, string(args.UUID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gameResolver{&detail.Game}, nil
+}
+
+type gamesArgs struct {
+	Limit   int32
+	Cursor  *string
+	Filters *[]struct {
+		Key   string
+		Value string
+	}
+	Sort *string
+	Near *struct {
+		Latitude  float64
+		Longitude float64
+		RadiusKm  float64
+	}
+}
+
+func (r graphQLResolver) Games(ctx context.Context, args gamesArgs) (*gamesPageResolver, error) {
+	user, err := gameUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string][]string{}
+	if args.Filters != nil {
+		for _, f := range *args.Filters {
+			values[f.Key] = append(values[f.Key], f.Value)
+		}
+	}
+	if args.Sort != nil && *args.Sort != "" {
+		values["sort"] = []string{*args.Sort}
+	}
+
+	q, err := query.ParseGameQuery(values)
+	if err != nil {
+		return nil, err
+	}
+
+	params := query.PageParams{Limit: int(args.Limit), Cursor: stringValue(args.Cursor)}
+
+	var page *query.GamesPage
+	if args.Near != nil {
+		page, err = r.app.Queries.GetGames.HandleNear(ctx, user, query.Near{
+			Latitude:  args.Near.Latitude,
+			Longitude: args.Near.Longitude,
+			RadiusKm:  args.Near.RadiusKm,
+		}, q, params)
+	} else {
+		page, err = r.app.Queries.GetGames.Handle(ctx, user, q, params)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &gamesPageResolver{page}, nil
+}
+
+func (r graphQLResolver) Player(ctx context.Context, args struct{ UUID graphql.ID }) (*playerResolver, error) {
+	user, err := gameUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := r.app.Queries.GetPlayer.Handle(ctx, user, string(args.UUID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &playerResolver{player}, nil
+}
+
+func (r graphQLResolver) Me(ctx context.Context) (*playerResolver, error) {
+	user, err := gameUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := r.app.Queries.GetPlayer.Handle(ctx, user, user.UUID())
+	if stderrors.Is(err, query.ErrorPlayerNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &playerResolver{player}, nil
+}
+
+func (r graphQLResolver) State(ctx context.Context, args struct{ UUID graphql.ID }) (*stateResolver, error) {
+	user, err := gameUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := r.app.Queries.GetState.Handle(ctx, user, string(args.UUID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &stateResolver{state}, nil
+}
+
+// createGameInput is the CreateGameInput of the createGame mutation.
+type createGameInput struct {
+	Title       string
+	Description string
+	Levels      []struct {
+		Title       string
+		Description string
+		Clues       *[]string
+		Answers     []string
+		Location    *locationInput
+	}
+	Ending   string
+	Kind     string
+	City     *string
+	State    *string
+	Country  *string
+	Location *locationInput
+	Locale   *string
+	Clues    *struct {
+		WrongAnswersRevealClues *bool
+		CooldownSeconds         *int32
+	}
+	Translations *[]struct {
+		Locale      string
+		Title       string
+		Description string
+		Ending      string
+		Levels      []struct {
+			Title       string
+			Description string
+			Clues       []string
+			Answers     *[]string
+		}
+	}
+}
+
+type locationInput struct {
+	Latitude  float64
+	Longitude float64
+}
+
+func (l *locationInput) location() *Location {
+	if l == nil {
+		return nil
+	}
+
+	return &Location{Latitude: l.Latitude, Longitude: l.Longitude}
+}
+
+// createGame returns the input as a CreateGame, since it has the same fields, so it is mapped to a command
+// the same way as the body of a request.
+func (in createGameInput) createGame() CreateGame {
+	body := CreateGame{
+		Title:       in.Title,
+		Description: in.Description,
+		Levels:      make([]GameLevel, len(in.Levels)),
+		Ending:      in.Ending,
+		Kind:        CreateGameKind(in.Kind),
+		City:        in.City,
+		State:       in.State,
+		Country:     in.Country,
+		Location:    in.Location.location(),
+		Locale:      in.Locale,
+	}
+
+	for i, l := range in.Levels {
+		body.Levels[i] = GameLevel{
+			Title:       l.Title,
+			Description: l.Description,
+			Clues:       l.Clues,
+			Answers:     l.Answers,
+			Location:    l.Location.location(),
+		}
+	}
+
+	if in.Clues != nil {
+		body.Clues = &ClueSettings{WrongAnswersRevealClues: in.Clues.WrongAnswersRevealClues}
+		if in.Clues.CooldownSeconds != nil {
+			cooldown := int(*in.Clues.CooldownSeconds)
+			body.Clues.CooldownSeconds = &cooldown
+		}
+	}
+
+	if in.Translations != nil {
+		translations := make([]GameTranslation, len(*in.Translations))
+		for i, t := range *in.Translations {
+			translations[i] = GameTranslation{
+				Locale:      t.Locale,
+				Title:       t.Title,
+				Description: t.Description,
+				Ending:      t.Ending,
+				Levels:      make([]LevelTranslation, len(t.Levels)),
+			}
+
+			for j, l := range t.Levels {
+				translations[i].Levels[j] = LevelTranslation{
+					Title:       l.Title,
+					Description: l.Description,
+					Clues:       l.Clues,
+					Answers:     l.Answers,
+				}
+			}
+		}
+		body.Translations = &translations
+	}
+
+	return body
+}
+
+func (r graphQLResolver) CreateGame(ctx context.Context, args struct{ Input createGameInput }) (bool, error) {
+	gameUser, err := gameUserFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	cmd := createGameCommand(args.Input.createGame())
+
+	cmd.Creator = gameUser
+
+	return r.handled(r.app.Commands.CreateGame.Handle(ctx, cmd))
+}
+
+func (r graphQLResolver) CreateGameState(ctx context.Context, args struct{ GameUUID graphql.ID }) (*responseResolver, error) {
+	gameUser, err := gameUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := createGameStateCommand(CreateGameState{GameUUID: string(args.GameUUID)})
+
+	cmd.User = gameUser
+	cmd.AcceptLanguage = acceptLanguageFromGraphQLContext(ctx)
+
+	return r.response(r.app.Commands.CreateGameState.Handle(ctx, cmd))
+}
+
+func (r graphQLResolver) UpdateGameState(ctx context.Context, args struct {
+	PlayerNumber string
+	Input        string
+}) (*responseResolver, error) {
+	user, err := gameUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := updateGameStateCommand(UpdateGameState{Input: args.Input})
+
+	cmd.User = user
+	cmd.PlayerNumber = args.PlayerNumber
+	cmd.AcceptLanguage = acceptLanguageFromGraphQLContext(ctx)
+
+	return r.response(r.app.Commands.HandleTextMessage.Handle(ctx, cmd))
+}
+
+func (r graphQLResolver) RequestClue(ctx context.Context, args struct{ PlayerNumber string }) (*responseResolver, error) {
+	user, err := gameUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.response(r.app.Commands.RequestClue.Handle(ctx, command.RequestClue{
+		User:           user,
+		PlayerNumber:   args.PlayerNumber,
+		AcceptLanguage: acceptLanguageFromGraphQLContext(ctx),
+	}))
+}
+
+func (r graphQLResolver) RateGame(ctx context.Context, args struct {
+	GameUUID graphql.ID
+	Stars    int32
+	Review   *string
+}) (bool, error) {
+	rater, err := gameUserFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	cmd := rateGameCommand(RateGame{Stars: int(args.Stars), Review: args.Review})
+
+	cmd.Rater = rater
+	cmd.GameUUID = string(args.GameUUID)
+
+	return r.handled(r.app.Commands.RateGame.Handle(ctx, cmd))
+}
+
+func (r graphQLResolver) ReportGame(ctx context.Context, args struct {
+	GameUUID graphql.ID
+	Reason   string
+	Details  *string
+}) (bool, error) {
+	reporter, err := gameUserFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	cmd := reportGameCommand(ReportGame{Reason: ReportGameReason(args.Reason), Details: args.Details})
+
+	cmd.Reporter = reporter
+	cmd.GameUUID = string(args.GameUUID)
+
+	return r.handled(r.app.Commands.ReportGame.Handle(ctx, cmd))
+}
+
+func (r graphQLResolver) ModerateGame(ctx context.Context, args struct {
+	GameUUID graphql.ID
+	Publish  bool
+}) (bool, error) {
+	moderator, err := gameUserFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	cmd := moderateGameCommand(ModerateGame{Publish: args.Publish})
+
+	cmd.Moderator = moderator
+	cmd.GameUUID = string(args.GameUUID)
+
+	return r.handled(r.app.Commands.ModerateGame.Handle(ctx, cmd))
+}
+
+func (r graphQLResolver) SetPlayerLocale(ctx context.Context, args struct{ Locale string }) (bool, error) {
+	user, err := gameUserFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	cmd := setPlayerLocaleCommand(SetPlayerLocale{Locale: args.Locale})
+
+	cmd.User = user
+
+	return r.handled(r.app.Commands.SetPlayerLocale.Handle(ctx, cmd))
+}
+
+func (r graphQLResolver) RequestNumberVerification(ctx context.Context) (bool, error) {
+	user, err := gameUserFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return r.handled(r.app.Commands.RequestNumberVerification.Handle(ctx, command.RequestNumberVerification{
+		User:           user,
+		AcceptLanguage: acceptLanguageFromGraphQLContext(ctx),
+	}))
+}
+
+func (r graphQLResolver) VerifyNumber(ctx context.Context, args struct{ Code string }) (bool, error) {
+	user, err := gameUserFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	cmd := verifyNumberCommand(VerifyNumber{Code: args.Code})
+
+	cmd.User = user
+
+	return r.handled(r.app.Commands.VerifyNumber.Handle(ctx, cmd))
+}
+
+// handled is the result of a mutation whose command returns nothing, which is true if it succeeded.
+func (r graphQLResolver) handled(err error) (bool, error) {
+	return err == nil, err
+}
+
+// response is the result of a mutation whose command returns the response of a game.
+func (r graphQLResolver) response(resp *game.Response, err error) (*responseResolver, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return &responseResolver{resp}, nil
+}
+
+type gameResolver struct {
+	g *query.Game
+}
+
+func (r gameResolver) UUID() graphql.ID                  { return graphql.ID(r.g.UUID) }
+func (r gameResolver) CreatorName() string               { return r.g.CreatorName }
+func (r gameResolver) Title() string                     { return r.g.Title }
+func (r gameResolver) Description() string               { return r.g.Description }
+func (r gameResolver) Kind() string                      { return r.g.Kind }
+func (r gameResolver) City() string                      { return r.g.City }
+func (r gameResolver) State() string                     { return r.g.State }
+func (r gameResolver) Country() string                   { return r.g.Country }
+func (r gameResolver) LevelCount() int32                 { return int32(r.g.Levels) }
+func (r gameResolver) Value() int32                      { return int32(r.g.Value) }
+func (r gameResolver) PlayCount() int32                  { return int32(r.g.PlayCount) }
+func (r gameResolver) CompletionCount() int32            { return int32(r.g.CompletionCount) }
+func (r gameResolver) AverageCompletionSeconds() float64 { return r.g.AverageCompletionSeconds }
+func (r gameResolver) RatingCount() int32                { return int32(r.g.RatingCount) }
+func (r gameResolver) AverageRating() float64            { return r.g.AverageRating }
+func (r gameResolver) CreatedAt() dateTime               { return dateTime{r.g.CreatedAt} }
+func (r gameResolver) Status() string                    { return r.g.Status }
+func (r gameResolver) DistanceKm() *float64              { return r.g.DistanceKm }
+
+func (r gameResolver) Location() *locationResolver {
+	if r.g.Location == nil {
+		return nil
+	}
+
+	return &locationResolver{r.g.Location}
+}
+
+type locationResolver struct {
+	l *query.Location
+}
+
+func (r locationResolver) Latitude() float64  { return r.l.Latitude }
+func (r locationResolver) Longitude() float64 { return r.l.Longitude }
+
+type gamesPageResolver struct {
+	page *query.GamesPage
+}
+
+func (r gamesPageResolver) Games() []*gameResolver {
+	games := make([]*gameResolver, len(r.page.Games))
+	for i, g := range r.page.Games {
+		games[i] = &gameResolver{g}
+	}
+
+	return games
+}
+
+func (r gamesPageResolver) NextCursor() *string {
+	if r.page.NextCursor == "" {
+		return nil
+	}
+
+	return &r.page.NextCursor
+}
+
+type levelResolver struct {
+	l *query.Level
+}
+
+func (r levelResolver) Number() int32       { return int32(r.l.Number) }
+func (r levelResolver) Title() string       { return r.l.Title }
+func (r levelResolver) Description() string { return r.l.Description }
+
+type responseResolver struct {
+	resp *game.Response
+}
+
+func (r responseResolver) Kind() string             { return string(r.resp.Kind) }
+func (r responseResolver) LevelTitle() string       { return r.resp.LevelTitle }
+func (r responseResolver) LevelDescription() string { return r.resp.LevelDescription }
+func (r responseResolver) Clue() string             { return r.resp.Clue }
+func (r responseResolver) EndMessage() string       { return r.resp.EndMessage }
+func (r responseResolver) Message() string          { return r.resp.Message }
+
+type stateResolver struct {
+	s *query.State
+}
+
+func (r stateResolver) UUID() graphql.ID       { return graphql.ID(r.s.UUID) }
+func (r stateResolver) PlayerUUID() graphql.ID { return graphql.ID(r.s.PlayerUUID) }
+func (r stateResolver) GameUUID() graphql.ID   { return graphql.ID(r.s.GameUUID) }
+func (r stateResolver) GameLevels() int32      { return int32(r.s.GameLevels) }
+func (r stateResolver) Level() int32           { return int32(r.s.Level) }
+func (r stateResolver) Completed() bool        { return r.s.Completed }
+func (r stateResolver) Quit() bool             { return r.s.Quit }
+func (r stateResolver) UpdatedAt() dateTime    { return dateTime{r.s.UpdatedAt} }
+
+func (r stateResolver) CurrentResponse() *responseResolver {
+	return &responseResolver{&r.s.CurrentResponse}
+}
+
+func (r stateResolver) CurrentLevel() *levelResolver {
+	if r.s.CurrentLevel == nil {
+		return nil
+	}
+
+	return &levelResolver{r.s.CurrentLevel}
+}
+
+func (r stateResolver) Game(ctx context.Context) (*gameResolver, error) {
+	return loadGame(ctx, r.s.GameUUID)
+}
+
+type playedGameResolver struct {
+	g query.PlayedGame
+}
+
+func (r playedGameResolver) StateUUID() graphql.ID { return graphql.ID(r.g.StateUUID) }
+func (r playedGameResolver) GameUUID() graphql.ID  { return graphql.ID(r.g.GameUUID) }
+func (r playedGameResolver) GameTitle() string     { return r.g.GameTitle }
+func (r playedGameResolver) Completed() bool       { return r.g.Completed }
+func (r playedGameResolver) Quit() bool            { return r.g.Quit }
+func (r playedGameResolver) Points() int32         { return int32(r.g.Points) }
+func (r playedGameResolver) StartedAt() dateTime   { return dateTime{r.g.StartedAt} }
+
+func (r playedGameResolver) FinishedAt() *dateTime {
+	if r.g.FinishedAt.IsZero() {
+		return nil
+	}
+
+	return &dateTime{r.g.FinishedAt}
+}
+
+func (r playedGameResolver) State(ctx context.Context) (*stateResolver, error) {
+	return loadState(ctx, r.g.StateUUID)
+}
+
+func (r playedGameResolver) Game(ctx context.Context) (*gameResolver, error) {
+	return loadGame(ctx, r.g.GameUUID)
+}
+
+type playerResolver struct {
+	p *query.Player
+}
+
+func (r playerResolver) UUID() graphql.ID     { return graphql.ID(r.p.UUID) }
+func (r playerResolver) GamesStarted() int32  { return int32(r.p.GamesStarted) }
+func (r playerResolver) GamesFinished() int32 { return int32(r.p.GamesFinished) }
+func (r playerResolver) TotalPoints() int32   { return int32(r.p.TotalPoints) }
+
+func (r playerResolver) History(args struct{ Limit int32 }) []*playedGameResolver {
+	history := r.p.History
+
+	if limit := int(args.Limit); limit < len(history) {
+		if limit < 0 {
+			limit = 0
+		}
+		history = history[len(history)-limit:]
+	}
+
+	resolvers := make([]*playedGameResolver, len(history))
+	for i, g := range history {
+		resolvers[i] = &playedGameResolver{g}
+	}
+
+	return resolvers
+}
+
+func (r playerResolver) CurrentState(ctx context.Context) (*stateResolver, error) {
+	history := r.p.History
+	if len(history) == 0 || history[len(history)-1].Completed || history[len(history)-1].Quit {
+		return nil, nil
+	}
+
+	return loadState(ctx, history[len(history)-1].StateUUID)
+}
@@ -0,0 +1,234 @@
+package ports
+
+// graphQLSchema is the schema of the GraphQL API in the schema definition language. Its fields are resolved
+// by graphQLResolver and the resolvers of the objects it returns.
+const graphQLSchema = `
+schema {
+  query: Query
+  mutation: Mutation
+}
+
+"A time in RFC 3339 format, e.g. 2021-02-01T15:04:05Z."
+scalar DateTime
+
+"A point on the earth."
+type Location {
+  latitude: Float!
+  longitude: Float!
+}
+
+"The public details of a game, which never include its clues, answers or ending."
+type Game {
+  uuid: ID!
+  "The display name of the creator."
+  creatorName: String!
+  title: String!
+  description: String!
+  kind: String!
+  city: String!
+  state: String!
+  country: String!
+  "The number of levels of the game."
+  levelCount: Int!
+  value: Int!
+  playCount: Int!
+  completionCount: Int!
+  averageCompletionSeconds: Float!
+  ratingCount: Int!
+  averageRating: Float!
+  createdAt: DateTime!
+  status: String!
+  "The starting point of the game. It is null if the game has no location."
+  location: Location
+  "Only given when games are listed near a point."
+  distanceKm: Float
+}
+
+"A page of games."
+type GamesPage {
+  games: [Game!]!
+  "Gets the next page. It is null if this is the last page."
+  nextCursor: String
+}
+
+"The public details of the level a player is on, which never include its clues or answers."
+type Level {
+  "The index of the level in the game, from 0."
+  number: Int!
+  title: String!
+  description: String!
+}
+
+"The response of a game to a player's input."
+type Response {
+  "One of level, clue, end, incorrect or message."
+  kind: String!
+  levelTitle: String!
+  levelDescription: String!
+  clue: String!
+  endMessage: String!
+  message: String!
+}
+
+"A player's progress through a game."
+type State {
+  uuid: ID!
+  playerUUID: ID!
+  gameUUID: ID!
+  gameLevels: Int!
+  level: Int!
+  completed: Boolean!
+  "Whether the player quit the game, so it can't be continued."
+  quit: Boolean!
+  currentResponse: Response!
+  "The level the player is on. It is null once the game is completed."
+  currentLevel: Level
+  updatedAt: DateTime!
+  "The game being played. It is null if the game is no longer published."
+  game: Game
+}
+
+"An entry in a player's history."
+type PlayedGame {
+  stateUUID: ID!
+  gameUUID: ID!
+  gameTitle: String!
+  completed: Boolean!
+  "Whether the player quit the game before completing it."
+  quit: Boolean!
+  points: Int!
+  startedAt: DateTime!
+  "It is null if the game isn't finished."
+  finishedAt: DateTime
+  state: State
+  "The game played. It is null if the game is no longer published."
+  game: Game
+}
+
+"A player and the games they have played."
+type Player {
+  uuid: ID!
+  gamesStarted: Int!
+  gamesFinished: Int!
+  totalPoints: Int!
+  "The games the player has played most recently, oldest first."
+  history(limit: Int = 20): [PlayedGame!]!
+  "The state of the game the player started most recently if they haven't finished or quit it."
+  currentState: State
+}
+
+input LocationInput {
+  latitude: Float!
+  longitude: Float!
+}
+
+input GameFilter {
+  key: String!
+  value: String!
+}
+
+input NearInput {
+  latitude: Float!
+  longitude: Float!
+  radiusKm: Float = 10
+}
+
+type Query {
+  "The public details of a published game."
+  game(uuid: ID!): Game
+  """
+  Published games. The filters and sort have the syntax of the query params of GET /games, e.g. the filter
+  {key: "city", value: "in:Austin,Dallas"} and the sort -value.
+  """
+  games(
+    limit: Int = 10
+    "The nextCursor of the previous page."
+    cursor: String
+    filters: [GameFilter!]
+    sort: String
+    "Restricts the games to those within radiusKm of the point and sorts them by distance."
+    near: NearInput
+  ): GamesPage!
+  "A player. Users may only read their own player unless they are an admin or organizer."
+  player(uuid: ID!): Player
+  "The player of the authenticated user. It is null if they haven't played a game."
+  me: Player
+  "A game state. Users may only read their own game states unless they are an admin or organizer."
+  state(uuid: ID!): State
+}
+
+"The fields are the same as those of CreateGame in openapi.json."
+input CreateGameInput {
+  title: String!
+  description: String!
+  levels: [GameLevelInput!]!
+  "The message players get when they finish the game."
+  ending: String!
+  kind: String!
+  city: String
+  state: String
+  country: String
+  location: LocationInput
+  locale: String
+  clues: ClueSettingsInput
+  translations: [GameTranslationInput!]
+}
+
+input GameLevelInput {
+  title: String!
+  description: String!
+  clues: [String!]
+  answers: [String!]!
+  location: LocationInput
+}
+
+input ClueSettingsInput {
+  wrongAnswersRevealClues: Boolean
+  cooldownSeconds: Int
+}
+
+input GameTranslationInput {
+  locale: String!
+  title: String!
+  description: String!
+  ending: String!
+  levels: [LevelTranslationInput!]!
+}
+
+input LevelTranslationInput {
+  title: String!
+  description: String!
+  clues: [String!]!
+  answers: [String!]
+}
+
+type Mutation {
+  "Creates a game whose creator is the authenticated user."
+  createGame(input: CreateGameInput!): Boolean!
+  "Starts a game for the authenticated user."
+  createGameState(gameUUID: ID!): Response!
+  """
+  Submits the input of the player with the number, which may be a text command such as HINT rather than an
+  answer. Users may only update their own game state unless they are an admin or organizer.
+  """
+  updateGameState(playerNumber: String!, input: String!): Response!
+  """
+  Reveals the next clue of the current level of the player with the number. Users may only request clues for
+  themselves unless they are an admin or organizer.
+  """
+  requestClue(playerNumber: String!): Response!
+  rateGame(gameUUID: ID!, stars: Int!, review: String): Boolean!
+  reportGame(
+    gameUUID: ID!
+    reason: String!
+    "Required if the reason is other."
+    details: String
+  ): Boolean!
+  "Publishes or hides a reported game. Only admins may moderate games."
+  moderateGame(gameUUID: ID!, publish: Boolean!): Boolean!
+  setPlayerLocale(locale: String!): Boolean!
+  "Sends a verification code to the number of the authenticated user."
+  requestNumberVerification: Boolean!
+  verifyNumber(code: String!): Boolean!
+}
+`
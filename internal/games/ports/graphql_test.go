@@ -0,0 +1,250 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/common/auth"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/adapters"
+	"gopher-cache/internal/games/app"
+	"gopher-cache/internal/games/app/command"
+	"gopher-cache/internal/games/app/query"
+	"gopher-cache/internal/games/domain/game"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingProjectionRepository counts the batch reads of games and states so tests can check they are
+// batched.
+type countingProjectionRepository struct {
+	*adapters.MemoryProjectionRepository
+	gameBatches  int
+	stateBatches int
+}
+
+func (r *countingProjectionRepository) ReadGamesByUUID(ctx context.Context, uuids []string) ([]*query.Game, error) {
+	r.gameBatches++
+	return r.MemoryProjectionRepository.ReadGamesByUUID(ctx, uuids)
+}
+
+func (r *countingProjectionRepository) ReadStatesByUUID(ctx context.Context, uuids []string) ([]*query.State, error) {
+	r.stateBatches++
+	return r.MemoryProjectionRepository.ReadStatesByUUID(ctx, uuids)
+}
+
+type graphQLResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Path       []interface{}          `json:"path"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// newGraphQLTestServer serves the GraphQL API of application with mock auth. It returns a function sending
+// an operation authenticated as the player with uuid.
+func newGraphQLTestServer(t *testing.T, application app.Application) func(uuid, operation string, variables map[string]interface{}) graphQLResponse {
+	router := chi.NewRouter()
+	router.Use(logs.NewStructuredLogger(logrus.StandardLogger()))
+	router.Use(auth.HttpMockMiddleware)
+	APIHandler(NewHTTPServer(application), router)
+
+	return func(uuid, operation string, variables map[string]interface{}) graphQLResponse {
+		body, err := json.Marshal(map[string]interface{}{"query": operation, "variables": variables})
+		require.NoError(t, err)
+
+		r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Authorization", "Bearer "+mockToken(t, uuid))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp graphQLResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+		return resp
+	}
+}
+
+func TestGraphQL_Player(t *testing.T) {
+	ctx := context.Background()
+	repo := &countingProjectionRepository{MemoryProjectionRepository: adapters.NewMemoryProjectionRepository()}
+	started := time.Date(2021, 2, 1, 15, 4, 5, 0, time.UTC)
+
+	for _, g := range []*query.Game{
+		{UUID: "game-1", Title: "Hunt", Levels: 3, Status: string(game.StatusPublished)},
+		{UUID: "game-2", Title: "Chase", Levels: 2, Status: string(game.StatusPublished)},
+	} {
+		require.NoError(t, repo.SaveGameProjection(ctx, g))
+	}
+
+	require.NoError(t, repo.SavePlayerProjection(ctx, &query.Player{
+		UUID:          "player-1",
+		GamesStarted:  2,
+		GamesFinished: 1,
+		History: []query.PlayedGame{
+			{StateUUID: "state-1", GameUUID: "game-1", GameTitle: "Hunt", Completed: true, StartedAt: started, FinishedAt: started.Add(time.Hour)},
+			{StateUUID: "state-2", GameUUID: "game-2", GameTitle: "Chase", StartedAt: started.Add(2 * time.Hour)},
+		},
+	}))
+	require.NoError(t, repo.SaveStateProjection(ctx, &query.State{
+		UUID: "state-1", PlayerUUID: "player-1", GameUUID: "game-1", GameLevels: 3, Level: 2, Completed: true,
+	}))
+	require.NoError(t, repo.SaveStateProjection(ctx, &query.State{
+		UUID:            "state-2",
+		PlayerUUID:      "player-1",
+		GameUUID:        "game-2",
+		GameLevels:      2,
+		Level:           1,
+		CurrentResponse: game.Response{Kind: game.ClueResponse, Clue: "Look up"},
+		CurrentLevel:    &query.Level{Number: 1, Title: "Tower", Description: "Climb it"},
+		UpdatedAt:       started.Add(3 * time.Hour),
+	}))
+
+	serve := newGraphQLTestServer(t, app.Application{Queries: app.Queries{
+		GetGame:   query.NewReadGameHandler(repo),
+		GetPlayer: query.NewReadPlayerHandler(repo),
+		GetState:  query.NewReadStateHandler(repo),
+	}})
+
+	const operation = `{
+		me {
+			uuid
+			currentState {
+				level
+				currentResponse { kind clue }
+				currentLevel { number title description }
+				game { title levelCount }
+			}
+			history { gameTitle finishedAt state { completed } game { uuid } }
+		}
+	}`
+
+	t.Run("player", func(t *testing.T) {
+		repo.gameBatches, repo.stateBatches = 0, 0
+
+		resp := serve("player-1", operation, nil)
+		require.Empty(t, resp.Errors)
+
+		want := `{
+			"me": {
+				"uuid": "player-1",
+				"currentState": {
+					"level": 1,
+					"currentResponse": {"kind": "clue", "clue": "Look up"},
+					"currentLevel": {"number": 1, "title": "Tower", "description": "Climb it"},
+					"game": {"title": "Chase", "levelCount": 2}
+				},
+				"history": [
+					{"gameTitle": "Hunt", "finishedAt": "2021-02-01T16:04:05Z", "state": {"completed": true}, "game": {"uuid": "game-1"}},
+					{"gameTitle": "Chase", "finishedAt": null, "state": {"completed": false}, "game": {"uuid": "game-2"}}
+				]
+			}
+		}`
+		got, err := json.Marshal(resp.Data)
+		require.NoError(t, err)
+		assert.JSONEq(t, want, string(got))
+
+		// The games loaded within the wait of the loader are read in one batch, so the games of the current state
+		// and of the history are read in at most one batch for each path, and so are their states.
+		assert.LessOrEqual(t, repo.gameBatches, 2, "the games should be read in batches")
+		assert.LessOrEqual(t, repo.stateBatches, 2, "the states should be read in batches")
+	})
+
+	t.Run("no_player", func(t *testing.T) {
+		resp := serve("player-2", operation, nil)
+		require.Empty(t, resp.Errors)
+
+		assert.Nil(t, resp.Data["me"])
+	})
+
+	t.Run("another_player", func(t *testing.T) {
+		resp := serve("player-2", `query State($uuid: ID!) { state(uuid: $uuid) { level } }`, map[string]interface{}{"uuid": "state-2"})
+
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+		assert.Equal(t, query.ErrorStateNotFound.Slug(), resp.Errors[0].Extensions["slug"])
+		assert.Equal(t, []interface{}{"state"}, resp.Errors[0].Path)
+		assert.Nil(t, resp.Data["state"])
+	})
+
+	t.Run("introspection", func(t *testing.T) {
+		resp := serve("player-1", `{ __type(name: "Player") { fields { name } } }`, nil)
+		require.Empty(t, resp.Errors)
+
+		got, err := json.Marshal(resp.Data["__type"])
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `"history"`)
+	})
+
+	t.Run("too_complex", func(t *testing.T) {
+		resp := serve("player-1", `{ me { history(limit: 500) { state { uuid level completed updatedAt } game { uuid title } } } }`, nil)
+
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "INVALID_REQUEST", resp.Errors[0].Extensions["code"])
+		assert.Contains(t, resp.Errors[0].Message, "complexity")
+		assert.Nil(t, resp.Data)
+	})
+}
+
+func TestGraphQL_CreateGame(t *testing.T) {
+	projections := adapters.NewMemoryProjectionRepository()
+	projector := query.NewProjector(projections)
+
+	serve := newGraphQLTestServer(t, app.Application{
+		Commands: app.Commands{
+			CreateGame: command.NewCreateGameHandler(adapters.NewMemoryGameRepository(), projector, adapters.NewRuleModerator()),
+		},
+		Queries: app.Queries{
+			GetGames: query.NewReadGamesHandler(projections, query.NewCursorSigner([]byte("secret"))),
+		},
+	})
+
+	const createGame = `mutation CreateGame($input: CreateGameInput!) { createGame(input: $input) }`
+
+	resp := serve("player-1", createGame, map[string]interface{}{"input": map[string]interface{}{
+		"title":       "Hunt",
+		"description": "Find it",
+		"ending":      "Found it",
+		"kind":        "urban",
+		"city":        "Austin",
+		"state":       "TX",
+		"country":     "US",
+		"levels": []interface{}{
+			map[string]interface{}{"title": "One", "description": "First", "clues": []string{"Look"}, "answers": []string{"a"}},
+			map[string]interface{}{"title": "Two", "description": "Second", "clues": []string{"Look"}, "answers": []string{"b"}},
+		},
+	}})
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, true, resp.Data["createGame"])
+
+	resp = serve("player-1", `{ games(filters: [{key: "city", value: "Austin"}]) { games { title city levelCount } nextCursor } }`, nil)
+	require.Empty(t, resp.Errors)
+
+	got, err := json.Marshal(resp.Data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"games": {"games": [{"title": "Hunt", "city": "Austin", "levelCount": 2}], "nextCursor": null}}`, string(got))
+
+	t.Run("invalid", func(t *testing.T) {
+		resp := serve("player-1", createGame, map[string]interface{}{"input": map[string]interface{}{
+			"title":       "",
+			"description": "Find it",
+			"ending":      "Found it",
+			"kind":        "urban",
+			"levels":      []interface{}{},
+		}})
+
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "BAD_USER_INPUT", resp.Errors[0].Extensions["code"])
+		assert.Nil(t, resp.Data, "the mutation is non-null so the data should be null")
+	})
+}
@@ -0,0 +1,418 @@
+package ports
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopher-cache/internal/common/genproto/games"
+	"gopher-cache/internal/games/app"
+	"gopher-cache/internal/games/app/command"
+	"gopher-cache/internal/games/app/query"
+	"gopher-cache/internal/games/domain/game"
+	"time"
+)
+
+// GrpcServer maps gRPC calls to application commands and queries. Errors are returned as they are and should
+// be converted to a status by an interceptor, e.g. grpcerr.UnaryServerInterceptor.
+type GrpcServer struct {
+	games.UnimplementedGamesServiceServer
+
+	app   app.Application
+	watch WatchStateConfig
+	// watchers has a value for each call of WatchState, so it is full when MaxWatchers are watching.
+	watchers chan struct{}
+}
+
+// WatchStateConfig limits how often WatchState reads the state and how many calls watch it.
+type WatchStateConfig struct {
+	// Interval is how often the state is read while it changes.
+	Interval time.Duration
+	// MaxInterval is how long the reads back off to while the state doesn't change.
+	MaxInterval time.Duration
+	// MaxDuration is how long a call watches for before it ends and the client has to call again.
+	MaxDuration time.Duration
+	// MaxWatchers is how many calls can watch states at once. Calls over it are rejected.
+	MaxWatchers int
+}
+
+// NewGrpcServer creates a new gRPC server whose WatchState is limited by watch.
+func NewGrpcServer(app app.Application, watch WatchStateConfig) GrpcServer {
+	if watch.Interval <= 0 {
+		panic("watch interval must be positive")
+	}
+	if watch.MaxInterval < watch.Interval {
+		panic("watch max interval must be at least the interval")
+	}
+	if watch.MaxDuration <= 0 {
+		panic("watch max duration must be positive")
+	}
+	if watch.MaxWatchers <= 0 {
+		panic("max watchers must be positive")
+	}
+
+	return GrpcServer{app: app, watch: watch, watchers: make(chan struct{}, watch.MaxWatchers)}
+}
+
+// acceptLanguageFromContext returns the accept-language metadata of a call or an empty string.
+func acceptLanguageFromContext(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("accept-language"); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// CreateGame creates a game whose creator is the authenticated user.
+func (g GrpcServer) CreateGame(ctx context.Context, req *games.CreateGameRequest) (*emptypb.Empty, error) {
+	gameUser, err := gameUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := createGameCommandFromProto(req)
+
+	cmd.Creator = gameUser
+
+	if err := g.app.Commands.CreateGame.Handle(ctx, cmd); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// CreateGameState starts a game for the authenticated user.
+func (g GrpcServer) CreateGameState(ctx context.Context, req *games.CreateGameStateRequest) (*games.Response, error) {
+	gameUser, err := gameUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := command.CreateGameState{
+		User:           gameUser,
+		GameUUID:       req.GameUuid,
+		AcceptLanguage: acceptLanguageFromContext(ctx),
+	}
+
+	resp, err := g.app.Commands.CreateGameState.Handle(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return responseToProto(*resp), nil
+}
+
+// UpdateGameState submits the input of the player with the number in the request. Users may only update
+// their own game state unless they are an admin or organizer.
+func (g GrpcServer) UpdateGameState(ctx context.Context, req *games.UpdateGameStateRequest) (*games.Response, error) {
+	user, err := gameUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := command.UpdateGameState{
+		User:           user,
+		PlayerNumber:   req.PlayerNumber,
+		Input:          req.Input,
+		AcceptLanguage: acceptLanguageFromContext(ctx),
+	}
+
+	resp, err := g.app.Commands.HandleTextMessage.Handle(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return responseToProto(*resp), nil
+}
+
+// GetGames queries for games with the filters and sort of the request, which have the same syntax as the
+// query params of the HTTP API. If no limit is given then it defaults to 10.
+func (g GrpcServer) GetGames(ctx context.Context, req *games.GetGamesRequest) (*games.GamesPage, error) {
+	user, err := gameUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string][]string, len(req.Filters)+1)
+	for key, value := range req.Filters {
+		values[key] = []string{value}
+	}
+	if req.Sort != "" {
+		values["sort"] = []string{req.Sort}
+	}
+
+	q, err := query.ParseGameQuery(values)
+	if err != nil {
+		return nil, err
+	}
+
+	params := query.PageParams{Limit: int(req.Limit), Cursor: req.Cursor}
+	if params.Limit == 0 {
+		params.Limit = 10
+	}
+
+	var page *query.GamesPage
+	if req.Near != nil {
+		near := query.Near{Latitude: req.Near.Latitude, Longitude: req.Near.Longitude, RadiusKm: req.Near.RadiusKm}
+		if near.RadiusKm == 0 {
+			near.RadiusKm = 10
+		}
+
+		page, err = g.app.Queries.GetGames.HandleNear(ctx, user, near, q, params)
+	} else {
+		page, err = g.app.Queries.GetGames.Handle(ctx, user, q, params)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return gamesPageToProto(page), nil
+}
+
+// GetPlayer queries for a player. Users may only read their own player unless they are an admin or organizer.
+func (g GrpcServer) GetPlayer(ctx context.Context, req *games.GetPlayerRequest) (*games.Player, error) {
+	user, err := gameUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := g.app.Queries.GetPlayer.Handle(ctx, user, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return playerToProto(player), nil
+}
+
+// GetState queries for a game state. Users may only read their own game states unless they are an admin or
+// organizer. Other game states are not found.
+func (g GrpcServer) GetState(ctx context.Context, req *games.GetStateRequest) (*games.State, error) {
+	user, err := gameUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := g.app.Queries.GetState.Handle(ctx, user, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return stateToProto(state), nil
+}
+
+// WatchState sends the game state and then each update of it until the game is completed, the call is
+// cancelled or it has watched for the MaxDuration of the config, after which the client should call again.
+// Updates are found by reading the state from the read model, so every instance of the service sees them
+// however the projections are updated. The state is read every Interval while it changes and the reads back
+// off to MaxInterval while it doesn't. Calls over MaxWatchers get ResourceExhausted.
+func (g GrpcServer) WatchState(req *games.GetStateRequest, stream games.GamesService_WatchStateServer) error {
+	user, err := gameUserFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	select {
+	case g.watchers <- struct{}{}:
+		defer func() { <-g.watchers }()
+	default:
+		return status.Error(codes.ResourceExhausted, "too many calls are watching states, try again later")
+	}
+
+	ctx, cancel := context.WithTimeout(stream.Context(), g.watch.MaxDuration)
+	defer cancel()
+
+	interval := g.watch.Interval
+
+	var lastUpdate *time.Time
+	for {
+		state, err := g.app.Queries.GetState.Handle(ctx, user, req.Uuid)
+		if err != nil {
+			return watchEnded(stream.Context(), ctx, err)
+		}
+
+		if lastUpdate == nil || state.UpdatedAt.After(*lastUpdate) {
+			if err := stream.Send(stateToProto(state)); err != nil {
+				return err
+			}
+
+			lastUpdate = &state.UpdatedAt
+			interval = g.watch.Interval
+		} else if interval *= 2; interval > g.watch.MaxInterval {
+			interval = g.watch.MaxInterval
+		}
+
+		if state.Completed {
+			return nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return watchEnded(stream.Context(), ctx, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// watchEnded returns the error a watch of callCtx ends with after it stopped because of err. A watch that
+// reached the deadline of watchCtx ends without an error.
+func watchEnded(callCtx, watchCtx context.Context, err error) error {
+	if callCtx.Err() == nil && watchCtx.Err() == context.DeadlineExceeded {
+		return nil
+	}
+
+	return err
+}
+
+func createGameCommandFromProto(req *games.CreateGameRequest) command.CreateGame {
+	cmd := command.CreateGame{
+		Title:        req.Title,
+		Description:  req.Description,
+		Levels:       make([]command.GameLevel, len(req.Levels)),
+		Ending:       req.Ending,
+		Kind:         req.Kind,
+		City:         req.City,
+		State:        req.State,
+		Country:      req.Country,
+		Location:     locationCommandFromProto(req.Location),
+		Locale:       req.Locale,
+		Translations: make([]command.GameTranslation, len(req.Translations)),
+	}
+
+	for i, l := range req.Levels {
+		cmd.Levels[i] = command.GameLevel{
+			Title:       l.Title,
+			Description: l.Description,
+			Clues:       l.Clues,
+			Answers:     l.Answers,
+			Location:    locationCommandFromProto(l.Location),
+		}
+	}
+
+	if req.Clues != nil {
+		cmd.Clues = &command.ClueSettings{
+			WrongAnswersRevealClues: req.Clues.WrongAnswersRevealClues,
+			CooldownSeconds:         int(req.Clues.CooldownSeconds),
+		}
+	}
+
+	for i, t := range req.Translations {
+		cmd.Translations[i] = command.GameTranslation{
+			Locale:      t.Locale,
+			Title:       t.Title,
+			Description: t.Description,
+			Ending:      t.Ending,
+			Levels:      make([]command.LevelTranslation, len(t.Levels)),
+		}
+
+		for j, l := range t.Levels {
+			cmd.Translations[i].Levels[j] = command.LevelTranslation{
+				Title:       l.Title,
+				Description: l.Description,
+				Clues:       l.Clues,
+				Answers:     l.Answers,
+			}
+		}
+	}
+
+	return cmd
+}
+
+func locationCommandFromProto(l *games.Location) *command.Location {
+	if l == nil {
+		return nil
+	}
+
+	return &command.Location{Latitude: l.Latitude, Longitude: l.Longitude}
+}
+
+func responseToProto(r game.Response) *games.Response {
+	return &games.Response{
+		Kind:             string(r.Kind),
+		LevelTitle:       r.LevelTitle,
+		LevelDescription: r.LevelDescription,
+		Clue:             r.Clue,
+		EndMessage:       r.EndMessage,
+		Message:          r.Message,
+	}
+}
+
+func gamesPageToProto(page *query.GamesPage) *games.GamesPage {
+	resp := &games.GamesPage{
+		Games:      make([]*games.Game, len(page.Games)),
+		NextCursor: page.NextCursor,
+	}
+
+	for i, g := range page.Games {
+		resp.Games[i] = &games.Game{
+			Uuid:                     g.UUID,
+			CreatorName:              g.CreatorName,
+			Title:                    g.Title,
+			Description:              g.Description,
+			Kind:                     g.Kind,
+			City:                     g.City,
+			State:                    g.State,
+			Country:                  g.Country,
+			Levels:                   int32(g.Levels),
+			Value:                    int32(g.Value),
+			PlayCount:                int32(g.PlayCount),
+			CompletionCount:          int32(g.CompletionCount),
+			AverageCompletionSeconds: g.AverageCompletionSeconds,
+			RatingCount:              int32(g.RatingCount),
+			AverageRating:            g.AverageRating,
+			CreatedAt:                timestamppb.New(g.CreatedAt),
+			Status:                   g.Status,
+			DistanceKm:               g.DistanceKm,
+		}
+
+		if g.Location != nil {
+			resp.Games[i].Location = &games.Location{Latitude: g.Location.Latitude, Longitude: g.Location.Longitude}
+		}
+	}
+
+	return resp
+}
+
+func playerToProto(p *query.Player) *games.Player {
+	resp := &games.Player{
+		Uuid:          p.UUID,
+		GamesStarted:  int32(p.GamesStarted),
+		GamesFinished: int32(p.GamesFinished),
+		TotalPoints:   int32(p.TotalPoints),
+		History:       make([]*games.PlayedGame, len(p.History)),
+	}
+
+	for i, h := range p.History {
+		resp.History[i] = &games.PlayedGame{
+			StateUuid: h.StateUUID,
+			GameUuid:  h.GameUUID,
+			GameTitle: h.GameTitle,
+			Completed: h.Completed,
+			Points:    int32(h.Points),
+			StartedAt: timestamppb.New(h.StartedAt),
+		}
+
+		if !h.FinishedAt.IsZero() {
+			resp.History[i].FinishedAt = timestamppb.New(h.FinishedAt)
+		}
+	}
+
+	return resp
+}
+
+func stateToProto(s *query.State) *games.State {
+	return &games.State{
+		Uuid:            s.UUID,
+		PlayerUuid:      s.PlayerUUID,
+		GameUuid:        s.GameUUID,
+		GameLevels:      int32(s.GameLevels),
+		Level:           int32(s.Level),
+		Completed:       s.Completed,
+		CurrentResponse: responseToProto(s.CurrentResponse),
+		UpdatedAt:       timestamppb.New(s.UpdatedAt),
+	}
+}
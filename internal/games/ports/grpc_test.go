@@ -0,0 +1,287 @@
+package ports
+
+import (
+	"context"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gopher-cache/internal/common/auth"
+	"gopher-cache/internal/common/genproto/games"
+	"gopher-cache/internal/common/server/grpcerr"
+	"gopher-cache/internal/games/app"
+	"gopher-cache/internal/games/app/query"
+	"gopher-cache/internal/games/domain/game"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stateReadModel is a StateReadModel whose states can be updated while they are watched. It counts the reads
+// of single states.
+type stateReadModel struct {
+	lock   sync.Mutex
+	states map[string]query.State
+	reads  int
+}
+
+func (m *stateReadModel) ReadState(_ context.Context, uuid string) (*query.State, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.reads++
+
+	s, ok := m.states[uuid]
+	if !ok {
+		return nil, query.ErrorProjectionNotFound
+	}
+
+	return &s, nil
+}
+
+func (m *stateReadModel) ReadStatesByUUID(_ context.Context, uuids []string) ([]*query.State, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	states := make([]*query.State, len(uuids))
+	for i, uuid := range uuids {
+		if s, ok := m.states[uuid]; ok {
+			states[i] = &s
+		}
+	}
+
+	return states, nil
+}
+
+func (m *stateReadModel) update(s query.State) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.states[s.UUID] = s
+}
+
+// testWatchStateConfig reads states often so tests of WatchState don't wait long for updates.
+var testWatchStateConfig = WatchStateConfig{
+	Interval:    10 * time.Millisecond,
+	MaxInterval: 40 * time.Millisecond,
+	MaxDuration: time.Minute,
+	MaxWatchers: 10,
+}
+
+// newGrpcClient serves a GrpcServer over an in-memory connection the same way as server.RunGRPCServer, with
+// mock auth.
+func newGrpcClient(t *testing.T, application app.Application) games.GamesServiceClient {
+	return newGrpcClientWithConfig(t, application, testWatchStateConfig)
+}
+
+func newGrpcClientWithConfig(t *testing.T, application app.Application, watch WatchStateConfig) games.GamesServiceClient {
+	authInterceptor := auth.GrpcAuthInterceptor{Tokens: auth.MockTokenAuthenticator{}}
+
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcerr.UnaryServerInterceptor, authInterceptor.Unary),
+		grpc.ChainStreamInterceptor(grpcerr.StreamServerInterceptor, authInterceptor.Stream),
+	)
+	games.RegisterGamesServiceServer(srv, NewGrpcServer(application, watch))
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return games.NewGamesServiceClient(conn)
+}
+
+// mockToken returns a token accepted by auth.MockTokenAuthenticator for the player with uuid.
+func mockToken(t *testing.T, uuid string) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"id":     uuid,
+		"name":   "Player",
+		"email":  "player@example.com",
+		"number": "+15734497033",
+	}).SignedString([]byte("mock_secret"))
+	require.NoError(t, err)
+
+	return token
+}
+
+// withMockToken returns a context whose calls are authenticated as the player with uuid.
+func withMockToken(t *testing.T, ctx context.Context, uuid string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+mockToken(t, uuid))
+}
+
+func assertStatus(t *testing.T, err error, wantCode codes.Code, wantSlug string) {
+	s, ok := status.FromError(err)
+	require.True(t, ok, err)
+	assert.Equal(t, wantCode, s.Code())
+
+	require.NotEmpty(t, s.Details())
+	assert.Equal(t, wantSlug, s.Details()[0].(*errdetails.ErrorInfo).Reason)
+}
+
+func TestGrpcServer_GetState(t *testing.T) {
+	readModel := &stateReadModel{states: map[string]query.State{
+		"state-1": {UUID: "state-1", PlayerUUID: "player-1", GameUUID: "game-1", GameLevels: 2, Level: 1},
+	}}
+
+	client := newGrpcClient(t, app.Application{Queries: app.Queries{GetState: query.NewReadStateHandler(readModel)}})
+
+	t.Run("player", func(t *testing.T) {
+		state, err := client.GetState(withMockToken(t, context.Background(), "player-1"), &games.GetStateRequest{Uuid: "state-1"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "game-1", state.GameUuid)
+		assert.Equal(t, int32(1), state.Level)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		_, err := client.GetState(context.Background(), &games.GetStateRequest{Uuid: "state-1"})
+
+		assertStatus(t, err, codes.Unauthenticated, "unable-to-get-jwt")
+	})
+
+	t.Run("another player", func(t *testing.T) {
+		_, err := client.GetState(withMockToken(t, context.Background(), "player-2"), &games.GetStateRequest{Uuid: "state-1"})
+
+		assertStatus(t, err, codes.NotFound, query.ErrorStateNotFound.Slug())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.GetState(withMockToken(t, context.Background(), "player-1"), &games.GetStateRequest{Uuid: "state-2"})
+
+		assertStatus(t, err, codes.NotFound, query.ErrorStateNotFound.Slug())
+	})
+}
+
+func TestGrpcServer_WatchState(t *testing.T) {
+	started := time.Now().Add(-time.Minute)
+	readModel := &stateReadModel{states: map[string]query.State{
+		"state-1": {UUID: "state-1", PlayerUUID: "player-1", GameLevels: 2, Level: 1, UpdatedAt: started},
+	}}
+
+	client := newGrpcClient(t, app.Application{Queries: app.Queries{GetState: query.NewReadStateHandler(readModel)}})
+
+	ctx, cancel := context.WithTimeout(withMockToken(t, context.Background(), "player-1"), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchState(ctx, &games.GetStateRequest{Uuid: "state-1"})
+	require.NoError(t, err)
+
+	state, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), state.Level)
+
+	readModel.update(query.State{UUID: "state-1", PlayerUUID: "player-1", GameLevels: 2, Level: 2, UpdatedAt: started.Add(time.Second)})
+
+	state, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), state.Level)
+
+	readModel.update(query.State{
+		UUID:            "state-1",
+		PlayerUUID:      "player-1",
+		GameLevels:      2,
+		Level:           2,
+		Completed:       true,
+		CurrentResponse: game.Response{Kind: game.EndResponse, EndMessage: "Well done!"},
+		UpdatedAt:       started.Add(2 * time.Second),
+	})
+
+	state, err = stream.Recv()
+	require.NoError(t, err)
+	assert.True(t, state.Completed)
+	assert.Equal(t, "Well done!", state.CurrentResponse.EndMessage)
+
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err, "the stream should end when the game is completed")
+}
+
+func TestGrpcServer_WatchState_Limits(t *testing.T) {
+	newReadModel := func() *stateReadModel {
+		return &stateReadModel{states: map[string]query.State{
+			"state-1": {UUID: "state-1", PlayerUUID: "player-1", GameLevels: 2, Level: 1, UpdatedAt: time.Now()},
+		}}
+	}
+
+	t.Run("max duration", func(t *testing.T) {
+		watch := testWatchStateConfig
+		watch.MaxDuration = 50 * time.Millisecond
+
+		client := newGrpcClientWithConfig(t, app.Application{Queries: app.Queries{GetState: query.NewReadStateHandler(newReadModel())}}, watch)
+
+		ctx, cancel := context.WithTimeout(withMockToken(t, context.Background(), "player-1"), 5*time.Second)
+		defer cancel()
+
+		stream, err := client.WatchState(ctx, &games.GetStateRequest{Uuid: "state-1"})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		assert.Equal(t, io.EOF, err, "the stream should end after the max duration")
+	})
+
+	t.Run("backs off while unchanged", func(t *testing.T) {
+		readModel := newReadModel()
+
+		watch := testWatchStateConfig
+		watch.MaxDuration = 200 * time.Millisecond
+
+		client := newGrpcClientWithConfig(t, app.Application{Queries: app.Queries{GetState: query.NewReadStateHandler(readModel)}}, watch)
+
+		ctx, cancel := context.WithTimeout(withMockToken(t, context.Background(), "player-1"), 5*time.Second)
+		defer cancel()
+
+		stream, err := client.WatchState(ctx, &games.GetStateRequest{Uuid: "state-1"})
+		require.NoError(t, err)
+
+		for err == nil {
+			_, err = stream.Recv()
+		}
+		require.Equal(t, io.EOF, err)
+
+		readModel.lock.Lock()
+		defer readModel.lock.Unlock()
+
+		// Reads every 10ms would be 20 reads, backing off to 40ms leaves about 7.
+		assert.Less(t, readModel.reads, 12)
+	})
+
+	t.Run("max watchers", func(t *testing.T) {
+		watch := testWatchStateConfig
+		watch.MaxWatchers = 1
+
+		client := newGrpcClientWithConfig(t, app.Application{Queries: app.Queries{GetState: query.NewReadStateHandler(newReadModel())}}, watch)
+
+		ctx, cancel := context.WithTimeout(withMockToken(t, context.Background(), "player-1"), 5*time.Second)
+		defer cancel()
+
+		first, err := client.WatchState(ctx, &games.GetStateRequest{Uuid: "state-1"})
+		require.NoError(t, err)
+		_, err = first.Recv()
+		require.NoError(t, err)
+
+		second, err := client.WatchState(ctx, &games.GetStateRequest{Uuid: "state-1"})
+		require.NoError(t, err)
+		_, err = second.Recv()
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+}
@@ -2,7 +2,8 @@
 package ports
 
 import (
-	"github.com/go-chi/chi"
+	"context"
+	"errors"
 	"github.com/go-chi/render"
 	"gopher-cache/internal/common/auth"
 	"gopher-cache/internal/common/server/httperr"
@@ -11,77 +12,177 @@ import (
 	"gopher-cache/internal/games/app/query"
 	"gopher-cache/internal/games/domain/game"
 	"net/http"
-	"strconv"
+	"net/url"
 )
 
 // HTTPServer maps HTTP request to application commands and queries.
 type HTTPServer struct {
 	app app.Application
+
+	graphQL graphQLServer
 }
 
 // Creates a new HTTP server.
 func NewHTTPServer(app app.Application) HTTPServer {
-	return HTTPServer{app: app}
+	return HTTPServer{app: app, graphQL: newGraphQLServer(app)}
+}
+
+// gameUserFromRequest returns the authenticated user of the request as a game.User.
+func gameUserFromRequest(r *http.Request) (game.User, error) {
+	return gameUserFromContext(r.Context())
+}
+
+// gameUserFromContext returns the authenticated user as a game.User. Users whose token has no roles
+// get the game.DefaultRoles. Machine clients authenticated with an API key get the permissions in the
+// key's scopes. A user whose number isn't a valid phone number gets command.ErrorInvalidNumber.
+func gameUserFromContext(ctx context.Context) (game.User, error) {
+	u, err := newGameUserFromContext(ctx)
+	if errors.Is(err, game.ErrorInvalidNumber) {
+		return game.User{}, command.ErrorInvalidNumber
+	}
+
+	return u, err
+}
+
+func newGameUserFromContext(ctx context.Context) (game.User, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		return game.User{}, err
+	}
+
+	if user.Scopes != nil {
+		var permissions []game.Permission
+		for _, scope := range user.Scopes {
+			// Scopes that are no longer permissions are ignored rather than locking the client out.
+			if p, err := game.NewPermission(scope); err == nil {
+				permissions = append(permissions, p)
+			}
+		}
+
+		return game.NewClientUser(user.UUID, user.DisplayName, permissions...)
+	}
+
+	if len(user.Roles) == 0 {
+		return game.NewNamedUser(user.UUID, user.Number, user.DisplayName)
+	}
+
+	roles := make([]game.Role, len(user.Roles))
+	for i, role := range user.Roles {
+		roles[i] = game.Role(role)
+	}
+
+	return game.NewUserWithRoles(user.UUID, user.Number, user.DisplayName, roles...)
 }
 
 // CreateGame expects the body of the request to have JSON in the form of
-// command.CreateGame.
+// CreateGame.
 func (h HTTPServer) CreateGame(w http.ResponseWriter, r *http.Request) {
-	user, err := auth.UserFromContext(r.Context())
+	gameUser, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	body := new(CreateGame)
+
+	err = render.Decode(r, body)
+	if err != nil {
+		httperr.BadRequest("invalid-request-body", err, w, r)
+		return
+	}
+
+	cmd := createGameCommand(*body)
+
+	cmd.Creator = gameUser
+
+	err = h.app.Commands.CreateGame.Handle(r.Context(), cmd)
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
 	}
+}
 
-	gameUser, err := game.NewUser(user.UUID, user.Number)
+// CreateGameState expects the body of the request to have JSON in the form of
+// CreateGameState.
+func (h HTTPServer) CreateGameState(w http.ResponseWriter, r *http.Request, params CreateGameStateParams) {
+	gameUser, err := gameUserFromRequest(r)
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
 	}
 
-	cmd := new(command.CreateGame)
+	body := new(CreateGameState)
 
-	err = render.Decode(r, cmd)
+	err = render.Decode(r, body)
 	if err != nil {
-		httperr.RespondWithSlugError(err, w, r)
+		httperr.BadRequest("invalid-request-body", err, w, r)
 		return
 	}
 
-	cmd.Creator = gameUser
+	cmd := createGameStateCommand(*body)
 
-	err = h.app.Commands.CreateGame.Handle(r.Context(), *cmd)
+	cmd.User = gameUser
+	cmd.AcceptLanguage = stringValue(params.AcceptLanguage)
+
+	resp, err := h.app.Commands.CreateGameState.Handle(r.Context(), cmd)
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
 	}
+
+	render.Respond(w, r, resp)
 }
 
-// CreateGameState expects the body of the request to have JSON in the form of
-// command.CreateGameState.
-func (h HTTPServer) CreateGameState(w http.ResponseWriter, r *http.Request) {
-	user, err := auth.UserFromContext(r.Context())
+// UpdateGameState expects the body of the request to have JSON in the form of
+// UpdateGameState. A URL param player-number must also be present. Users may only update
+// their own game state unless they are an admin or organizer. The input may be a text command such as HINT
+// rather than an answer.
+func (h HTTPServer) UpdateGameState(w http.ResponseWriter, r *http.Request, playerNumber string, params UpdateGameStateParams) {
+	user, err := gameUserFromRequest(r)
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
 	}
 
-	gameUser, err := game.NewUser(user.UUID, user.Number)
+	body := new(UpdateGameState)
+
+	err = render.Decode(r, body)
+	if err != nil {
+		httperr.BadRequest("invalid-request-body", err, w, r)
+		return
+	}
+
+	cmd := updateGameStateCommand(*body)
+
+	cmd.User = user
+	cmd.PlayerNumber = playerNumber
+	cmd.AcceptLanguage = stringValue(params.AcceptLanguage)
+
+	resp, err := h.app.Commands.HandleTextMessage.Handle(r.Context(), cmd)
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
 	}
 
-	cmd := new(command.CreateGameState)
+	render.Respond(w, r, resp)
+}
 
-	err = render.Decode(r, cmd)
+// RequestClue reveals the next clue of the player's current level. A URL param player-number must be
+// present. Users may only request clues for themselves unless they are an admin or organizer.
+func (h HTTPServer) RequestClue(w http.ResponseWriter, r *http.Request, playerNumber string, params RequestClueParams) {
+	user, err := gameUserFromRequest(r)
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
 	}
 
-	cmd.User = gameUser
+	cmd := command.RequestClue{
+		User:           user,
+		PlayerNumber:   playerNumber,
+		AcceptLanguage: stringValue(params.AcceptLanguage),
+	}
 
-	resp, err := h.app.Commands.CreateGameState.Handle(r.Context(), *cmd)
+	resp, err := h.app.Commands.RequestClue.Handle(r.Context(), cmd)
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
@@ -90,107 +191,297 @@ func (h HTTPServer) CreateGameState(w http.ResponseWriter, r *http.Request) {
 	render.Respond(w, r, resp)
 }
 
-// UpdateGameState expects the body of the request to have JSON in the form of
-// command.UpdateGameState. A URL param player-number must also be present.
-func (h HTTPServer) UpdateGameState(w http.ResponseWriter, r *http.Request) {
-	// We'll use the user in the context to authenticate the request.
-	_, err := auth.UserFromContext(r.Context())
+// pageParams returns the pagination params of a request. If no limit is given then it defaults to 10.
+func pageParams(limit *int, cursor *string, offset *int) query.PageParams {
+	page := query.PageParams{Limit: 10}
+
+	if limit != nil {
+		page.Limit = *limit
+	}
+	if cursor != nil {
+		page.Cursor = *cursor
+	}
+	if offset != nil {
+		page.Offset = *offset
+	}
+
+	return page
+}
+
+// deprecateOffset warns clients still paginating with offset that they should move to cursors.
+func deprecateOffset(w http.ResponseWriter, params query.PageParams) {
+	if params.Offset != 0 {
+		w.Header().Set("Deprecation", "true")
+	}
+}
+
+// nearFromParams returns nil if neither lat nor lng are given. The radius is in kilometers and defaults
+// to 10.
+func nearFromParams(params GetGamesParams) (*query.Near, error) {
+	if params.Lat == nil && params.Lng == nil {
+		return nil, nil
+	}
+	if params.Lat == nil || params.Lng == nil {
+		return nil, errors.New("lat and lng must be given together")
+	}
+
+	near := &query.Near{
+		Latitude:  *params.Lat,
+		Longitude: *params.Lng,
+		RadiusKm:  10,
+	}
+
+	if params.Radius != nil {
+		near.RadiusKm = *params.Radius
+	}
+
+	return near, nil
+}
+
+// gameQueryValues returns the params of a request that filter and sort games, which are all of them but
+// the pagination and location params.
+func gameQueryValues(r *http.Request) url.Values {
+	values := r.URL.Query()
+	for _, param := range []string{"limit", "cursor", "offset", "lat", "lng", "radius"} {
+		values.Del(param)
+	}
+
+	return values
+}
+
+// GetGames queries for games. Games can be filtered and sorted on an allow list of fields
+// using the syntax described by query.ParseGameQuery, e.g. ?city=in:Austin,Dallas&value=gte:10&sort=-value.
+// Results are paginated with limit and cursor, which is the nextCursor of the previous page.
+// If no limit is given then it defaults to 10. offset still works but is deprecated. lat and lng restrict the games to those within radius kilometers
+// (default 10) of the point and sort them by distance.
+func (h HTTPServer) GetGames(w http.ResponseWriter, r *http.Request, params GetGamesParams) {
+	user, err := gameUserFromRequest(r)
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
 	}
 
-	cmd := new(command.UpdateGameState)
+	near, err := nearFromParams(params)
+	if err != nil {
+		httperr.BadRequest("query-params", err, w, r)
+		return
+	}
 
-	err = render.Decode(r, cmd)
+	q, err := query.ParseGameQuery(gameQueryValues(r))
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
 	}
 
-	cmd.PlayerNumber = chi.URLParam(r, "player-number")
+	page := pageParams(params.Limit, params.Cursor, params.Offset)
 
-	resp, err := h.app.Commands.UpdateGameState.Handle(r.Context(), *cmd)
+	var games *query.GamesPage
+	if near != nil {
+		games, err = h.app.Queries.GetGames.HandleNear(r.Context(), user, *near, q, page)
+	} else {
+		games, err = h.app.Queries.GetGames.Handle(r.Context(), user, q, page)
+	}
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
 	}
 
-	render.Respond(w, r, resp)
+	deprecateOffset(w, page)
+	render.Respond(w, r, games)
 }
 
-func gameQueryParamsFromRequest(r *http.Request) (limit, offset int, options []query.GameOption, err error) {
-	values := r.URL.Query()
+// GetGame queries for the public details of a game. The UUID is expressed in a URL param uuid.
+func (h HTTPServer) GetGame(w http.ResponseWriter, r *http.Request, uuid string) {
+	user, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
 
-	limitStr := values.Get("limit")
-	if limitStr != "" {
-		limit, err = strconv.Atoi(limitStr)
-		if err != nil {
-			return
-		}
-		values.Del("limit")
-	} else {
-		limit = 10
+	g, err := h.app.Queries.GetGame.Handle(r.Context(), user, uuid)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
 	}
 
-	offsetStr := values.Get("offset")
-	if offsetStr != "" {
-		offset, err = strconv.Atoi(offsetStr)
-		if err != nil {
-			return
-		}
-		values.Del("offset")
-	} else {
-		offset = 0
+	render.Respond(w, r, g)
+}
+
+// RateGame expects the body of the request to have JSON in the form of RateGame.
+// The game UUID is expressed in a URL param uuid.
+func (h HTTPServer) RateGame(w http.ResponseWriter, r *http.Request, uuid string) {
+	rater, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	body := new(RateGame)
+
+	err = render.Decode(r, body)
+	if err != nil {
+		httperr.BadRequest("invalid-request-body", err, w, r)
+		return
 	}
 
-	for k := range values {
-		options = append(options, query.GameOption{
-			Key:   k,
-			Op:    "==",
-			Value: values.Get(k),
-		})
+	cmd := rateGameCommand(*body)
+
+	cmd.Rater = rater
+	cmd.GameUUID = uuid
+
+	err = h.app.Commands.RateGame.Handle(r.Context(), cmd)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
 	}
 
-	return
+	w.WriteHeader(http.StatusNoContent)
 }
 
-// GetGames queries for games. Supported queries are camel cased member names of
-// the game.Game type. limit and offset are also available to support pagination.
-// If no limit is given then it defaults to 10.
-func (h HTTPServer) GetGames(w http.ResponseWriter, r *http.Request) {
-	// We'll use the user in the context to authenticate the request.
-	_, err := auth.UserFromContext(r.Context())
+// ReportGame expects the body of the request to have JSON in the form of ReportGame.
+// The game UUID is expressed in a URL param uuid.
+func (h HTTPServer) ReportGame(w http.ResponseWriter, r *http.Request, uuid string) {
+	reporter, err := gameUserFromRequest(r)
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
 	}
 
-	limit, offset, options, err := gameQueryParamsFromRequest(r)
+	body := new(ReportGame)
+
+	err = render.Decode(r, body)
 	if err != nil {
-		httperr.BadRequest("query-params", err, w, r)
+		httperr.BadRequest("invalid-request-body", err, w, r)
+		return
+	}
+
+	cmd := reportGameCommand(*body)
+
+	cmd.Reporter = reporter
+	cmd.GameUUID = uuid
+
+	err = h.app.Commands.ReportGame.Handle(r.Context(), cmd)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetModerationQueue queries for the games waiting for a moderator, oldest report first. Results are
+// paginated the same way as GetGames. Only admins may read the queue.
+func (h HTTPServer) GetModerationQueue(w http.ResponseWriter, r *http.Request, params GetModerationQueueParams) {
+	user, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	page := pageParams(params.Limit, params.Cursor, params.Offset)
+
+	queue, err := h.app.Queries.GetModerationQueue.Handle(r.Context(), user, page)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	deprecateOffset(w, page)
+	render.Respond(w, r, queue)
+}
+
+// ModerateGame expects the body of the request to have JSON in the form of ModerateGame.
+// The game UUID is expressed in a URL param uuid. Only admins may moderate games.
+func (h HTTPServer) ModerateGame(w http.ResponseWriter, r *http.Request, uuid string) {
+	moderator, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	body := new(ModerateGame)
+
+	err = render.Decode(r, body)
+	if err != nil {
+		httperr.BadRequest("invalid-request-body", err, w, r)
+		return
+	}
+
+	cmd := moderateGameCommand(*body)
+
+	cmd.Moderator = moderator
+	cmd.GameUUID = uuid
+
+	err = h.app.Commands.ModerateGame.Handle(r.Context(), cmd)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetGameReviews queries for the reviews of a game, newest first. The game UUID is expressed in a
+// URL param uuid. Results are paginated the same way as GetGames.
+func (h HTTPServer) GetGameReviews(w http.ResponseWriter, r *http.Request, uuid string, params GetGameReviewsParams) {
+	user, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	page := pageParams(params.Limit, params.Cursor, params.Offset)
+
+	reviews, err := h.app.Queries.GetGameReviews.Handle(r.Context(), user, uuid, page)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
 		return
 	}
 
-	games, err := h.app.Queries.GetGames.Handle(r.Context(), limit, offset, options...)
+	deprecateOffset(w, page)
+	render.Respond(w, r, reviews)
+}
+
+// SearchGames searches the title, description and city of games for the text in the q param.
+// The results can be narrowed with the kind, city, state and country params and are ordered
+// by relevance. Results are paginated the same way as GetGames.
+func (h HTTPServer) SearchGames(w http.ResponseWriter, r *http.Request, params SearchGamesParams) {
+	user, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	page := pageParams(params.Limit, params.Cursor, params.Offset)
+
+	search := query.GameSearch{
+		Text:    params.Q,
+		Kind:    stringValue(params.Kind),
+		City:    stringValue(params.City),
+		State:   stringValue(params.State),
+		Country: stringValue(params.Country),
+	}
+
+	games, err := h.app.Queries.SearchGames.Handle(r.Context(), user, search, page)
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
 	}
 
+	deprecateOffset(w, page)
 	render.Respond(w, r, games)
 }
 
-// GetPlayer queries for a players UUID. The UUID is expressed in a URL param uuid.
-func (h HTTPServer) GetPlayer(w http.ResponseWriter, r *http.Request) {
-	// We'll use the user in the context to authenticate the request.
-	_, err := auth.UserFromContext(r.Context())
+// GetPlayer queries for a players UUID. The UUID is expressed in a URL param uuid. Users may only read
+// their own player unless they are an admin or organizer.
+func (h HTTPServer) GetPlayer(w http.ResponseWriter, r *http.Request, uuid string) {
+	user, err := gameUserFromRequest(r)
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
 	}
 
-	player, err := h.app.Queries.GetPlayer.Handle(r.Context(), chi.URLParam(r, "uuid"))
+	player, err := h.app.Queries.GetPlayer.Handle(r.Context(), user, uuid)
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
@@ -199,16 +490,116 @@ func (h HTTPServer) GetPlayer(w http.ResponseWriter, r *http.Request) {
 	render.Respond(w, r, player)
 }
 
-// GetState queries for a game state by UUID. The UUID is expressed in a URL param uuid.
-func (h HTTPServer) GetState(w http.ResponseWriter, r *http.Request) {
-	// We'll use the user in the context to authenticate the request.
-	_, err := auth.UserFromContext(r.Context())
+// RequestNumberVerification sends a verification code to the number of the user, which must be verified
+// before the player can receive game messages.
+func (h HTTPServer) RequestNumberVerification(w http.ResponseWriter, r *http.Request, params RequestNumberVerificationParams) {
+	user, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	cmd := command.RequestNumberVerification{
+		User:           user,
+		AcceptLanguage: stringValue(params.AcceptLanguage),
+	}
+
+	err = h.app.Commands.RequestNumberVerification.Handle(r.Context(), cmd)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetPlayerLocale expects the body of the request to have JSON in the form of SetPlayerLocale.
+func (h HTTPServer) SetPlayerLocale(w http.ResponseWriter, r *http.Request) {
+	user, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	body := new(SetPlayerLocale)
+
+	err = render.Decode(r, body)
+	if err != nil {
+		httperr.BadRequest("invalid-request-body", err, w, r)
+		return
+	}
+
+	cmd := setPlayerLocaleCommand(*body)
+
+	cmd.User = user
+
+	err = h.app.Commands.SetPlayerLocale.Handle(r.Context(), cmd)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// VerifyNumber expects the body of the request to have JSON in the form of VerifyNumber.
+func (h HTTPServer) VerifyNumber(w http.ResponseWriter, r *http.Request) {
+	user, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	body := new(VerifyNumber)
+
+	err = render.Decode(r, body)
+	if err != nil {
+		httperr.BadRequest("invalid-request-body", err, w, r)
+		return
+	}
+
+	cmd := verifyNumberCommand(*body)
+
+	cmd.User = user
+
+	err = h.app.Commands.VerifyNumber.Handle(r.Context(), cmd)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MigratePlayerNumbers normalizes the numbers of players created before numbers were normalized and
+// verifies the numbers of players who were already playing games. Only admins and organizers may migrate
+// numbers.
+func (h HTTPServer) MigratePlayerNumbers(w http.ResponseWriter, r *http.Request) {
+	user, err := gameUserFromRequest(r)
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
 	}
 
-	state, err := h.app.Queries.GetState.Handle(r.Context(), chi.URLParam(r, "uuid"))
+	resp, err := h.app.Commands.MigratePlayerNumbers.Handle(r.Context(), command.MigratePlayerNumbers{User: user})
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	render.Respond(w, r, resp)
+}
+
+// GetState queries for a game state by UUID. The UUID is expressed in a URL param uuid. Users may only
+// read their own game states unless they are an admin or organizer. Other game states are not found.
+func (h HTTPServer) GetState(w http.ResponseWriter, r *http.Request, uuid string) {
+	user, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	state, err := h.app.Queries.GetState.Handle(r.Context(), user, uuid)
 	if err != nil {
 		httperr.RespondWithSlugError(err, w, r)
 		return
@@ -216,3 +607,140 @@ func (h HTTPServer) GetState(w http.ResponseWriter, r *http.Request) {
 
 	render.Respond(w, r, state)
 }
+
+// RebuildProjections regenerates every projection backing the queries from scratch. Only admins may
+// rebuild projections.
+func (h HTTPServer) RebuildProjections(w http.ResponseWriter, r *http.Request) {
+	user, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	err = h.app.Commands.RebuildProjections.Handle(r.Context(), command.RebuildProjections{User: user})
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+}
+
+// GetProjectionLag queries for metrics on how far the projections lag behind the events they are built from.
+// Only admins may read them.
+func (h HTTPServer) GetProjectionLag(w http.ResponseWriter, r *http.Request) {
+	user, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	lag, err := h.app.Queries.GetProjectionLag.Handle(r.Context(), user)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	render.Respond(w, r, lag)
+}
+
+// GetCreatorGameAnalytics queries for the analytics of every game created by the authenticated user.
+func (h HTTPServer) GetCreatorGameAnalytics(w http.ResponseWriter, r *http.Request) {
+	user, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	analytics, err := h.app.Queries.GetGameAnalytics.HandleCreator(r.Context(), user)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	render.Respond(w, r, analytics)
+}
+
+// GetGameAnalytics queries for the analytics of a game by UUID. The UUID is expressed in a URL param uuid.
+// Only the creator of the game may view its analytics.
+func (h HTTPServer) GetGameAnalytics(w http.ResponseWriter, r *http.Request, uuid string) {
+	user, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	analytics, err := h.app.Queries.GetGameAnalytics.Handle(r.Context(), user, uuid)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	render.Respond(w, r, analytics)
+}
+
+// CreateAPIKey expects the body of the request to have JSON in the form of CreateAPIKey.
+// The key is only ever returned in this response. Only admins may create API keys.
+func (h HTTPServer) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	admin, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	body := new(CreateAPIKey)
+
+	err = render.Decode(r, body)
+	if err != nil {
+		httperr.BadRequest("invalid-request-body", err, w, r)
+		return
+	}
+
+	cmd := createAPIKeyCommand(*body)
+
+	cmd.Admin = admin
+
+	resp, err := h.app.Commands.CreateAPIKey.Handle(r.Context(), cmd)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.Respond(w, r, resp)
+}
+
+// GetAPIKeys queries for every API key, newest first. Only admins may read API keys.
+func (h HTTPServer) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	admin, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	keys, err := h.app.Queries.GetAPIKeys.Handle(r.Context(), admin)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	render.Respond(w, r, keys)
+}
+
+// RevokeAPIKey revokes the API key with the ID in the URL param id. Only admins may revoke API keys.
+func (h HTTPServer) RevokeAPIKey(w http.ResponseWriter, r *http.Request, id string) {
+	admin, err := gameUserFromRequest(r)
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	err = h.app.Commands.RevokeAPIKey.Handle(r.Context(), command.RevokeAPIKey{
+		Admin: admin,
+		ID:    id,
+	})
+	if err != nil {
+		httperr.RespondWithSlugError(err, w, r)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,34 +1,52 @@
 package ports
 
 import (
-	"github.com/go-chi/chi"
+	"errors"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"gopher-cache/internal/common/openapi"
+	"gopher-cache/internal/common/server/httperr"
 	"net/http"
 )
 
-// ServerInterface represents the interface the server must have to satisfy the API.
-type ServerInterface interface {
-	// /games POST
-	CreateGame(w http.ResponseWriter, r *http.Request)
-	// /game-states POST
-	CreateGameState(w http.ResponseWriter, r *http.Request)
-	// /game-states/{player-number} PUT
-	UpdateGameState(w http.ResponseWriter, r *http.Request)
-	// /games GET
-	GetGames(w http.ResponseWriter, r *http.Request)
-	// /players/uuid GET
-	GetPlayer(w http.ResponseWriter, r *http.Request)
-	// /game-states/uuid GET
-	GetState(w http.ResponseWriter, r *http.Request)
+// The ServerInterface, routes and models of the API are generated from openapi.json, which is the source of
+// truth for the API.
+//go:generate go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen -generate types,chi-server,spec -package ports -o openapi.gen.go openapi.json
+
+// apiSpec is the loaded openapi.json the requests of the API are validated against.
+var apiSpec = mustLoadSpec()
+
+func mustLoadSpec() *openapi3.T {
+	doc, err := GetSwagger()
+	if err != nil {
+		panic(err)
+	}
+
+	return doc
 }
 
 // APIHandler binds a server implementing the ServerInterface to the games API using the given router.
+// Requests whose params or body don't match the spec are rejected before they reach the server.
 func APIHandler(si ServerInterface, r chi.Router) http.Handler {
-	r.Post("/games", si.CreateGame)
-	r.Post("/game-states", si.CreateGameState)
-	r.Put("/game-states/{player-number}", si.UpdateGameState)
-	r.Get("/games", si.GetGames)
-	r.Get("/players/{uuid}", si.GetPlayer)
-	r.Get("/game-states/{uuid}", si.GetState)
+	r.Use(openapi.NewRequestValidator(apiSpec, r))
+
+	HandlerWithOptions(si, ChiServerOptions{BaseRouter: r})
+
+	// Requests that match no route get the same error responses as the rest of the API.
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		httperr.NotFound("route-not-found", errors.New("no route for "+r.URL.Path), w, r)
+	})
+	r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		httperr.MethodNotAllowed("method-not-allowed", errors.New(r.Method+" isn't allowed for "+r.URL.Path), w, r)
+	})
 
 	return r
 }
+
+// APIDocsHandler serves the spec of the games API at /openapi.json and Swagger UI for it at /docs using the
+// given router. The paths are relative to where the router is mounted, which should be the same as the API.
+func APIDocsHandler(r chi.Router) {
+	r.Method(http.MethodGet, "/openapi.json", openapi.SpecHandler(apiSpec))
+	r.Method(http.MethodGet, "/docs", openapi.SwaggerUIHandler(apiSpec.Info.Title, "openapi.json", "docs"))
+	r.Method(http.MethodGet, "/docs/*", openapi.SwaggerUIAssetHandler())
+}
@@ -0,0 +1,120 @@
+package ports
+
+import (
+	"encoding/json"
+	"github.com/deepmap/oapi-codegen/pkg/codegen"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopher-cache/internal/common/logs"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGeneratedCodeIsUpToDate(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromFile("openapi.json")
+	require.NoError(t, err)
+
+	want, err := codegen.Generate(doc, "ports", codegen.Options{
+		GenerateTypes:     true,
+		GenerateChiServer: true,
+		EmbedSpec:         true,
+	})
+	require.NoError(t, err)
+
+	got, err := ioutil.ReadFile("openapi.gen.go")
+	require.NoError(t, err)
+
+	// The header names the module and version running the generator, which are this module's in a test.
+	withoutHeader := func(code string) string {
+		return code[strings.Index(code, "package ports"):]
+	}
+
+	assert.Equal(t, withoutHeader(want), withoutHeader(string(got)), "openapi.gen.go is out of date, run go generate")
+}
+
+// createGameServer only implements CreateGame. The other operations aren't called by the tests.
+type createGameServer struct {
+	ServerInterface
+	created []CreateGame
+}
+
+func (s *createGameServer) CreateGame(w http.ResponseWriter, r *http.Request) {
+	body := CreateGame{}
+	_ = render.Decode(r, &body)
+	s.created = append(s.created, body)
+}
+
+func TestAPIHandler(t *testing.T) {
+	si := &createGameServer{}
+
+	router := chi.NewRouter()
+	router.Use(logs.NewStructuredLogger(logrus.StandardLogger()))
+	APIDocsHandler(router)
+	router.Mount("/", APIHandler(si, chi.NewRouter()))
+
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		w := serve(http.MethodPost, "/games", `{
+			"title": "Hunt",
+			"description": "Find it",
+			"ending": "Found it",
+			"kind": "urban",
+			"levels": [{"title": "One", "description": "First", "answers": ["a"]}]
+		}`)
+
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Len(t, si.created, 1)
+		assert.Equal(t, "Hunt", si.created[0].Title)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		w := serve(http.MethodPost, "/games", `{"title": "Hunt", "kind": "rural", "levels": []}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{
+			"slug": "invalid-request",
+			"fields": [
+				{"field": "description", "rule": "required"},
+				{"field": "ending", "rule": "required"},
+				{"field": "kind", "rule": "enum"}
+			]
+		}`, w.Body.String())
+		assert.Len(t, si.created, 1, "invalid requests shouldn't reach the server")
+	})
+
+	t.Run("spec", func(t *testing.T) {
+		w := serve(http.MethodGet, "/openapi.json", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		want, err := json.Marshal(apiSpec)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(want), w.Body.String())
+	})
+
+	t.Run("docs", func(t *testing.T) {
+		w := serve(http.MethodGet, "/docs", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "swagger-ui")
+
+		w = serve(http.MethodGet, "/docs/swagger-ui-bundle.js", "")
+
+		assert.Equal(t, http.StatusOK, w.Code, "the assets of the docs should be bundled")
+	})
+}
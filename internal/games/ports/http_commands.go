@@ -0,0 +1,137 @@
+package ports
+
+import (
+	"gopher-cache/internal/games/app/command"
+)
+
+// The commands of requests with a body are created from the models generated from openapi.json. The fields
+// of a command that come from the URL, headers or authenticated user are left for the handler to set.
+
+func createGameCommand(body CreateGame) command.CreateGame {
+	cmd := command.CreateGame{
+		Title:       body.Title,
+		Description: body.Description,
+		Levels:      make([]command.GameLevel, len(body.Levels)),
+		Ending:      body.Ending,
+		Kind:        string(body.Kind),
+		City:        stringValue(body.City),
+		State:       stringValue(body.State),
+		Country:     stringValue(body.Country),
+		Location:    locationCommand(body.Location),
+		Locale:      stringValue(body.Locale),
+	}
+
+	for i, l := range body.Levels {
+		cmd.Levels[i] = command.GameLevel{
+			Title:       l.Title,
+			Description: l.Description,
+			Clues:       stringsValue(l.Clues),
+			Answers:     l.Answers,
+			Location:    locationCommand(l.Location),
+		}
+	}
+
+	if body.Clues != nil {
+		cmd.Clues = &command.ClueSettings{
+			WrongAnswersRevealClues: body.Clues.WrongAnswersRevealClues,
+			CooldownSeconds:         intValue(body.Clues.CooldownSeconds),
+		}
+	}
+
+	if body.Translations == nil {
+		return cmd
+	}
+
+	cmd.Translations = make([]command.GameTranslation, len(*body.Translations))
+	for i, t := range *body.Translations {
+		cmd.Translations[i] = command.GameTranslation{
+			Locale:      t.Locale,
+			Title:       t.Title,
+			Description: t.Description,
+			Ending:      t.Ending,
+			Levels:      make([]command.LevelTranslation, len(t.Levels)),
+		}
+
+		for j, l := range t.Levels {
+			cmd.Translations[i].Levels[j] = command.LevelTranslation{
+				Title:       l.Title,
+				Description: l.Description,
+				Clues:       l.Clues,
+				Answers:     stringsValue(l.Answers),
+			}
+		}
+	}
+
+	return cmd
+}
+
+func locationCommand(l *Location) *command.Location {
+	if l == nil {
+		return nil
+	}
+
+	return &command.Location{Latitude: l.Latitude, Longitude: l.Longitude}
+}
+
+func createGameStateCommand(body CreateGameState) command.CreateGameState {
+	return command.CreateGameState{GameUUID: body.GameUUID}
+}
+
+func updateGameStateCommand(body UpdateGameState) command.UpdateGameState {
+	return command.UpdateGameState{Input: body.Input}
+}
+
+func rateGameCommand(body RateGame) command.RateGame {
+	return command.RateGame{Stars: body.Stars, Review: stringValue(body.Review)}
+}
+
+func reportGameCommand(body ReportGame) command.ReportGame {
+	return command.ReportGame{Reason: string(body.Reason), Details: stringValue(body.Details)}
+}
+
+func moderateGameCommand(body ModerateGame) command.ModerateGame {
+	return command.ModerateGame{Publish: body.Publish}
+}
+
+func setPlayerLocaleCommand(body SetPlayerLocale) command.SetPlayerLocale {
+	return command.SetPlayerLocale{Locale: body.Locale}
+}
+
+func verifyNumberCommand(body VerifyNumber) command.VerifyNumber {
+	return command.VerifyNumber{Code: body.Code}
+}
+
+func createAPIKeyCommand(body CreateAPIKey) command.CreateAPIKey {
+	scopes := make([]string, len(body.Scopes))
+	for i, s := range body.Scopes {
+		scopes[i] = string(s)
+	}
+
+	return command.CreateAPIKey{Name: body.Name, Scopes: scopes, ExpiresAt: body.ExpiresAt}
+}
+
+// Optional fields of the models are pointers that are nil when they are left out of a body.
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func stringsValue(s *[]string) []string {
+	if s == nil {
+		return nil
+	}
+
+	return *s
+}
+
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+
+	return *i
+}
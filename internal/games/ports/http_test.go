@@ -0,0 +1,57 @@
+package ports
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gopher-cache/internal/common/auth"
+	"gopher-cache/internal/common/logs"
+	"gopher-cache/internal/games/adapters"
+	"gopher-cache/internal/games/app"
+	"gopher-cache/internal/games/app/query"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPServer_ReadScope(t *testing.T) {
+	projections := adapters.NewMemoryProjectionRepository()
+	cursors := query.NewCursorSigner([]byte("secret"))
+
+	server := NewHTTPServer(app.Application{Queries: app.Queries{
+		GetGames:         query.NewReadGamesHandler(projections, cursors),
+		SearchGames:      query.NewSearchGamesHandler(adapters.NewMemorySearchIndex(), cursors),
+		GetGameAnalytics: query.NewReadGameAnalyticsHandler(projections),
+	}})
+
+	tests := []struct {
+		name   string
+		user   auth.User
+		status int
+	}{
+		{"user", auth.User{UUID: "player-1", Number: "+15734497033"}, http.StatusOK},
+		{"key with read scope", auth.User{UUID: "api-key:1", Scopes: []string{"read-games"}}, http.StatusOK},
+		{"key without read scope", auth.User{UUID: "api-key:2", Scopes: []string{"create-games"}}, http.StatusForbidden},
+	}
+
+	router := chi.NewRouter()
+	router.Use(logs.NewStructuredLogger(logrus.StandardLogger()))
+	APIHandler(server, router)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, target := range []string{"/games", "/games/search?q=hunt", "/analytics/games"} {
+				r := httptest.NewRequest(http.MethodGet, target, nil)
+				r = r.WithContext(auth.ContextWithUser(r.Context(), tt.user))
+
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, r)
+
+				assert.Equal(t, tt.status, w.Code, target)
+				if tt.status == http.StatusForbidden {
+					assert.Contains(t, w.Body.String(), query.ErrorCannotReadGames.Slug(), target)
+				}
+			}
+		})
+	}
+}